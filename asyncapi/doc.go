@@ -0,0 +1,28 @@
+/*
+Package asyncapi reads AsyncAPI 2.x and 3.x documents for comparison.
+
+# Overview
+
+An AsyncAPI document describes the messages an event-driven application sends
+and receives on its channels. Parse reduces a document to what oasdiff compares:
+the channels, the operations on each channel, and the messages each operation
+carries, with their content type, payload and headers.
+
+	doc, err := asyncapi.Parse(data)
+
+# Operations
+
+Operations are keyed by their action within a channel. AsyncAPI 2.x names the
+actions from the client's point of view (publish, subscribe) and 3.x from the
+application's (receive, send), so a publish or receive operation carries the
+messages the application accepts, like the request of an HTTP operation, and a
+subscribe or send operation the messages it emits, like a response. In 3.x,
+operations with the same action on the same channel are merged.
+
+# Schemas
+
+Payload and header schemas are read as OpenAPI 3.1 schemas, that is JSON Schema,
+so diff.SchemaDiff compares them like any OpenAPI schema. $refs to
+components/schemas are kept as refs; other $refs must be local to the document.
+*/
+package asyncapi
@@ -0,0 +1,81 @@
+package asyncapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Operation actions in AsyncAPI 2.x (publish, subscribe) and 3.x (receive, send)
+const (
+	ActionPublish   = "publish"
+	ActionSubscribe = "subscribe"
+	ActionReceive   = "receive"
+	ActionSend      = "send"
+)
+
+// Document is an AsyncAPI document reduced to what oasdiff compares
+type Document struct {
+	// AsyncAPI is the version of the AsyncAPI specification, e.g. 2.6.0
+	AsyncAPI string
+	Info     *openapi3.Info
+	Channels Channels
+}
+
+// Channels maps channel addresses to channels
+type Channels map[string]*Channel
+
+// Channel is a channel and the operations on it
+type Channel struct {
+	Address    string
+	Operations Operations
+}
+
+// Operations maps actions to operations
+type Operations map[string]*Operation
+
+// Operation is what an application does on a channel: send or receive messages
+type Operation struct {
+	Action   string
+	Messages Messages
+
+	// OpenAPI is the operation as an OpenAPI operation, which oasdiff reports
+	// changes to operations with. It has the operation's id and extensions,
+	// and its messages: those received as a request body with a media type per
+	// message name, and those sent as responses with a status per message name.
+	OpenAPI *openapi3.Operation
+}
+
+// IsRequest reports whether the operation carries the messages the application
+// receives, which are to it what a request is to an HTTP server.
+func (operation *Operation) IsRequest() bool {
+	return operation.Action == ActionPublish || operation.Action == ActionReceive
+}
+
+// Messages maps message names to messages
+type Messages map[string]*Message
+
+// Message is a message that an operation carries
+type Message struct {
+	Name        string
+	ContentType string
+	Payload     *openapi3.SchemaRef
+	Headers     *openapi3.SchemaRef
+}
+
+// equivalentActions maps the actions of 2.x to those of 3.x and vice versa:
+// publish is receive and subscribe is send
+var equivalentActions = map[string]string{
+	ActionPublish:   ActionReceive,
+	ActionReceive:   ActionPublish,
+	ActionSubscribe: ActionSend,
+	ActionSend:      ActionSubscribe,
+}
+
+// Find returns the operation with the action, or with the equivalent action of
+// the other major version, so that a 2.x operation matches its 3.x successor.
+func (operations Operations) Find(action string) (*Operation, bool) {
+	if operation, ok := operations[action]; ok {
+		return operation, true
+	}
+	operation, ok := operations[equivalentActions[action]]
+	return operation, ok
+}
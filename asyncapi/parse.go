@@ -0,0 +1,482 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/yaml"
+)
+
+// maxRefDepth bounds the chain of $refs followed to resolve an object
+const maxRefDepth = 32
+
+// schemaPrefix names the payload and header schemas in the OpenAPI document
+// that Parse loads them from
+const schemaPrefix = "x-oasdiff-asyncapi-"
+
+// IsAsyncAPI reports whether data is an AsyncAPI document, that is, a mapping
+// with an asyncapi field.
+func IsAsyncAPI(data []byte) bool {
+	var probe struct {
+		AsyncAPI any `json:"asyncapi"`
+	}
+	if _, err := yaml.Unmarshal(data, &probe, yaml.DecodeOpts{DisableTimestamps: true}); err != nil {
+		return false
+	}
+	version, ok := probe.AsyncAPI.(string)
+	return ok && version != ""
+}
+
+// Parse reads an AsyncAPI 2.x or 3.x document in YAML or JSON
+func Parse(data []byte) (*Document, error) {
+	var root map[string]any
+	if _, err := yaml.Unmarshal(data, &root, yaml.DecodeOpts{DisableTimestamps: true}); err != nil {
+		return nil, err
+	}
+
+	version, _ := root["asyncapi"].(string)
+	p := &parser{
+		root:    root,
+		schemas: map[string]any{},
+	}
+
+	doc := &Document{
+		AsyncAPI: version,
+		Info:     getInfo(root),
+		Channels: Channels{},
+	}
+
+	var err error
+	switch {
+	case strings.HasPrefix(version, "2."):
+		err = p.parseV2(doc)
+	case strings.HasPrefix(version, "3."):
+		err = p.parseV3(doc)
+	default:
+		err = fmt.Errorf("unsupported AsyncAPI version %q, expected 2.x or 3.x", version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.loadSchemas(); err != nil {
+		return nil, err
+	}
+
+	for _, channel := range doc.Channels {
+		for _, operation := range channel.Operations {
+			setMessages(operation)
+		}
+	}
+
+	return doc, nil
+}
+
+// setMessages describes the messages of an operation in its OpenAPI operation:
+// those it receives as the request body, with a media type per message, and
+// those it sends as responses, with a response per message.
+func setMessages(operation *Operation) {
+	if operation.IsRequest() {
+		content := openapi3.Content{}
+		for name, message := range operation.Messages {
+			content[name] = &openapi3.MediaType{Schema: message.Payload}
+		}
+		operation.OpenAPI.RequestBody = &openapi3.RequestBodyRef{
+			Value: &openapi3.RequestBody{Required: true, Content: content},
+		}
+		return
+	}
+
+	responses := openapi3.NewResponses()
+	for name, message := range operation.Messages {
+		responses.Set(name, &openapi3.ResponseRef{
+			Value: &openapi3.Response{
+				Content: openapi3.Content{
+					message.ContentType: &openapi3.MediaType{Schema: message.Payload},
+				},
+			},
+		})
+	}
+	operation.OpenAPI.Responses = responses
+}
+
+// parser holds the document being parsed, and the payload and header schemas
+// to load once all messages are read
+type parser struct {
+	root    map[string]any
+	schemas map[string]any
+	targets []schemaTarget
+}
+
+type schemaTarget struct {
+	name   string
+	target **openapi3.SchemaRef
+}
+
+func getInfo(root map[string]any) *openapi3.Info {
+	info, _ := root["info"].(map[string]any)
+	title, _ := info["title"].(string)
+	version, _ := info["version"].(string)
+	description, _ := info["description"].(string)
+	return &openapi3.Info{
+		Title:       title,
+		Version:     version,
+		Description: description,
+	}
+}
+
+// parseV2 reads the channels of an AsyncAPI 2.x document, each with a publish
+// and a subscribe operation, which carry a message or a oneOf of messages
+func (p *parser) parseV2(doc *Document) error {
+	channels, _ := p.root["channels"].(map[string]any)
+	for _, address := range slices.Sorted(maps.Keys(channels)) {
+		channelItem, _, err := p.resolve(channels[address])
+		if err != nil {
+			return fmt.Errorf("channel %q: %w", address, err)
+		}
+
+		channel := &Channel{Address: address, Operations: Operations{}}
+		for _, action := range []string{ActionPublish, ActionSubscribe} {
+			if channelItem[action] == nil {
+				continue
+			}
+			operation, _, err := p.resolve(channelItem[action])
+			if err != nil {
+				return fmt.Errorf("channel %q: %s: %w", address, action, err)
+			}
+
+			messages, err := p.v2Messages(operation["message"])
+			if err != nil {
+				return fmt.Errorf("channel %q: %s: %w", address, action, err)
+			}
+
+			operationId, _ := operation["operationId"].(string)
+			channel.Operations[action] = &Operation{
+				Action:   action,
+				Messages: messages,
+				OpenAPI:  newOpenAPIOperation(operationId, operation),
+			}
+		}
+		doc.Channels[address] = channel
+	}
+	return nil
+}
+
+func (p *parser) v2Messages(node any) (Messages, error) {
+	result := Messages{}
+	if node == nil {
+		return result, nil
+	}
+
+	message, name, err := p.resolve(node)
+	if err != nil {
+		return nil, err
+	}
+
+	items, isOneOf := message["oneOf"].([]any)
+	if !isOneOf {
+		return result, p.addMessage(result, message, name, "message")
+	}
+
+	for i, item := range items {
+		message, name, err := p.resolve(item)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.addMessage(result, message, name, fmt.Sprintf("message%d", i+1)); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// parseV3 reads the channels of an AsyncAPI 3.x document and the operations on
+// them, which carry the messages they list or else all of their channel's
+func (p *parser) parseV3(doc *Document) error {
+	channels, _ := p.root["channels"].(map[string]any)
+	addresses := map[string]string{}
+	for _, key := range slices.Sorted(maps.Keys(channels)) {
+		channel, _, err := p.resolve(channels[key])
+		if err != nil {
+			return fmt.Errorf("channel %q: %w", key, err)
+		}
+		address, _ := channel["address"].(string)
+		if address == "" {
+			address = key
+		}
+		addresses[key] = address
+		doc.Channels[address] = &Channel{Address: address, Operations: Operations{}}
+	}
+
+	operations, _ := p.root["operations"].(map[string]any)
+	for _, operationId := range slices.Sorted(maps.Keys(operations)) {
+		if err := p.addV3Operation(doc, addresses, operationId, operations[operationId]); err != nil {
+			return fmt.Errorf("operation %q: %w", operationId, err)
+		}
+	}
+	return nil
+}
+
+func (p *parser) addV3Operation(doc *Document, addresses map[string]string, operationId string, node any) error {
+	operation, _, err := p.resolve(node)
+	if err != nil {
+		return err
+	}
+
+	action, _ := operation["action"].(string)
+	if action != ActionSend && action != ActionReceive {
+		return fmt.Errorf("invalid action %q, expected send or receive", action)
+	}
+
+	channelRef, _ := operation["channel"].(map[string]any)
+	ref, _ := channelRef["$ref"].(string)
+	tokens, err := pointerTokens(ref)
+	if err != nil || len(tokens) != 2 || tokens[0] != "channels" {
+		return fmt.Errorf("invalid channel $ref %q", ref)
+	}
+	channelKey := tokens[1]
+	address, ok := addresses[channelKey]
+	if !ok {
+		return fmt.Errorf("channel %q not found", channelKey)
+	}
+
+	messages := Messages{}
+	if refs, ok := operation["messages"].([]any); ok {
+		for _, item := range refs {
+			message, name, err := p.resolve(item)
+			if err != nil {
+				return err
+			}
+			if err := p.addMessage(messages, message, name, "message"); err != nil {
+				return err
+			}
+		}
+	} else {
+		channel, _, _ := p.resolve(p.root["channels"].(map[string]any)[channelKey])
+		channelMessages, _ := channel["messages"].(map[string]any)
+		for _, name := range slices.Sorted(maps.Keys(channelMessages)) {
+			message, _, err := p.resolve(channelMessages[name])
+			if err != nil {
+				return err
+			}
+			if err := p.addMessage(messages, message, name, "message"); err != nil {
+				return err
+			}
+		}
+	}
+
+	channel := doc.Channels[address]
+	if existing, ok := channel.Operations[action]; ok {
+		maps.Copy(existing.Messages, messages)
+		return nil
+	}
+	channel.Operations[action] = &Operation{
+		Action:   action,
+		Messages: messages,
+		OpenAPI:  newOpenAPIOperation(operationId, operation),
+	}
+	return nil
+}
+
+// addMessage adds a message to messages under the name it is referenced by, or
+// else its messageId or name, or else the fallback
+func (p *parser) addMessage(messages Messages, message map[string]any, name, fallback string) error {
+	if name == "" {
+		name, _ = message["messageId"].(string)
+	}
+	if name == "" {
+		name, _ = message["name"].(string)
+	}
+	if name == "" {
+		name = fallback
+	}
+
+	contentType, _ := message["contentType"].(string)
+	if contentType == "" {
+		contentType, _ = p.root["defaultContentType"].(string)
+	}
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	result := &Message{
+		Name:        name,
+		ContentType: contentType,
+	}
+
+	schemaFormat, _ := message["schemaFormat"].(string)
+	if err := p.addSchema(message["payload"], schemaFormat, &result.Payload); err != nil {
+		return fmt.Errorf("message %q: payload: %w", name, err)
+	}
+	if err := p.addSchema(message["headers"], "", &result.Headers); err != nil {
+		return fmt.Errorf("message %q: headers: %w", name, err)
+	}
+
+	messages[name] = result
+	return nil
+}
+
+// addSchema adds a schema to load into target. In 3.x a schema may be given
+// with its format, as a multi-format schema object.
+func (p *parser) addSchema(node any, schemaFormat string, target **openapi3.SchemaRef) error {
+	if node == nil {
+		return nil
+	}
+
+	schema, ok := node.(map[string]any)
+	if !ok {
+		return errors.New("a schema must be an object")
+	}
+
+	// a $ref to components/schemas is kept, for the loader to resolve like
+	// those within schemas, and others are resolved here
+	if ref, ok := schema["$ref"].(string); ok && !strings.HasPrefix(ref, "#/components/schemas/") {
+		resolved, _, err := p.resolve(schema)
+		if err != nil {
+			return err
+		}
+		schema = resolved
+	}
+
+	if format, ok := schema["schemaFormat"].(string); ok && schema["schema"] != nil {
+		schemaFormat = format
+		return p.addSchema(schema["schema"], schemaFormat, target)
+	}
+
+	if !isJSONSchemaFormat(schemaFormat) {
+		return fmt.Errorf("unsupported schema format %q", schemaFormat)
+	}
+
+	name := fmt.Sprintf("%s%d", schemaPrefix, len(p.targets)+1)
+	p.schemas[name] = schema
+	p.targets = append(p.targets, schemaTarget{name: name, target: target})
+	return nil
+}
+
+// isJSONSchemaFormat reports whether a schema format is one of JSON Schema's
+// dialects: the AsyncAPI, JSON Schema and OpenAPI formats
+func isJSONSchemaFormat(schemaFormat string) bool {
+	return schemaFormat == "" ||
+		strings.HasPrefix(schemaFormat, "application/vnd.aai.asyncapi") ||
+		strings.HasPrefix(schemaFormat, "application/schema+") ||
+		strings.HasPrefix(schemaFormat, "application/vnd.oai.openapi")
+}
+
+// loadSchemas loads the payload and header schemas with kin-openapi, which
+// resolves their $refs, as schemas of an OpenAPI document along with the
+// document's components/schemas
+func (p *parser) loadSchemas() error {
+	if len(p.targets) == 0 {
+		return nil
+	}
+
+	components, _ := p.root["components"].(map[string]any)
+	if schemas, ok := components["schemas"].(map[string]any); ok {
+		for name, schema := range schemas {
+			p.schemas[name] = schema
+		}
+	}
+
+	data, err := json.Marshal(map[string]any{
+		"openapi": "3.1.0",
+		"info":    map[string]any{"title": "", "version": ""},
+		"paths":   map[string]any{},
+		"components": map[string]any{
+			"schemas": p.schemas,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	spec, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return fmt.Errorf("failed to load schemas: %w", err)
+	}
+
+	for _, target := range p.targets {
+		*target.target = spec.Components.Schemas[target.name]
+	}
+	return nil
+}
+
+// resolve follows node's $ref, if any, to an object in the document, and
+// returns the object and the last token of the ref, which names it
+func (p *parser) resolve(node any) (map[string]any, string, error) {
+	name := ""
+	for range maxRefDepth {
+		object, ok := node.(map[string]any)
+		if !ok {
+			return nil, "", errors.New("expected an object")
+		}
+
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return object, name, nil
+		}
+
+		tokens, err := pointerTokens(ref)
+		if err != nil {
+			return nil, "", err
+		}
+
+		node = p.root
+		for _, token := range tokens {
+			mapping, ok := node.(map[string]any)
+			if !ok {
+				return nil, "", fmt.Errorf("$ref %q not found", ref)
+			}
+			if node, ok = mapping[token]; !ok {
+				return nil, "", fmt.Errorf("$ref %q not found", ref)
+			}
+		}
+		if len(tokens) > 0 {
+			name = tokens[len(tokens)-1]
+		}
+	}
+	return nil, "", errors.New("too many nested $refs")
+}
+
+// pointerTokens returns the tokens of a $ref to a JSON pointer within the
+// document, e.g. #/components/messages/userSignedUp
+func pointerTokens(ref string) ([]string, error) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, fmt.Errorf("$ref %q is not supported, only $refs within the document are", ref)
+	}
+	pointer, err := url.PathUnescape(pointer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid $ref %q: %w", ref, err)
+	}
+	pointer = strings.TrimPrefix(pointer, "/")
+	if pointer == "" {
+		return nil, nil
+	}
+
+	tokens := strings.Split(pointer, "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// newOpenAPIOperation returns an OpenAPI operation with the id and the
+// extensions of an AsyncAPI operation; setMessages adds its messages
+func newOpenAPIOperation(operationId string, operation map[string]any) *openapi3.Operation {
+	result := &openapi3.Operation{OperationID: operationId}
+	for key, value := range operation {
+		if strings.HasPrefix(key, "x-") {
+			if result.Extensions == nil {
+				result.Extensions = map[string]any{}
+			}
+			result.Extensions[key] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+package asyncapi_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/oasdiff/oasdiff/asyncapi"
+	"github.com/stretchr/testify/require"
+)
+
+func parse(t *testing.T, file string) *asyncapi.Document {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	doc, err := asyncapi.Parse(data)
+	require.NoError(t, err)
+	return doc
+}
+
+func TestIsAsyncAPI(t *testing.T) {
+	require.True(t, asyncapi.IsAsyncAPI([]byte("asyncapi: 3.0.0\ninfo: {}\n")))
+	require.True(t, asyncapi.IsAsyncAPI([]byte(`{"asyncapi": "2.6.0"}`)))
+	require.False(t, asyncapi.IsAsyncAPI([]byte("openapi: 3.0.3\n")))
+	require.False(t, asyncapi.IsAsyncAPI([]byte("- asyncapi\n")))
+}
+
+func TestParse_V2(t *testing.T) {
+	doc := parse(t, "../data/asyncapi/base-v2.yaml")
+	require.Equal(t, "2.6.0", doc.AsyncAPI)
+	require.Equal(t, "Account Service", doc.Info.Title)
+	require.Len(t, doc.Channels, 3)
+
+	publish := doc.Channels["user/signup"].Operations[asyncapi.ActionPublish]
+	require.True(t, publish.IsRequest())
+	require.Equal(t, "signUp", publish.OpenAPI.OperationID)
+	signUp := publish.Messages["SignUp"]
+	require.Equal(t, "application/json", signUp.ContentType)
+	require.Equal(t, []string{"email"}, signUp.Payload.Value.Required)
+
+	subscribe := doc.Channels["user/signedup"].Operations[asyncapi.ActionSubscribe]
+	require.False(t, subscribe.IsRequest())
+	payload := subscribe.Messages["UserSignedUp"].Payload
+	require.Equal(t, "#/components/schemas/User", payload.Ref)
+	require.Contains(t, payload.Value.Properties, "email")
+
+	// an inline message is named by its messageId
+	require.Contains(t, doc.Channels["user/deleted"].Operations[asyncapi.ActionSubscribe].Messages, "UserDeleted")
+}
+
+func TestParse_V2OneOf(t *testing.T) {
+	doc := parse(t, "../data/asyncapi/revision-v2.yaml")
+	messages := doc.Channels["user/signedup"].Operations[asyncapi.ActionSubscribe].Messages
+	require.Len(t, messages, 2)
+	require.Equal(t, "application/avro", messages["UserInvited"].ContentType)
+}
+
+func TestParse_V3(t *testing.T) {
+	doc := parse(t, "../data/asyncapi/base-v3.yaml")
+	require.Equal(t, "3.0.0", doc.AsyncAPI)
+	require.Len(t, doc.Channels, 3)
+
+	// an operation without messages carries all of its channel's
+	receive := doc.Channels["user/signup"].Operations[asyncapi.ActionReceive]
+	require.True(t, receive.IsRequest())
+	require.Contains(t, receive.Messages, "SignUp")
+
+	// a multi-format schema is unwrapped
+	send := doc.Channels["user/signedup"].Operations[asyncapi.ActionSend]
+	require.Equal(t, "onUserSignedUp", send.OpenAPI.OperationID)
+	require.Equal(t, "#/components/schemas/User", send.Messages["UserSignedUp"].Payload.Ref)
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := asyncapi.Parse([]byte("asyncapi: 1.2.0\n"))
+	require.ErrorContains(t, err, "unsupported AsyncAPI version")
+
+	_, err = asyncapi.Parse([]byte(`
+asyncapi: 2.6.0
+channels:
+  events:
+    subscribe:
+      message:
+        $ref: 'other.yaml#/Event'
+`))
+	require.ErrorContains(t, err, "only $refs within the document are")
+
+	_, err = asyncapi.Parse([]byte(`
+asyncapi: 2.6.0
+channels:
+  events:
+    subscribe:
+      message:
+        schemaFormat: application/vnd.apache.avro;version=1.9.0
+        payload:
+          type: record
+`))
+	require.ErrorContains(t, err, "unsupported schema format")
+}
@@ -29,6 +29,10 @@ type ApiChange struct {
 	// transition's own finding. Set by WithSchema.
 	claimed bool
 
+	// message renders the text of a change reported by a custom rule (see custom_rules.go),
+	// whose id has no localized message
+	message *customMessage
+
 	// DEPRECATED: Will be removed after migration to BaseSource/RevisionSource
 	SourceFile      string
 	SourceLine      int
@@ -47,7 +51,7 @@ func NewApiChange(id string, config *Config, args []any, comment string, operati
 		OperationId: operation.OperationID,
 		Operation:   method,
 		Path:        path,
-		Source:      load.NewSource((*operationsSources)[operation]),
+		Source:      load.NewSource(getOperationFile(operationsSources, operation)),
 		CommonChange: CommonChange{
 			Attributes: getAttributes(config, operation),
 		},
@@ -114,6 +118,9 @@ func (c ApiChange) GetId() string {
 }
 
 func (c ApiChange) GetText(l Localizer) string {
+	if c.message != nil {
+		return c.message.render(c, colorizedValues) + c.getDetailsSuffix()
+	}
 	return l(c.Id, colorizedValues(c.Args)...) + c.getDetailsSuffix()
 }
 
@@ -122,6 +129,9 @@ func (c ApiChange) GetArgs() []any {
 }
 
 func (c ApiChange) GetUncolorizedText(l Localizer) string {
+	if c.message != nil {
+		return c.message.render(c, quotedValues) + c.getDetailsSuffix()
+	}
 	return l(c.Id, quotedValues(c.Args)...) + c.getDetailsSuffix()
 }
 
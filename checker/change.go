@@ -36,6 +36,15 @@ type CommonChange struct {
 	RevisionSource *Source // Location in revision (modified) file
 
 	Attributes map[string]any
+
+	// Consumers are the consumers that the change affects, when filtered by consumer usage
+	Consumers []string
+
+	// UsageCount is the number of calls observed to the change's endpoint, when traffic is given
+	UsageCount *int64
+
+	// Verification is the outcome of looking for a counterexample to the change, when verified
+	Verification *Verification
 }
 
 func (c CommonChange) GetBaseSource() *Source {
@@ -49,3 +58,43 @@ func (c CommonChange) GetRevisionSource() *Source {
 func (c CommonChange) GetAttributes() map[string]any {
 	return c.Attributes
 }
+
+func (c CommonChange) GetConsumers() []string {
+	return c.Consumers
+}
+
+// GetConsumers returns the consumers that the change affects, when filtered by consumer usage.
+// It isn't part of Change, so that changes implemented outside this package keep satisfying it; they may provide it
+// by embedding CommonChange or by implementing GetConsumers.
+func GetConsumers(change Change) []string {
+	if c, ok := change.(interface{ GetConsumers() []string }); ok {
+		return c.GetConsumers()
+	}
+	return nil
+}
+
+func (c CommonChange) GetUsageCount() *int64 {
+	return c.UsageCount
+}
+
+// GetUsageCount returns the number of calls observed to the change's endpoint, when traffic is given.
+// Like GetConsumers, it isn't part of Change.
+func GetUsageCount(change Change) *int64 {
+	if c, ok := change.(interface{ GetUsageCount() *int64 }); ok {
+		return c.GetUsageCount()
+	}
+	return nil
+}
+
+func (c CommonChange) GetVerification() *Verification {
+	return c.Verification
+}
+
+// GetVerification returns the outcome of looking for a counterexample to the change, when verified.
+// Like GetConsumers, it isn't part of Change.
+func GetVerification(change Change) *Verification {
+	if c, ok := change.(interface{ GetVerification() *Verification }); ok {
+		return c.GetVerification()
+	}
+	return nil
+}
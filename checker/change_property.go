@@ -0,0 +1,75 @@
+package checker
+
+import "strings"
+
+// propertyArgIndex maps the property rules that don't name the property by
+// their first argument to the index of the argument that does, e.g. the enum
+// value removed from a property comes before the property.
+var propertyArgIndex = map[string]int{
+	RequestPropertyEnumValueAddedId:              1,
+	RequestPropertyEnumValueRemovedId:            1,
+	RequestReadOnlyPropertyEnumValueRemovedId:    1,
+	RequestPropertyXExtensibleEnumValueRemovedId: 1,
+	ResponsePropertyEnumValueAddedId:             1,
+	ResponseWriteOnlyPropertyEnumValueAddedId:    1,
+	ResponsePropertyEnumValueRemovedId:           1,
+
+	RequestPropertyPatternAddedId:   1,
+	RequestPropertyPatternRemovedId: 1,
+
+	RequestPropertyAllOfAddedId:                  1,
+	RequestPropertyAllOfRemovedId:                1,
+	RequestPropertyAllOfAddedAnnotationOnlyId:    1,
+	RequestPropertyAllOfRemovedAnnotationOnlyId:  1,
+	ResponsePropertyAllOfAddedId:                 1,
+	ResponsePropertyAllOfRemovedId:               1,
+	ResponsePropertyAllOfAddedAnnotationOnlyId:   1,
+	ResponsePropertyAllOfRemovedAnnotationOnlyId: 1,
+	RequestPropertyAnyOfAddedId:                  1,
+	RequestPropertyAnyOfRemovedId:                1,
+	ResponsePropertyAnyOfAddedId:                 1,
+	ResponsePropertyAnyOfRemovedId:               1,
+	RequestPropertyOneOfAddedId:                  1,
+	RequestPropertyOneOfRemovedId:                1,
+	ResponsePropertyOneOfAddedId:                 1,
+	ResponsePropertyOneOfRemovedId:               1,
+	RequestPropertyPrefixItemsAddedId:            1,
+	RequestPropertyPrefixItemsRemovedId:          1,
+	ResponsePropertyPrefixItemsAddedId:           1,
+	ResponsePropertyPrefixItemsRemovedId:         1,
+
+	RequestPropertyDependentSchemaAddedId:    1,
+	RequestPropertyDependentSchemaRemovedId:  1,
+	ResponsePropertyDependentSchemaAddedId:   1,
+	ResponsePropertyDependentSchemaRemovedId: 1,
+	RequestPropertyPatternPropertyAddedId:    1,
+	RequestPropertyPatternPropertyRemovedId:  1,
+	ResponsePropertyPatternPropertyAddedId:   1,
+	ResponsePropertyPatternPropertyRemovedId: 1,
+
+	RequestPropertyDiscriminatorMappingAddedId:    1,
+	RequestPropertyDiscriminatorMappingDeletedId:  1,
+	RequestPropertyDiscriminatorMappingChangedId:  3,
+	ResponsePropertyDiscriminatorMappingAddedId:   1,
+	ResponsePropertyDiscriminatorMappingDeletedId: 1,
+	ResponsePropertyDiscriminatorMappingChangedId: 3,
+}
+
+// getChangedProperty returns the path of the property that a property rule's
+// change names. The rules about the body itself, like request-body-pattern-property-added,
+// name no property even though their ids mention one.
+func getChangedProperty(id string, args []any) (string, bool) {
+	if !strings.Contains(id, "property") || strings.HasPrefix(id, "request-body-") || strings.HasPrefix(id, "response-body-") {
+		return "", false
+	}
+
+	index := propertyArgIndex[id]
+	if index >= len(args) {
+		return "", false
+	}
+	property, ok := args[index].(string)
+	if !ok || property == "" {
+		return "", false
+	}
+	return property, true
+}
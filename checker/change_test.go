@@ -0,0 +1,33 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/stretchr/testify/require"
+)
+
+// externalChange is a Change implemented outside the checker package, which has only the methods of Change
+type externalChange struct {
+	checker.Change
+}
+
+func TestGetConsumers(t *testing.T) {
+	change := checker.ApiChange{CommonChange: checker.CommonChange{Consumers: []string{"mobile"}}}
+	require.Equal(t, []string{"mobile"}, checker.GetConsumers(change))
+	require.Nil(t, checker.GetConsumers(externalChange{Change: change}))
+}
+
+func TestGetUsageCount(t *testing.T) {
+	count := int64(1200)
+	change := checker.ApiChange{CommonChange: checker.CommonChange{UsageCount: &count}}
+	require.Equal(t, &count, checker.GetUsageCount(change))
+	require.Nil(t, checker.GetUsageCount(externalChange{Change: change}))
+}
+
+func TestGetVerification(t *testing.T) {
+	verification := &checker.Verification{Confirmed: true}
+	change := checker.ApiChange{CommonChange: checker.CommonChange{Verification: verification}}
+	require.Equal(t, verification, checker.GetVerification(change))
+	require.Nil(t, checker.GetVerification(externalChange{Change: change}))
+}
@@ -3,7 +3,6 @@ package checker
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"cloud.google.com/go/civil"
 	"github.com/oasdiff/oasdiff/diff"
@@ -120,7 +119,7 @@ func APIDeprecationCheck(diffReport *diff.Diff, operationsSources *diff.Operatio
 				continue
 			}
 
-			days := date.DaysSince(civil.DateOf(time.Now()))
+			days := date.DaysSince(config.getAsOf())
 
 			if days < int(deprecationDays) {
 				result = append(result, NewApiChange(
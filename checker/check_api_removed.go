@@ -1,9 +1,6 @@
 package checker
 
 import (
-	"time"
-
-	"cloud.google.com/go/civil"
 	"github.com/oasdiff/oasdiff/diff"
 )
 
@@ -104,7 +101,7 @@ func checkAPIRemoval(opInfo opInfo, isPath bool) Change {
 		).WithSources(baseSource, nil)
 	}
 
-	if civil.DateOf(time.Now()).Before(date) {
+	if opInfo.config.getAsOf().Before(date) {
 		return opInfo.NewApiChange(
 			getBeforeSunsetId(isPath),
 			[]any{date},
@@ -2,9 +2,7 @@ package checker
 
 import (
 	"slices"
-	"time"
 
-	"cloud.google.com/go/civil"
 	"github.com/oasdiff/oasdiff/diff"
 )
 
@@ -24,8 +22,8 @@ func APISunsetChangedCheck(diffReport *diff.Diff, operationsSources *diff.Operat
 			continue
 		}
 		for operation, operationDiff := range pathItem.OperationsDiff.Modified {
-			opRevision := pathItem.Revision.GetOperation(operation)
-			opBase := pathItem.Base.GetOperation(operation)
+			opRevision := operationDiff.Revision
+			opBase := operationDiff.Base
 			baseSource, revisionSource := operationFieldSources(operationsSources, operationDiff, diff.SunsetExtension)
 
 			if !opRevision.Deprecated {
@@ -68,7 +66,7 @@ func APISunsetChangedCheck(diffReport *diff.Diff, operationsSources *diff.Operat
 				continue
 			}
 
-			days := date.DaysSince(civil.DateOf(time.Now()))
+			days := date.DaysSince(config.getAsOf())
 
 			stability, err := getStabilityLevel(opRevision.Extensions)
 			if err != nil {
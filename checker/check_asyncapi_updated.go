@@ -0,0 +1,111 @@
+package checker
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/oasdiff/oasdiff/asyncapi"
+	"github.com/oasdiff/oasdiff/diff"
+)
+
+const (
+	AsyncAPIChannelAddedId              = "asyncapi-channel-added"
+	AsyncAPIChannelRemovedId            = "asyncapi-channel-removed"
+	AsyncAPIOperationAddedId            = "asyncapi-operation-added"
+	AsyncAPIOperationRemovedId          = "asyncapi-operation-removed"
+	AsyncAPIMessageAddedId              = "asyncapi-message-added"
+	AsyncAPIMessageRemovedId            = "asyncapi-message-removed"
+	AsyncAPIMessageContentTypeChangedId = "asyncapi-message-content-type-changed"
+)
+
+// AsyncAPIUpdatedCheck reports the channels, operations and messages added to
+// or removed from an AsyncAPI document, and changes to the content type of
+// messages. Changes to message payloads are reported by the request and
+// response checks (see mergeAsyncAPIMessagesIntoPathsDiff).
+//
+// Changes are reported per operation, with the operation's action as the
+// method and the channel's address as the path.
+func AsyncAPIUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+
+	if diffReport.AsyncAPIDiff == nil || diffReport.AsyncAPIDiff.ChannelsDiff == nil {
+		return result
+	}
+	channelsDiff := diffReport.AsyncAPIDiff.ChannelsDiff
+
+	newChange := func(id string, args []any, operation *asyncapi.Operation, address string) ApiChange {
+		return NewApiChange(
+			id,
+			config,
+			args,
+			"",
+			operationsSources,
+			operation.OpenAPI,
+			strings.ToUpper(operation.Action),
+			address,
+		)
+	}
+
+	for _, address := range channelsDiff.Added {
+		for _, operation := range sortedOperations(channelsDiff.Revision[address]) {
+			result = append(result, newChange(AsyncAPIChannelAddedId, []any{}, operation, address))
+		}
+	}
+
+	for _, address := range channelsDiff.Deleted {
+		for _, operation := range sortedOperations(channelsDiff.Base[address]) {
+			result = append(result, newChange(AsyncAPIChannelRemovedId, []any{}, operation, address))
+		}
+	}
+
+	for _, address := range slices.Sorted(maps.Keys(channelsDiff.Modified)) {
+		channelDiff := channelsDiff.Modified[address]
+		operationsDiff := channelDiff.OperationsDiff
+
+		for _, action := range operationsDiff.Added {
+			result = append(result, newChange(AsyncAPIOperationAddedId, []any{}, channelDiff.Revision.Operations[action], address))
+		}
+
+		for _, action := range operationsDiff.Deleted {
+			result = append(result, newChange(AsyncAPIOperationRemovedId, []any{}, channelDiff.Base.Operations[action], address))
+		}
+
+		for _, action := range slices.Sorted(maps.Keys(operationsDiff.Modified)) {
+			operationDiff := operationsDiff.Modified[action]
+			messagesDiff := operationDiff.MessagesDiff
+			if messagesDiff == nil {
+				continue
+			}
+
+			for _, name := range messagesDiff.Added {
+				result = append(result, newChange(AsyncAPIMessageAddedId, []any{name}, operationDiff.Revision, address))
+			}
+
+			for _, name := range messagesDiff.Deleted {
+				result = append(result, newChange(AsyncAPIMessageRemovedId, []any{name}, operationDiff.Revision, address))
+			}
+
+			for _, name := range slices.Sorted(maps.Keys(messagesDiff.Modified)) {
+				contentTypeDiff := messagesDiff.Modified[name].ContentTypeDiff
+				if contentTypeDiff == nil {
+					continue
+				}
+				result = append(result, newChange(AsyncAPIMessageContentTypeChangedId, []any{name, contentTypeDiff.From, contentTypeDiff.To}, operationDiff.Revision, address))
+			}
+		}
+	}
+
+	return result
+}
+
+func sortedOperations(channel *asyncapi.Channel) []*asyncapi.Operation {
+	if channel == nil {
+		return nil
+	}
+	result := make([]*asyncapi.Operation, 0, len(channel.Operations))
+	for _, action := range slices.Sorted(maps.Keys(channel.Operations)) {
+		result = append(result, channel.Operations[action])
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/stretchr/testify/require"
+)
+
+func getAsyncAPIChanges(t *testing.T, config *checker.Config, base, revision string) checker.Changes {
+	t.Helper()
+	s1, err := open(base)
+	require.NoError(t, err)
+	s2, err := open(revision)
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	return checker.CheckBackwardCompatibilityUntilLevel(config, d, osm, checker.INFO)
+}
+
+// removing a channel and adding a message
+func TestAsyncAPIUpdated_V2(t *testing.T) {
+	errs := getAsyncAPIChanges(t, singleCheckConfig(checker.AsyncAPIUpdatedCheck), "../data/asyncapi/base-v2.yaml", "../data/asyncapi/revision-v2.yaml")
+	require.Len(t, errs, 2)
+
+	require.Equal(t, checker.AsyncAPIChannelRemovedId, errs[0].GetId())
+	require.Equal(t, checker.ERR, errs[0].GetLevel())
+	require.Equal(t, "SUBSCRIBE", errs[0].GetOperation())
+	require.Equal(t, "user/deleted", errs[0].GetPath())
+	require.Equal(t, "onUserDeleted", errs[0].(checker.ApiChange).OperationId)
+	require.Equal(t, "../data/asyncapi/base-v2.yaml", errs[0].GetSource())
+
+	require.Equal(t, checker.AsyncAPIMessageAddedId, errs[1].GetId())
+	require.Equal(t, checker.INFO, errs[1].GetLevel())
+	require.Equal(t, []any{"UserInvited"}, errs[1].GetArgs())
+	require.Equal(t, "user/signedup", errs[1].GetPath())
+}
+
+// changing the content type of a message
+func TestAsyncAPIUpdated_ContentType(t *testing.T) {
+	errs := getAsyncAPIChanges(t, singleCheckConfig(checker.AsyncAPIUpdatedCheck), "../data/asyncapi/base-v3.yaml", "../data/asyncapi/revision-v3.yaml")
+	require.Len(t, errs, 2)
+
+	require.Equal(t, checker.AsyncAPIChannelRemovedId, errs[0].GetId())
+	require.Equal(t, "SEND", errs[0].GetOperation())
+
+	require.Equal(t, checker.AsyncAPIMessageContentTypeChangedId, errs[1].GetId())
+	require.Equal(t, []any{"UserSignedUp", "application/json", "application/cloudevents+json"}, errs[1].GetArgs())
+	require.Equal(t, "the content type of the message `UserSignedUp` changed from `application/json` to `application/cloudevents+json`", errs[1].GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+// payload changes are reported by the request and response checks: messages
+// received as request bodies and messages sent as responses
+func TestAsyncAPIUpdated_Payloads(t *testing.T) {
+	errs := getAsyncAPIChanges(t, allChecksConfig(), "../data/asyncapi/base-v3.yaml", "../data/asyncapi/revision-v3.yaml")
+
+	ids := map[string]checker.Change{}
+	for _, change := range errs {
+		ids[change.GetId()] = change
+	}
+
+	require.Contains(t, ids, checker.NewRequiredRequestPropertyId)
+	require.Equal(t, "RECEIVE", ids[checker.NewRequiredRequestPropertyId].GetOperation())
+	require.Equal(t, "user/signup", ids[checker.NewRequiredRequestPropertyId].GetPath())
+
+	require.Contains(t, ids, checker.ResponseRequiredPropertyRemovedId)
+	require.Equal(t, "SEND", ids[checker.ResponseRequiredPropertyRemovedId].GetOperation())
+	require.Equal(t, "user/signedup", ids[checker.ResponseRequiredPropertyRemovedId].GetPath())
+}
+
+// no AsyncAPI changes
+func TestAsyncAPIUpdated_NoChanges(t *testing.T) {
+	errs := getAsyncAPIChanges(t, allChecksConfig(), "../data/asyncapi/base-v2.yaml", "../data/asyncapi/base-v2.yaml")
+	require.Empty(t, errs)
+}
@@ -431,7 +431,7 @@ func TestBreaking_AddPatternRecursive(t *testing.T) {
 	require.Equal(t, "added the pattern `^[a-z]+$` to the request property `data/created`", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
 }
 
-// modifying a pattern in a schema is breaking
+// modifying a pattern in a schema to a pattern that accepts every previously accepted value is not breaking
 func TestBreaking_ModifyPattern(t *testing.T) {
 	s1, err := open("../data/pattern-base.yaml")
 	require.NoError(t, err)
@@ -442,10 +442,11 @@ func TestBreaking_ModifyPattern(t *testing.T) {
 	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
 	require.NoError(t, err)
 	errs := checker.CheckBackwardCompatibility(allChecksConfig(), d, osm)
-	require.NotEmpty(t, errs)
-	requireSingleChange(t, errs, checker.RequestPropertyPatternChangedId)
-	require.Equal(t, "changed the pattern of the request property `created` from `^[a-z]+$` to `.+`", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
-	require.Equal(t, checker.WARN, errs[0].GetLevel())
+	require.Empty(t, errs)
+
+	errs = checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyPatternUpdatedCheck), d, osm, checker.INFO)
+	requireSingleChange(t, errs, checker.RequestPropertyPatternGeneralizedId)
+	require.Equal(t, "changed the pattern of the request property `created` from `^[a-z]+$` to a more general pattern `.+`", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
 }
 
 // modifying a pattern to .* in a schema is not breaking
@@ -1,6 +1,7 @@
 package checker
 
 import (
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oasdiff/oasdiff/diff"
 )
 
@@ -13,16 +14,50 @@ const (
 	APIComponentSecurityOauthScopeAddedId           = "api-security-component-oauth-scope-added"
 	APIComponentSecurityOauthScopeRemovedId         = "api-security-component-oauth-scope-removed"
 	APIComponentSecurityOauthScopeUpdatedId         = "api-security-component-oauth-scope-changed"
+
+	APIComponentsSecurityRemovedWithDeprecationId          = "api-security-component-removed-with-deprecation"
+	APIComponentsSecurityRemovedBeforeSunsetId             = "api-security-component-removed-before-sunset"
+	APIComponentsSecuritySunsetParseId                     = "api-security-component-sunset-parse"
+	APIComponentSecurityOauthScopeRemovedWithDeprecationId = "api-security-component-oauth-scope-removed-with-deprecation"
+	APIComponentSecurityOauthScopeRemovedBeforeSunsetId    = "api-security-component-oauth-scope-removed-before-sunset"
+	APIComponentSecurityOauthScopeSunsetParseId            = "api-security-component-oauth-scope-sunset-parse"
 )
 
 const ComponentSecuritySchemes = "securitySchemes"
 
+// A security scheme has no deprecated field, so it is deprecated with the x-deprecated extension
+var securitySchemeRemovalIds = removalIds{
+	withoutDeprecation: APIComponentsSecurityRemovedId,
+	withDeprecation:    APIComponentsSecurityRemovedWithDeprecationId,
+	beforeSunset:       APIComponentsSecurityRemovedBeforeSunsetId,
+	sunsetParse:        APIComponentsSecuritySunsetParseId,
+}
+
+// OAuth scopes are deprecated with the x-deprecated-scopes extension of their flow
+var oauthScopeRemovalIds = removalIds{
+	withoutDeprecation: APIComponentSecurityOauthScopeRemovedId,
+	withDeprecation:    APIComponentSecurityOauthScopeRemovedWithDeprecationId,
+	beforeSunset:       APIComponentSecurityOauthScopeRemovedBeforeSunsetId,
+	sunsetParse:        APIComponentSecurityOauthScopeSunsetParseId,
+}
+
+// getImplicitFlowDeprecatedScopes returns the deprecated scopes of the implicit flow of a security scheme, the only
+// flow whose scopes are checked
+func getImplicitFlowDeprecatedScopes(scheme *openapi3.SecurityScheme) map[string]any {
+	if scheme == nil || scheme.Flows == nil || scheme.Flows.Implicit == nil {
+		return nil
+	}
+	return getDeprecatedScopes(scheme.Flows.Implicit.Extensions)
+}
+
 // checkOAuthUpdates reports oauth flow changes for a modified security scheme.
 // baseSource/revisionSource locate the scheme in each spec: added scopes are
 // reported against the revision, removed scopes against the base, and in-place
 // changes (urls, scope value) against both, matching the source convention used
 // elsewhere.
-func checkOAuthUpdates(updatedSecurity *diff.SecuritySchemeDiff, updatedSecurityName string, baseSource, revisionSource *Source) Changes {
+// baseScheme is the scheme in the base spec, whose x-deprecated-scopes tell
+// which of the removed scopes were deprecated.
+func checkOAuthUpdates(config *Config, updatedSecurity *diff.SecuritySchemeDiff, updatedSecurityName string, baseScheme *openapi3.SecurityScheme, baseSource, revisionSource *Source) Changes {
 	result := make(Changes, 0)
 
 	if updatedSecurity.OAuthFlowsDiff == nil {
@@ -61,11 +96,20 @@ func checkOAuthUpdates(updatedSecurity *diff.SecuritySchemeDiff, updatedSecurity
 			}.WithSources(nil, revisionSource))
 		}
 
+		deprecatedScopes := getImplicitFlowDeprecatedScopes(baseScheme)
 		for _, removedScope := range scopesDiff.Deleted {
+			args := []any{updatedSecurityName, removedScope}
+			removal, ok := oauthScopeRemovalIds.newRemoval(config, APIComponentSecurityOauthScopeRemovedId, args), true
+			if sunset, deprecated := deprecatedScopes[removedScope]; deprecated {
+				removal, ok = oauthScopeRemovalIds.getDeprecatedRemoval(config, sunset, true, args...)
+			}
+			if !ok {
+				continue
+			}
 			result = append(result, ComponentChange{
-				Id:        APIComponentSecurityOauthScopeRemovedId,
-				Level:     INFO,
-				Args:      []any{updatedSecurityName, removedScope},
+				Id:        removal.id,
+				Level:     removal.level,
+				Args:      removal.args,
 				Component: ComponentSecuritySchemes,
 			}.WithSources(baseSource, nil))
 		}
@@ -110,27 +154,35 @@ func APIComponentsSecurityUpdatedCheck(diffReport *diff.Diff, operationsSources
 
 	for _, updatedSecurity := range diffReport.ComponentsDiff.SecuritySchemesDiff.Deleted {
 		var baseSource *Source
+		var extensions map[string]any
 		if ref := diffReport.ComponentsDiff.SecuritySchemesDiff.Base[updatedSecurity]; ref != nil && ref.Value != nil {
 			baseSource = sourceFromOrigin(ref.Value.Origin)
+			extensions = ref.Value.Extensions
+		}
+		removal, ok := securitySchemeRemovalIds.getRemoval(config, false, extensions, updatedSecurity)
+		if !ok {
+			continue
 		}
 		result = append(result, ComponentChange{
-			Id:        APIComponentsSecurityRemovedId,
-			Level:     INFO,
-			Args:      []any{updatedSecurity},
+			Id:        removal.id,
+			Level:     removal.level,
+			Args:      removal.args,
 			Component: ComponentSecuritySchemes,
 		}.WithSources(baseSource, nil))
 	}
 
 	for updatedSecurityName, updatedSecurity := range diffReport.ComponentsDiff.SecuritySchemesDiff.Modified {
 		var baseSource, revisionSource *Source
+		var baseScheme *openapi3.SecurityScheme
 		if ref := diffReport.ComponentsDiff.SecuritySchemesDiff.Base[updatedSecurityName]; ref != nil && ref.Value != nil {
 			baseSource = sourceFromOrigin(ref.Value.Origin)
+			baseScheme = ref.Value
 		}
 		if ref := diffReport.ComponentsDiff.SecuritySchemesDiff.Revision[updatedSecurityName]; ref != nil && ref.Value != nil {
 			revisionSource = sourceFromOrigin(ref.Value.Origin)
 		}
 
-		result = append(result, checkOAuthUpdates(updatedSecurity, updatedSecurityName, baseSource, revisionSource)...)
+		result = append(result, checkOAuthUpdates(config, updatedSecurity, updatedSecurityName, baseScheme, baseSource, revisionSource)...)
 
 		if updatedSecurity.TypeDiff != nil {
 			result = append(result, ComponentChange{
@@ -3,6 +3,7 @@ package checker_test
 import (
 	"testing"
 
+	"cloud.google.com/go/civil"
 	"github.com/oasdiff/oasdiff/checker"
 	"github.com/oasdiff/oasdiff/diff"
 	"github.com/stretchr/testify/require"
@@ -114,6 +115,50 @@ func TestComponentSecurityRemoved(t *testing.T) {
 	require.Equal(t, "the component security scheme `BasicAuth` was removed", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
 }
 
+// removing a deprecated security component
+func TestComponentSecurityRemovedWithDeprecation(t *testing.T) {
+	s1, err := open("../data/checker/component_security_updated_revision.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/component_security_updated_base.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Components.SecuritySchemes["BasicAuth"].Value.Extensions = map[string]any{"x-deprecated": true}
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.APIComponentsSecurityUpdatedCheck), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.ComponentChange{
+		Id:        checker.APIComponentsSecurityRemovedWithDeprecationId,
+		Args:      []any{"BasicAuth"},
+		Level:     checker.INFO,
+		Component: checker.ComponentSecuritySchemes,
+	}, errs[0])
+	require.Equal(t, "the deprecated component security scheme `BasicAuth` was removed", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+// removing a deprecated security component before its sunset date
+func TestComponentSecurityRemovedBeforeSunset(t *testing.T) {
+	s1, err := open("../data/checker/component_security_updated_revision.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/component_security_updated_base.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Components.SecuritySchemes["BasicAuth"].Value.Extensions = map[string]any{"x-deprecated": true, "x-sunset": "2026-12-31"}
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.APIComponentsSecurityUpdatedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.ComponentChange{
+		Id:        checker.APIComponentsSecurityRemovedBeforeSunsetId,
+		Args:      []any{"BasicAuth", civil.Date{Year: 2026, Month: 12, Day: 31}},
+		Level:     checker.INFO,
+		Component: checker.ComponentSecuritySchemes,
+	}, errs[0])
+	require.Equal(t, "the component security scheme `BasicAuth` was removed before the sunset date `2026-12-31`", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
 // adding a new oauth security scope
 func TestComponentSecurityOauthScopeAdded(t *testing.T) {
 	s1, err := open("../data/checker/component_security_updated_base.yaml")
@@ -180,6 +225,47 @@ func TestComponentSecurityOauthScopeRemoved(t *testing.T) {
 	require.Equal(t, "the component security scheme `petstore_auth` oauth scope `admin:pets` was removed", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
 }
 
+// removing a deprecated oauth security scope before its sunset date
+func TestComponentSecurityOauthScopeRemovedBeforeSunset(t *testing.T) {
+	s1, err := open("../data/checker/component_security_updated_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/component_security_updated_base.yaml")
+	require.NoError(t, err)
+
+	implicit := s1.Spec.Components.SecuritySchemes["petstore_auth"].Value.Flows.Implicit
+	implicit.Scopes["admin:pets"] = "grants access to admin operations"
+	implicit.Extensions = map[string]any{"x-deprecated-scopes": map[string]any{"admin:pets": "2026-12-31"}}
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.APIComponentsSecurityUpdatedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.ComponentChange{
+		Id:        checker.APIComponentSecurityOauthScopeRemovedBeforeSunsetId,
+		Args:      []any{"petstore_auth", "admin:pets", civil.Date{Year: 2026, Month: 12, Day: 31}},
+		Level:     checker.INFO,
+		Component: checker.ComponentSecuritySchemes,
+	}, errs[0])
+	require.Equal(t, "the component security scheme `petstore_auth` oauth scope `admin:pets` was removed before the sunset date `2026-12-31`", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+// removing a deprecated oauth security scope after its sunset date is fine
+func TestComponentSecurityOauthScopeRemovedAfterSunset(t *testing.T) {
+	s1, err := open("../data/checker/component_security_updated_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/component_security_updated_base.yaml")
+	require.NoError(t, err)
+
+	implicit := s1.Spec.Components.SecuritySchemes["petstore_auth"].Value.Flows.Implicit
+	implicit.Scopes["admin:pets"] = "grants access to admin operations"
+	implicit.Extensions = map[string]any{"x-deprecated-scopes": map[string]any{"admin:pets": "2026-09-30"}}
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.APIComponentsSecurityUpdatedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm, checker.INFO)
+	require.Empty(t, errs)
+}
+
 // removing a new oauth security scope
 func TestComponentSecurityOauthScopeUpdated(t *testing.T) {
 	s1, err := open("../data/checker/component_security_updated_base.yaml")
@@ -1,9 +1,6 @@
 package checker
 
 import (
-	"time"
-
-	"cloud.google.com/go/civil"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oasdiff/oasdiff/diff"
 )
@@ -87,7 +84,7 @@ func RequestParameterDeprecationCheck(diffReport *diff.Diff, operationsSources *
 						continue
 					}
 
-					days := date.DaysSince(civil.DateOf(time.Now()))
+					days := date.DaysSince(config.getAsOf())
 
 					if days < int(deprecationDays) {
 						result = append(result, opInfo.NewApiChange(
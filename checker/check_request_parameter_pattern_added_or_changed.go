@@ -11,8 +11,26 @@ const (
 	RequestParameterPatternGeneralizedId = "request-parameter-pattern-generalized"
 	PatternChangedCommentId              = "pattern-changed-warn-comment"
 	PatternAddedCommentId                = "pattern-added-error-comment"
+	PatternNarrowedCommentId             = "pattern-narrowed-error-comment"
 )
 
+// getPatternChange returns the rule, the comment and the level of a change from one request pattern to another.
+// A pattern that accepts every value that the previous pattern accepted is a generalization.
+// Otherwise, the change is breaking if the new pattern provably rejects some previously accepted values,
+// and a warning if this can't be decided, for example because the patterns use lookarounds.
+// A breaking change is an error unless the user customized the level of the rule.
+func getPatternChange(config *Config, from, to, changedId, generalizedId string) (id string, comment string, level Level) {
+	contained, decided := diff.IsPatternSubset(from, to)
+	switch {
+	case !decided:
+		return changedId, PatternChangedCommentId, config.getLogLevel(changedId)
+	case contained:
+		return generalizedId, "", config.getLogLevel(generalizedId)
+	default:
+		return changedId, PatternNarrowedCommentId, config.getPreciseLevel(changedId, ERR)
+	}
+}
+
 func RequestParameterPatternAddedOrChangedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
 	result := make(Changes, 0)
 	if diffReport.PathsDiff == nil {
@@ -54,19 +72,15 @@ func RequestParameterPatternAddedOrChangedCheck(diffReport *diff.Diff, operation
 							"",
 						).WithSchema(paramItem.SchemaDiff).WithSources(baseSource, nil))
 					} else {
-						id := RequestParameterPatternChangedId
-						comment := PatternChangedCommentId
+						id, comment, level := getPatternChange(config, patternDiff.From.(string), patternDiff.To.(string), RequestParameterPatternChangedId, RequestParameterPatternGeneralizedId)
 
-						if patternDiff.To == ".*" {
-							id = RequestParameterPatternGeneralizedId
-							comment = ""
-						}
-
-						result = append(result, opInfo.NewApiChange(
+						change := opInfo.NewApiChange(
 							id,
 							[]any{paramLocation, paramName, patternDiff.From, patternDiff.To},
 							comment,
-						).WithSchema(paramItem.SchemaDiff).WithSources(baseSource, revisionSource))
+						).WithSchema(paramItem.SchemaDiff).WithSources(baseSource, revisionSource)
+						change.Level = level
+						result = append(result, change)
 					}
 				}
 			}
@@ -9,27 +9,28 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// changing pattern of request parameters
+// changing pattern of request parameters to a pattern that can't be analyzed
 func TestRequestParameterPatternChanged(t *testing.T) {
 	s1, err := open("../data/checker/request_parameter_pattern_added_or_changed_base.yaml")
 	require.NoError(t, err)
 	s2, err := open("../data/checker/request_parameter_pattern_added_or_changed_base.yaml")
 	require.NoError(t, err)
 
-	s2.Spec.Paths.Value("/test").Post.Parameters[0].Value.Schema.Value.Pattern = "^[\\w\\s]+$"
+	s2.Spec.Paths.Value("/test").Post.Parameters[0].Value.Schema.Value.Pattern = "^(?=\\w)[\\w\\s]+$"
 	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
 	require.NoError(t, err)
 	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestParameterPatternAddedOrChangedCheck), d, osm, checker.WARN)
 	requireSingleApiChange(t, checker.ApiChange{
 		Id:        checker.RequestParameterPatternChangedId,
-		Args:      []any{"query", "category", "^\\w+$", "^[\\w\\s]+$"},
+		Args:      []any{"query", "category", "^\\w+$", "^(?=\\w)[\\w\\s]+$"},
 		Comment:   checker.PatternChangedCommentId,
 		Operation: "POST",
 		Path:      "/test",
 		Source:    load.NewSource("../data/checker/request_parameter_pattern_added_or_changed_base.yaml"),
 	}, errs)
-	require.Equal(t, "changed the pattern of the `query` request parameter `category` from `^\\w+$` to `^[\\w\\s]+$`", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
-	require.Equal(t, "This is a warning because adding or changing a pattern may restrict the accepted values and break existing clients. For pattern changes, it is difficult to automatically analyze if the new pattern is a superset of the previous pattern (e.g. changed from '[0-9]+' to '[0-9]*')", errs[0].GetComment(checker.NewDefaultLocalizer()))
+	require.Equal(t, "changed the pattern of the `query` request parameter `category` from `^\\w+$` to `^(?=\\w)[\\w\\s]+$`", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
+	require.Equal(t, checker.WARN, errs[0].GetLevel())
+	require.Equal(t, "This is a warning because changing a pattern may restrict the accepted values and break existing clients. oasdiff couldn't determine whether the new pattern accepts every value that the previous pattern accepted, for example because it uses lookarounds or backreferences", errs[0].GetComment(checker.NewDefaultLocalizer()))
 }
 
 // generalizing pattern of request parameters
@@ -53,6 +54,86 @@ func TestRequestParameterPatternGeneralized(t *testing.T) {
 	require.Equal(t, "changed the pattern of the `query` request parameter `category` from `^\\w+$` to a more general pattern `.*`", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
 }
 
+// narrowing pattern of request parameters
+func TestRequestParameterPatternNarrowed(t *testing.T) {
+	s1, err := open("../data/checker/request_parameter_pattern_added_or_changed_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/request_parameter_pattern_added_or_changed_base.yaml")
+	require.NoError(t, err)
+
+	s2.Spec.Paths.Value("/test").Post.Parameters[0].Value.Schema.Value.Pattern = "^[a-z]+$"
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestParameterPatternAddedOrChangedCheck), d, osm, checker.ERR)
+	requireSingleApiChange(t, checker.ApiChange{
+		Id:        checker.RequestParameterPatternChangedId,
+		Args:      []any{"query", "category", "^\\w+$", "^[a-z]+$"},
+		Comment:   checker.PatternNarrowedCommentId,
+		Operation: "POST",
+		Path:      "/test",
+		Source:    load.NewSource("../data/checker/request_parameter_pattern_added_or_changed_base.yaml"),
+	}, errs)
+	require.Equal(t, checker.ERR, errs[0].GetLevel())
+	require.Equal(t, "This is a breaking change because the new pattern rejects some values that the previous pattern accepted, breaking existing clients", errs[0].GetComment(checker.NewDefaultLocalizer()))
+}
+
+// narrowing pattern of request parameters with a customized level
+func TestRequestParameterPatternNarrowed_CustomLevel(t *testing.T) {
+	s1, err := open("../data/checker/request_parameter_pattern_added_or_changed_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/request_parameter_pattern_added_or_changed_base.yaml")
+	require.NoError(t, err)
+
+	s2.Spec.Paths.Value("/test").Post.Parameters[0].Value.Schema.Value.Pattern = "^[a-z]+$"
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	config := singleCheckConfig(checker.RequestParameterPatternAddedOrChangedCheck, checker.WithSeverityLevels(map[string]checker.Level{
+		checker.RequestParameterPatternChangedId: checker.INFO,
+	}))
+	errs := checker.CheckBackwardCompatibilityUntilLevel(config, d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.INFO, errs[0].GetLevel())
+}
+
+// narrowing pattern of request parameters with the level pinned to its default
+func TestRequestParameterPatternNarrowed_DefaultLevel(t *testing.T) {
+	s1, err := open("../data/checker/request_parameter_pattern_added_or_changed_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/request_parameter_pattern_added_or_changed_base.yaml")
+	require.NoError(t, err)
+
+	s2.Spec.Paths.Value("/test").Post.Parameters[0].Value.Schema.Value.Pattern = "^[a-z]+$"
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	config := singleCheckConfig(checker.RequestParameterPatternAddedOrChangedCheck, checker.WithSeverityLevels(map[string]checker.Level{
+		checker.RequestParameterPatternChangedId: checker.GetCheckLevels()[checker.RequestParameterPatternChangedId],
+	}))
+	errs := checker.CheckBackwardCompatibilityUntilLevel(config, d, osm, checker.INFO)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.WARN, errs[0].GetLevel())
+}
+
+// generalizing pattern of request parameters to a broader character class
+func TestRequestParameterPatternGeneralizedClass(t *testing.T) {
+	s1, err := open("../data/checker/request_parameter_pattern_added_or_changed_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/request_parameter_pattern_added_or_changed_base.yaml")
+	require.NoError(t, err)
+
+	s2.Spec.Paths.Value("/test").Post.Parameters[0].Value.Schema.Value.Pattern = "^[\\w\\s]+$"
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestParameterPatternAddedOrChangedCheck), d, osm, checker.INFO)
+	requireSingleApiChange(t, checker.ApiChange{
+		Id:        checker.RequestParameterPatternGeneralizedId,
+		Args:      []any{"query", "category", "^\\w+$", "^[\\w\\s]+$"},
+		Operation: "POST",
+		Path:      "/test",
+		Source:    load.NewSource("../data/checker/request_parameter_pattern_added_or_changed_base.yaml"),
+	}, errs)
+	require.Equal(t, checker.INFO, errs[0].GetLevel())
+}
+
 // adding pattern to request parameters
 func TestRequestParameterPatternAdded(t *testing.T) {
 	s1, err := open("../data/checker/request_parameter_pattern_added_or_changed_revision.yaml")
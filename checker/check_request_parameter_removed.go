@@ -1,9 +1,6 @@
 package checker
 
 import (
-	"time"
-
-	"cloud.google.com/go/civil"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oasdiff/oasdiff/diff"
 )
@@ -75,7 +72,7 @@ func checkParameterRemoval(opInfo opInfo, param *openapi3.Parameter) (ApiChange,
 		return getRequestParameterSunsetParse(opInfo, param, err), true
 	}
 
-	if civil.DateOf(time.Now()).Before(date) {
+	if opInfo.config.getAsOf().Before(date) {
 		return opInfo.NewApiChange(
 			ParameterRemovedBeforeSunsetId,
 			[]any{param.In, param.Name, date},
@@ -2,9 +2,7 @@ package checker
 
 import (
 	"slices"
-	"time"
 
-	"cloud.google.com/go/civil"
 	"github.com/oasdiff/oasdiff/diff"
 )
 
@@ -78,7 +76,7 @@ func RequestParameterSunsetChangedCheck(diffReport *diff.Diff, operationsSources
 						continue
 					}
 
-					days := date.DaysSince(civil.DateOf(time.Now()))
+					days := date.DaysSince(config.getAsOf())
 
 					stability, err := getStabilityLevel(opRevision.Extensions)
 					if err != nil {
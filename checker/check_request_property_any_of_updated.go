@@ -19,7 +19,7 @@ func RequestPropertyAnyOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 				result = append(result, info.newChange(RequestBodyAnyOfAddedId, []any{added.String()}, "").
 					WithSources(baseSource, revisionSource))
 			}
-			if deleted := info.schemaDiff.AnyOfDiff.Deleted; len(deleted) > 0 {
+			if deleted := getUncontainedSubschemas(info.schemaDiff.AnyOfDiff.Deleted, info.schemaDiff.Base.AnyOf, info.schemaDiff.Revision.AnyOf, false); len(deleted) > 0 {
 				baseSource, revisionSource := SubschemaSources(operationsSources, info.operationItem, info.schemaDiff, "anyOf", deleted[0].Index, -1)
 				result = append(result, info.newChange(RequestBodyAnyOfRemovedId, []any{deleted.String()}, "").
 					WithSources(baseSource, revisionSource))
@@ -37,7 +37,7 @@ func RequestPropertyAnyOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 				result = append(result, p.newChange(RequestPropertyAnyOfAddedId, []any{added.String(), propName}, "").
 					WithSources(propBaseSource, propRevisionSource))
 			}
-			if deleted := p.propertyDiff.AnyOfDiff.Deleted; len(deleted) > 0 {
+			if deleted := getUncontainedSubschemas(p.propertyDiff.AnyOfDiff.Deleted, p.propertyDiff.Base.AnyOf, p.propertyDiff.Revision.AnyOf, false); len(deleted) > 0 {
 				propBaseSource, propRevisionSource := SubschemaSources(operationsSources, info.operationItem, p.propertyDiff, "anyOf", deleted[0].Index, -1)
 				result = append(result, p.newChange(RequestPropertyAnyOfRemovedId, []any{deleted.String(), propName}, "").
 					WithSources(propBaseSource, propRevisionSource))
@@ -228,3 +228,19 @@ func TestRequestPropertyAnyOfNoSchemaDiff(t *testing.T) {
 	errs := checker.RequestPropertyAnyOfUpdatedCheck(d, osm, config)
 	require.Len(t, errs, 0)
 }
+
+// removing 'anyOf' subschemas that are contained in the remaining subschemas isn't reported
+func TestRequestPropertyAnyOfRemovedContained(t *testing.T) {
+	s1, err := open("../data/checker/request_property_any_of_removed_contained_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/request_property_any_of_removed_contained_revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyAnyOfUpdatedCheck), d, osm, checker.ERR)
+
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.RequestPropertyAnyOfRemovedId, errs[0].GetId())
+	require.Equal(t, []any{"#/components/schemas/Email", "name"}, errs[0].(checker.ApiChange).Args)
+}
@@ -1,9 +1,6 @@
 package checker
 
 import (
-	"time"
-
-	"cloud.google.com/go/civil"
 	"github.com/oasdiff/oasdiff/diff"
 )
 
@@ -81,7 +78,7 @@ func RequestPropertyDeprecationCheck(diffReport *diff.Diff, operationsSources *d
 				return
 			}
 
-			days := date.DaysSince(civil.DateOf(time.Now()))
+			days := date.DaysSince(config.getAsOf())
 
 			if days < int(deprecationDays) {
 				result = append(result, p.newChange(
@@ -22,7 +22,7 @@ func RequestPropertyOneOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 				result = append(result, info.newChange(RequestBodyOneOfAddedId, []any{added.String()}, "").
 					WithSources(baseSource, revisionSource))
 			}
-			if deleted := info.schemaDiff.OneOfDiff.Deleted; len(deleted) > 0 {
+			if deleted := getUncontainedSubschemas(info.schemaDiff.OneOfDiff.Deleted, info.schemaDiff.Base.OneOf, info.schemaDiff.Revision.OneOf, true); len(deleted) > 0 {
 				baseSource, revisionSource := SubschemaSources(operationsSources, info.operationItem, info.schemaDiff, "oneOf", deleted[0].Index, -1)
 				result = append(result, info.newChange(RequestBodyOneOfRemovedId, []any{deleted.String()}, "").
 					WithSources(baseSource, revisionSource))
@@ -40,7 +40,7 @@ func RequestPropertyOneOfUpdatedCheck(diffReport *diff.Diff, operationsSources *
 				result = append(result, p.newChange(RequestPropertyOneOfAddedId, []any{added.String(), propName}, "").
 					WithSources(propBaseSource, propRevisionSource))
 			}
-			if deleted := p.propertyDiff.OneOfDiff.Deleted; len(deleted) > 0 {
+			if deleted := getUncontainedSubschemas(p.propertyDiff.OneOfDiff.Deleted, p.propertyDiff.Base.OneOf, p.propertyDiff.Revision.OneOf, true); len(deleted) > 0 {
 				propBaseSource, propRevisionSource := SubschemaSources(operationsSources, info.operationItem, p.propertyDiff, "oneOf", deleted[0].Index, -1)
 				result = append(result, p.newChange(RequestPropertyOneOfRemovedId, []any{deleted.String(), propName}, "").
 					WithSources(propBaseSource, propRevisionSource))
@@ -173,3 +173,19 @@ func TestRequestPropertyOneOfNoSchemaDiff(t *testing.T) {
 	errs := checker.RequestPropertyOneOfUpdatedCheck(d, osm, config)
 	require.Len(t, errs, 0)
 }
+
+// removing 'oneOf' subschemas that are contained in a remaining subschema, and disjoint from the others, isn't reported
+func TestRequestPropertyOneOfRemovedContained(t *testing.T) {
+	s1, err := open("../data/checker/request_property_one_of_removed_contained_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/request_property_one_of_removed_contained_revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyOneOfUpdatedCheck), d, osm, checker.ERR)
+
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.RequestPropertyOneOfRemovedId, errs[0].GetId())
+	require.Equal(t, []any{"#/components/schemas/ColorName", "color"}, errs[0].(checker.ApiChange).Args)
+}
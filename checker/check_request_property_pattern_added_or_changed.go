@@ -37,17 +37,15 @@ func RequestPropertyPatternUpdatedCheck(diffReport *diff.Diff, operationsSources
 					PatternAddedCommentId,
 				).WithSources(propBaseSource, propRevisionSource))
 			} else {
-				id := RequestPropertyPatternChangedId
-				comment := PatternChangedCommentId
-				if patternDiff.To == ".*" {
-					id = RequestPropertyPatternGeneralizedId
-					comment = ""
-				}
-				result = append(result, p.newChange(
+				id, comment, level := getPatternChange(config, patternDiff.From.(string), patternDiff.To.(string), RequestPropertyPatternChangedId, RequestPropertyPatternGeneralizedId)
+
+				change := p.newChange(
 					id,
 					[]any{propName, patternDiff.From, patternDiff.To},
 					comment,
-				).WithSources(propBaseSource, propRevisionSource))
+				).WithSources(propBaseSource, propRevisionSource)
+				change.Level = level
+				result = append(result, change)
 			}
 		})
 	})
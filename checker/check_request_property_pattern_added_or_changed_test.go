@@ -9,27 +9,27 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// changing request property pattern
+// changing request property pattern to a pattern that can't be analyzed
 func TestRequestPropertyPatternChanged(t *testing.T) {
 	s1, err := open("../data/checker/request_property_pattern_added_or_changed_base.yaml")
 	require.NoError(t, err)
 	s2, err := open("../data/checker/request_property_pattern_added_or_changed_revision.yaml")
 	require.NoError(t, err)
 
-	s2.Spec.Paths.Value("/test").Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties["name"].Value.Pattern = "^[\\w\\s]+$"
+	s2.Spec.Paths.Value("/test").Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties["name"].Value.Pattern = "^([\\w\\s])\\1+$"
 
 	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
 	require.NoError(t, err)
 	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyPatternUpdatedCheck), d, osm, checker.INFO)
 	requireSingleApiChange(t, checker.ApiChange{
 		Id:        checker.RequestPropertyPatternChangedId,
-		Args:      []any{"name", "^\\w+$", "^[\\w\\s]+$"},
+		Args:      []any{"name", "^\\w+$", "^([\\w\\s])\\1+$"},
 		Operation: "POST",
 		Path:      "/test",
 		Source:    load.NewSource("../data/checker/request_property_pattern_added_or_changed_revision.yaml"),
 		Comment:   checker.PatternChangedCommentId,
 	}, errs)
-	require.Equal(t, "This is a warning because adding or changing a pattern may restrict the accepted values and break existing clients. For pattern changes, it is difficult to automatically analyze if the new pattern is a superset of the previous pattern (e.g. changed from '[0-9]+' to '[0-9]*')", errs[0].GetComment(checker.NewDefaultLocalizer()))
+	require.Equal(t, "This is a warning because changing a pattern may restrict the accepted values and break existing clients. oasdiff couldn't determine whether the new pattern accepts every value that the previous pattern accepted, for example because it uses lookarounds or backreferences", errs[0].GetComment(checker.NewDefaultLocalizer()))
 }
 
 // generalizing request property pattern
@@ -53,6 +53,69 @@ func TestRequestPropertyPatternGeneralized(t *testing.T) {
 	}, errs)
 }
 
+// narrowing request property pattern
+func TestRequestPropertyPatternNarrowed(t *testing.T) {
+	s1, err := open("../data/checker/request_property_pattern_added_or_changed_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/request_property_pattern_added_or_changed_revision.yaml")
+	require.NoError(t, err)
+
+	s2.Spec.Paths.Value("/test").Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties["name"].Value.Pattern = "^[a-z]+$"
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyPatternUpdatedCheck), d, osm, checker.ERR)
+	requireSingleApiChange(t, checker.ApiChange{
+		Id:        checker.RequestPropertyPatternChangedId,
+		Args:      []any{"name", "^\\w+$", "^[a-z]+$"},
+		Operation: "POST",
+		Path:      "/test",
+		Source:    load.NewSource("../data/checker/request_property_pattern_added_or_changed_revision.yaml"),
+		Comment:   checker.PatternNarrowedCommentId,
+	}, errs)
+	require.Equal(t, checker.ERR, errs[0].GetLevel())
+}
+
+// narrowing request property pattern with a customized level is reported at that level, and filtered by it
+func TestRequestPropertyPatternNarrowed_CustomLevel(t *testing.T) {
+	s1, err := open("../data/checker/request_property_pattern_added_or_changed_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/request_property_pattern_added_or_changed_revision.yaml")
+	require.NoError(t, err)
+
+	s2.Spec.Paths.Value("/test").Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties["name"].Value.Pattern = "^[a-z]+$"
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	config := singleCheckConfig(checker.RequestPropertyPatternUpdatedCheck, checker.WithSeverityLevels(map[string]checker.Level{checker.RequestPropertyPatternChangedId: checker.WARN}))
+	require.Empty(t, checker.CheckBackwardCompatibilityUntilLevel(config, d, osm, checker.ERR))
+
+	errs := checker.CheckBackwardCompatibilityUntilLevel(config, d, osm, checker.WARN)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.WARN, errs[0].GetLevel())
+}
+
+// generalizing request property pattern to a broader character class
+func TestRequestPropertyPatternGeneralizedClass(t *testing.T) {
+	s1, err := open("../data/checker/request_property_pattern_added_or_changed_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/request_property_pattern_added_or_changed_revision.yaml")
+	require.NoError(t, err)
+
+	s2.Spec.Paths.Value("/test").Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties["name"].Value.Pattern = "^[\\w\\s]+$"
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.RequestPropertyPatternUpdatedCheck), d, osm, checker.INFO)
+	requireSingleApiChange(t, checker.ApiChange{
+		Id:        checker.RequestPropertyPatternGeneralizedId,
+		Args:      []any{"name", "^\\w+$", "^[\\w\\s]+$"},
+		Operation: "POST",
+		Path:      "/test",
+		Source:    load.NewSource("../data/checker/request_property_pattern_added_or_changed_revision.yaml"),
+	}, errs)
+}
+
 // adding request property pattern
 func TestRequestPropertyPatternAdded(t *testing.T) {
 	s1, err := open("../data/checker/request_property_pattern_added_or_changed_revision.yaml")
@@ -5,8 +5,11 @@ import (
 )
 
 const (
-	RequiredResponseHeaderRemovedId = "required-response-header-removed"
-	OptionalResponseHeaderRemovedId = "optional-response-header-removed"
+	RequiredResponseHeaderRemovedId        = "required-response-header-removed"
+	OptionalResponseHeaderRemovedId        = "optional-response-header-removed"
+	ResponseHeaderRemovedWithDeprecationId = "response-header-removed-with-deprecation"
+	ResponseHeaderRemovedBeforeSunsetId    = "response-header-removed-before-sunset"
+	ResponseHeaderSunsetParseId            = "response-header-sunset-parse"
 )
 
 func ResponseHeaderRemovedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
@@ -37,20 +40,26 @@ func ResponseHeaderRemovedCheck(diffReport *diff.Diff, operationsSources *diff.O
 					}
 					header := responseDiff.Base.Headers[headerName].Value
 					baseSource := NewSourceFromOrigin(operationsSources, operationItem.Base, header.Origin)
-					required := header.Required
-					if required {
-						result = append(result, opInfo.NewApiChange(
-							RequiredResponseHeaderRemovedId,
-							[]any{headerName, responseStatus},
-							"",
-						).WithSources(baseSource, nil))
-					} else {
-						result = append(result, opInfo.NewApiChange(
-							OptionalResponseHeaderRemovedId,
-							[]any{headerName, responseStatus},
-							"",
-						).WithSources(baseSource, nil))
+					ids := removalIds{
+						withoutDeprecation: OptionalResponseHeaderRemovedId,
+						withDeprecation:    ResponseHeaderRemovedWithDeprecationId,
+						beforeSunset:       ResponseHeaderRemovedBeforeSunsetId,
+						sunsetParse:        ResponseHeaderSunsetParseId,
 					}
+					if header.Required {
+						ids.withoutDeprecation = RequiredResponseHeaderRemovedId
+					}
+					removal, ok := ids.getRemoval(config, header.Deprecated, header.Extensions, headerName, responseStatus)
+					if !ok {
+						continue
+					}
+					change := opInfo.NewApiChange(
+						removal.id,
+						removal.args,
+						"",
+					).WithSources(baseSource, nil)
+					change.Level = removal.level
+					result = append(result, change)
 				}
 			}
 		}
@@ -0,0 +1,128 @@
+package checker_test
+
+import (
+	"testing"
+
+	"cloud.google.com/go/civil"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/stretchr/testify/require"
+)
+
+func addResponseHeader(t *testing.T, s *load.SpecInfo, header *openapi3.Header) {
+	t.Helper()
+
+	header.Schema = &openapi3.SchemaRef{Value: openapi3.NewStringSchema()}
+	s.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Value("200").Value.Headers = openapi3.Headers{
+		"X-Rate-Limit": &openapi3.HeaderRef{Value: header},
+	}
+}
+
+// removing an optional response header
+func TestOptionalResponseHeaderRemoved(t *testing.T) {
+	s1, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+
+	addResponseHeader(t, s1, &openapi3.Header{})
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseHeaderRemovedCheck), d, osm, checker.INFO)
+	change := requireSingleChange(t, errs, checker.OptionalResponseHeaderRemovedId)
+	require.Equal(t, checker.WARN, change.GetLevel())
+	require.Equal(t, "the optional response header `X-Rate-Limit` removed for the status `200`", change.GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+// removing a deprecated response header without a sunset date
+func TestResponseHeaderRemovedWithDeprecation(t *testing.T) {
+	s1, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+
+	addResponseHeader(t, s1, &openapi3.Header{Parameter: openapi3.Parameter{Required: true, Deprecated: true}})
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseHeaderRemovedCheck), d, osm, checker.INFO)
+	change := requireSingleChange(t, errs, checker.ResponseHeaderRemovedWithDeprecationId)
+	require.Equal(t, checker.INFO, change.GetLevel())
+	require.Equal(t, "the deprecated response header `X-Rate-Limit` removed for the status `200`", change.GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+// removing a deprecated response header before its sunset date
+func TestResponseHeaderRemovedBeforeSunset(t *testing.T) {
+	s1, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+
+	addResponseHeader(t, s1, &openapi3.Header{Parameter: openapi3.Parameter{Deprecated: true, Extensions: map[string]any{"x-sunset": "2026-12-31"}}})
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseHeaderRemovedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm, checker.INFO)
+	change := requireSingleChange(t, errs, checker.ResponseHeaderRemovedBeforeSunsetId)
+	// removing the optional header without deprecation is only a warning
+	require.Equal(t, checker.WARN, change.GetLevel())
+	require.Equal(t, "the response header `X-Rate-Limit` removed for the status `200` before the sunset date `2026-12-31`", change.GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+// removing a deprecated response header after its sunset date is fine
+func TestResponseHeaderRemovedAfterSunset(t *testing.T) {
+	s1, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+
+	addResponseHeader(t, s1, &openapi3.Header{Parameter: openapi3.Parameter{Deprecated: true, Extensions: map[string]any{"x-sunset": "2026-09-30"}}})
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseHeaderRemovedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm, checker.INFO)
+	require.Empty(t, errs)
+}
+
+// removing a deprecated required response header before its sunset date is as breaking as removing it without deprecation
+func TestRequiredResponseHeaderRemovedBeforeSunset(t *testing.T) {
+	s1, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+
+	addResponseHeader(t, s1, &openapi3.Header{Parameter: openapi3.Parameter{Required: true, Deprecated: true, Extensions: map[string]any{"x-sunset": "2026-12-31"}}})
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseHeaderRemovedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm, checker.INFO)
+	change := requireSingleChange(t, errs, checker.ResponseHeaderRemovedBeforeSunsetId)
+	require.Equal(t, checker.ERR, change.GetLevel())
+}
+
+// an error-level check returns the removals before the sunset date that are errors, and only those
+func TestResponseHeaderRemovedBeforeSunset_ErrLevel(t *testing.T) {
+	for _, required := range []bool{false, true} {
+		s1, err := open("../data/checker/response_status_base.yaml")
+		require.NoError(t, err)
+		s2, err := open("../data/checker/response_status_base.yaml")
+		require.NoError(t, err)
+
+		addResponseHeader(t, s1, &openapi3.Header{Parameter: openapi3.Parameter{Required: required, Deprecated: true, Extensions: map[string]any{"x-sunset": "2026-12-31"}}})
+
+		d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+		require.NoError(t, err)
+		errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseHeaderRemovedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm, checker.ERR)
+		for _, change := range errs {
+			require.Equal(t, checker.ERR, change.GetLevel())
+		}
+		if required {
+			requireSingleChange(t, errs, checker.ResponseHeaderRemovedBeforeSunsetId)
+		} else {
+			require.Empty(t, errs)
+		}
+	}
+}
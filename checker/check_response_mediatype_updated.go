@@ -1,12 +1,16 @@
 package checker
 
 import (
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oasdiff/oasdiff/diff"
 )
 
 const (
-	ResponseMediaTypeRemovedId = "response-media-type-removed"
-	ResponseMediaTypeAddedId   = "response-media-type-added"
+	ResponseMediaTypeRemovedId                = "response-media-type-removed"
+	ResponseMediaTypeAddedId                  = "response-media-type-added"
+	ResponseMediaTypeRemovedWithDeprecationId = "response-media-type-removed-with-deprecation"
+	ResponseMediaTypeRemovedBeforeSunsetId    = "response-media-type-removed-before-sunset"
+	ResponseMediaTypeSunsetParseId            = "response-media-type-sunset-parse"
 )
 
 func ResponseMediaTypeUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
@@ -31,12 +35,18 @@ func ResponseMediaTypeUpdatedCheck(diffReport *diff.Diff, operationsSources *dif
 					continue
 				}
 				for _, mediaType := range responsesDiff.ContentDiff.MediaTypeDeleted {
+					removal, ok := getResponseMediaTypeRemoval(config, responsesDiff.Base, mediaType, responseStatus)
+					if !ok {
+						continue
+					}
 					baseSource := mediaTypeSource(operationsSources, operationItem.Base, responsesDiff.Base, mediaType)
-					result = append(result, opInfo.NewApiChange(
-						ResponseMediaTypeRemovedId,
-						[]any{mediaType, responseStatus},
+					change := opInfo.NewApiChange(
+						removal.id,
+						removal.args,
 						"",
-					).WithSources(baseSource, nil))
+					).WithSources(baseSource, nil)
+					change.Level = removal.level
+					result = append(result, change)
 				}
 				for _, mediaType := range responsesDiff.ContentDiff.MediaTypeAdded {
 					revisionSource := mediaTypeSource(operationsSources, operationItem.Revision, responsesDiff.Revision, mediaType)
@@ -51,3 +61,21 @@ func ResponseMediaTypeUpdatedCheck(diffReport *diff.Diff, operationsSources *dif
 	}
 	return result
 }
+
+// getResponseMediaTypeRemoval returns the change reported when a media type is removed from a response, and false if
+// it was removed after its sunset date.
+// A media type has no deprecated field, so it is deprecated with the x-deprecated extension.
+func getResponseMediaTypeRemoval(config *Config, response *openapi3.Response, mediaType, responseStatus string) (removal, bool) {
+	ids := removalIds{
+		withoutDeprecation: ResponseMediaTypeRemovedId,
+		withDeprecation:    ResponseMediaTypeRemovedWithDeprecationId,
+		beforeSunset:       ResponseMediaTypeRemovedBeforeSunsetId,
+		sunsetParse:        ResponseMediaTypeSunsetParseId,
+	}
+
+	var extensions map[string]any
+	if response != nil && response.Content[mediaType] != nil {
+		extensions = response.Content[mediaType].Extensions
+	}
+	return ids.getRemoval(config, false, extensions, mediaType, responseStatus)
+}
@@ -3,6 +3,7 @@ package checker_test
 import (
 	"testing"
 
+	"cloud.google.com/go/civil"
 	"github.com/oasdiff/oasdiff/checker"
 	"github.com/oasdiff/oasdiff/diff"
 	"github.com/oasdiff/oasdiff/load"
@@ -48,3 +49,42 @@ func TestDeleteNewMediaType(t *testing.T) {
 		OperationId: "createOneGroup",
 	}, errs)
 }
+
+// removing a deprecated media type from response before its sunset date is breaking
+func TestDeleteDeprecatedMediaTypeBeforeSunset(t *testing.T) {
+	s1, err := open("../data/checker/add_new_media_type_revision.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/add_new_media_type_base.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Value("200").Value.Content["application/xml"].Extensions = map[string]any{"x-deprecated": true, "x-sunset": "2026-12-31"}
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseMediaTypeUpdatedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm, checker.INFO)
+	requireSingleApiChange(t, checker.ApiChange{
+		Id:          checker.ResponseMediaTypeRemovedBeforeSunsetId,
+		Args:        []any{"application/xml", "200", civil.Date{Year: 2026, Month: 12, Day: 31}},
+		Operation:   "POST",
+		Path:        "/api/v1.0/groups",
+		Source:      load.NewSource("../data/checker/add_new_media_type_base.yaml"),
+		OperationId: "createOneGroup",
+	}, errs)
+	require.Equal(t, "removed the media type `application/xml` for the response with the status `200` before the sunset date `2026-12-31`", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+// removing a deprecated media type from response without a sunset date is not breaking
+func TestDeleteDeprecatedMediaType(t *testing.T) {
+	s1, err := open("../data/checker/add_new_media_type_revision.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/add_new_media_type_base.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Value("200").Value.Content["application/xml"].Extensions = map[string]any{"x-deprecated": true}
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseMediaTypeUpdatedCheck), d, osm, checker.INFO)
+	change := requireSingleChange(t, errs, checker.ResponseMediaTypeRemovedWithDeprecationId)
+	require.Equal(t, checker.INFO, change.GetLevel())
+}
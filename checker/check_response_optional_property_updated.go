@@ -38,16 +38,18 @@ func ResponseOptionalPropertyUpdatedCheck(diffReport *diff.Diff, operationsSourc
 				if w := parent.OneOfWrappingDiff; w != nil && slices.Contains(w.MovedProperties, propertyName) {
 					return
 				}
-				id := ResponseOptionalPropertyRemovedId
-				if propertyItem.WriteOnly {
-					id = ResponseOptionalWriteOnlyPropertyRemovedId
+				removal, ok := getResponsePropertyRemoval(config, propertyItem, ResponseOptionalPropertyRemovedId, ResponseOptionalWriteOnlyPropertyRemovedId, propertyFullName(propertyPath, propertyName), info.responseStatus)
+				if !ok {
+					return
 				}
 				baseSource := propertySource(operationsSources, info.operationItem.Base, propertyItem)
-				result = append(result, info.newChange(
-					id,
-					[]any{propertyFullName(propertyPath, propertyName), info.responseStatus},
+				change := info.newChange(
+					removal.id,
+					removal.args,
 					"",
-				).WithSchema(parent).WithSources(baseSource, nil))
+				).WithSchema(parent).WithSources(baseSource, nil)
+				change.Level = removal.level
+				result = append(result, change)
 			})
 
 		checkAddedPropertiesDiff(
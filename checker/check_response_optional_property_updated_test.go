@@ -29,6 +29,29 @@ func TestResponseOptionalPropertyUpdatedCheck(t *testing.T) {
 	}, errs)
 }
 
+// removing a deprecated optional property without a sunset date from a response
+func TestResponseOptionalPropertyRemovedWithDeprecation(t *testing.T) {
+	s1, err := open("../data/checker/response_optional_property_removed_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_optional_property_removed_revision.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Value("200").Value.Content["application/json"].Schema.Value.Properties["data"].Value.Properties["id"].Value.Deprecated = true
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseOptionalPropertyUpdatedCheck), d, osm, checker.INFO)
+	requireSingleApiChange(t, checker.ApiChange{
+		Id:          checker.ResponsePropertyRemovedWithDeprecationId,
+		Args:        []any{"data/id", "200"},
+		Operation:   "POST",
+		Path:        "/api/v1.0/groups",
+		Source:      load.NewSource("../data/checker/response_optional_property_removed_revision.yaml"),
+		OperationId: "createOneGroup",
+	}, errs)
+	require.Equal(t, checker.INFO, errs[0].GetLevel())
+}
+
 // adding an optional write-only property to a response
 func TestResponseOptionalPropertyAddedCheck(t *testing.T) {
 	s1, err := open("../data/checker/response_optional_property_removed_revision.yaml")
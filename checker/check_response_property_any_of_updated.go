@@ -16,7 +16,7 @@ func ResponsePropertyAnyOfUpdatedCheck(diffReport *diff.Diff, operationsSources
 
 	walkModifiedResponseSchemas(diffReport, operationsSources, config, func(info mediaTypeInfo) {
 		if info.schemaDiff.AnyOfDiff != nil {
-			if added := info.schemaDiff.AnyOfDiff.Added; len(added) > 0 {
+			if added := getUncontainedSubschemas(info.schemaDiff.AnyOfDiff.Added, info.schemaDiff.Revision.AnyOf, info.schemaDiff.Base.AnyOf, false); len(added) > 0 {
 				baseSource, revisionSource := SubschemaSources(operationsSources, info.operationItem, info.schemaDiff, "anyOf", -1, added[0].Index)
 				result = append(result, info.newChange(ResponseBodyAnyOfAddedId, []any{added.String(), info.responseStatus}, "").
 					WithSources(baseSource, revisionSource))
@@ -34,7 +34,7 @@ func ResponsePropertyAnyOfUpdatedCheck(diffReport *diff.Diff, operationsSources
 			}
 			propName := propertyFullName(p.propertyPath, p.propertyName)
 
-			if added := p.propertyDiff.AnyOfDiff.Added; len(added) > 0 {
+			if added := getUncontainedSubschemas(p.propertyDiff.AnyOfDiff.Added, p.propertyDiff.Revision.AnyOf, p.propertyDiff.Base.AnyOf, false); len(added) > 0 {
 				propBaseSource, propRevisionSource := SubschemaSources(operationsSources, info.operationItem, p.propertyDiff, "anyOf", -1, added[0].Index)
 				result = append(result, p.newChange(ResponsePropertyAnyOfAddedId, []any{added.String(), propName, info.responseStatus}, "").
 					WithSources(propBaseSource, propRevisionSource))
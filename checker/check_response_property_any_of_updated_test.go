@@ -1,6 +1,7 @@
 package checker_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/oasdiff/oasdiff/checker"
@@ -95,3 +96,21 @@ func TestResponsePropertyAnyOfRemoved(t *testing.T) {
 			OperationId: "listPets",
 		}}, errs)
 }
+
+// adding 'anyOf' subschemas that are contained in a subschema of the base isn't reported
+func TestResponsePropertyAnyOfAddedContained(t *testing.T) {
+	s1, err := open("../data/checker/response_property_any_of_added_contained_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_property_any_of_added_contained_revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponsePropertyAnyOfUpdatedCheck), d, osm, checker.INFO)
+
+	errs = slices.DeleteFunc(errs, func(change checker.Change) bool {
+		return change.GetId() != checker.ResponsePropertyAnyOfAddedId
+	})
+	require.Len(t, errs, 1)
+	require.Equal(t, []any{"#/components/schemas/Company", "owner", "200"}, errs[0].(checker.ApiChange).Args)
+}
@@ -17,7 +17,7 @@ func ResponsePropertyOneOfUpdated(diffReport *diff.Diff, operationsSources *diff
 	walkModifiedResponseSchemas(diffReport, operationsSources, config, func(info mediaTypeInfo) {
 
 		if info.schemaDiff.OneOfDiff != nil {
-			if added := info.schemaDiff.OneOfDiff.Added; len(added) > 0 {
+			if added := getUncontainedSubschemas(info.schemaDiff.OneOfDiff.Added, info.schemaDiff.Revision.OneOf, info.schemaDiff.Base.OneOf, true); len(added) > 0 {
 				baseSource, revisionSource := SubschemaSources(operationsSources, info.operationItem, info.schemaDiff, "oneOf", -1, added[0].Index)
 				result = append(result, info.newChange(ResponseBodyOneOfAddedId, []any{added.String(), info.responseStatus}, "").
 					WithSources(baseSource, revisionSource))
@@ -35,7 +35,7 @@ func ResponsePropertyOneOfUpdated(diffReport *diff.Diff, operationsSources *diff
 			}
 			propName := propertyFullName(p.propertyPath, p.propertyName)
 
-			if added := p.propertyDiff.OneOfDiff.Added; len(added) > 0 {
+			if added := getUncontainedSubschemas(p.propertyDiff.OneOfDiff.Added, p.propertyDiff.Revision.OneOf, p.propertyDiff.Base.OneOf, true); len(added) > 0 {
 				propBaseSource, propRevisionSource := SubschemaSources(operationsSources, info.operationItem, p.propertyDiff, "oneOf", -1, added[0].Index)
 				result = append(result, p.newChange(ResponsePropertyOneOfAddedId, []any{added.String(), propName, info.responseStatus}, "").
 					WithSources(propBaseSource, propRevisionSource))
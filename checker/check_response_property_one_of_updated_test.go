@@ -111,3 +111,19 @@ func TestResponsePropertyOneOfRemoved(t *testing.T) {
 			OperationId: "listPets",
 		}}, errs)
 }
+
+// adding 'oneOf' subschemas that are contained in a subschema of the base, and disjoint from the others, isn't reported
+func TestResponsePropertyOneOfAddedContained(t *testing.T) {
+	s1, err := open("../data/checker/response_property_one_of_added_contained_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_property_one_of_added_contained_revision.yaml")
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponsePropertyOneOfUpdated), d, osm, checker.ERR)
+
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.ResponsePropertyOneOfAddedId, errs[0].GetId())
+	require.Equal(t, []any{"#/components/schemas/Company", "owner", "200"}, errs[0].(checker.ApiChange).Args)
+}
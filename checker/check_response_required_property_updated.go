@@ -43,10 +43,6 @@ func ResponseRequiredPropertyUpdatedCheck(diffReport *diff.Diff, operationsSourc
 		checkDeletedPropertiesDiff(
 			info.schemaDiff,
 			func(propertyPath string, propertyName string, propertyItem *openapi3.Schema, parent *diff.SchemaDiff) {
-				id := ResponseRequiredPropertyRemovedId
-				if propertyItem.WriteOnly {
-					id = ResponseRequiredWriteOnlyPropertyRemovedId
-				}
 				if !slices.Contains(parent.Base.Required, propertyName) {
 					// Covered by response-optional-property-removed
 					return
@@ -61,12 +57,18 @@ func ResponseRequiredPropertyUpdatedCheck(diffReport *diff.Diff, operationsSourc
 					return
 				}
 
+				removal, ok := getResponsePropertyRemoval(config, propertyItem, ResponseRequiredPropertyRemovedId, ResponseRequiredWriteOnlyPropertyRemovedId, propertyFullName(propertyPath, propertyName), info.responseStatus)
+				if !ok {
+					return
+				}
 				baseSource := propertySource(operationsSources, info.operationItem.Base, propertyItem)
-				result = append(result, info.newChange(
-					id,
-					[]any{propertyFullName(propertyPath, propertyName), info.responseStatus},
+				change := info.newChange(
+					removal.id,
+					removal.args,
 					"",
-				).WithSchema(parent).WithSources(baseSource, nil))
+				).WithSchema(parent).WithSources(baseSource, nil)
+				change.Level = removal.level
+				result = append(result, change)
 			})
 		checkAddedPropertiesDiff(
 			info.schemaDiff,
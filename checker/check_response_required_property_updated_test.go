@@ -3,6 +3,7 @@ package checker_test
 import (
 	"testing"
 
+	"cloud.google.com/go/civil"
 	"github.com/oasdiff/oasdiff/checker"
 	"github.com/oasdiff/oasdiff/diff"
 	"github.com/oasdiff/oasdiff/load"
@@ -51,6 +52,48 @@ func TestResponseRequiredPropertyRemoved(t *testing.T) {
 	}, errs)
 }
 
+// removing a deprecated required property from the response body before its sunset date is breaking
+func TestResponseRequiredPropertyRemovedBeforeSunset(t *testing.T) {
+	s1, err := open("../data/checker/response_required_property_added_revision.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_required_property_added_base.yaml")
+	require.NoError(t, err)
+
+	property := s1.Spec.Components.Schemas["GroupView"].Value.Properties["data"].Value.Properties["new"].Value
+	property.Deprecated = true
+	property.Extensions = map[string]any{"x-sunset": "2026-12-31"}
+	s2.Spec.Components.Schemas["GroupView"].Value.Properties["data"].Value.Required = []string{"name", "id"}
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseRequiredPropertyUpdatedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm, checker.INFO)
+	requireSingleApiChange(t, checker.ApiChange{
+		Id:          checker.ResponsePropertyRemovedBeforeSunsetId,
+		Args:        []any{"data/new", "200", civil.Date{Year: 2026, Month: 12, Day: 31}},
+		Operation:   "POST",
+		Path:        "/api/v1.0/groups",
+		Source:      load.NewSource("../data/checker/response_required_property_added_base.yaml"),
+		OperationId: "createOneGroup",
+	}, errs)
+	require.Equal(t, checker.ERR, errs[0].GetLevel())
+}
+
+// removing a deprecated required property from the response body after its sunset date is fine
+func TestResponseRequiredPropertyRemovedAfterSunset(t *testing.T) {
+	s1, err := open("../data/checker/response_required_property_added_revision.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_required_property_added_base.yaml")
+	require.NoError(t, err)
+
+	property := s1.Spec.Components.Schemas["GroupView"].Value.Properties["data"].Value.Properties["new"].Value
+	property.Deprecated = true
+	property.Extensions = map[string]any{"x-sunset": "2026-09-30"}
+	s2.Spec.Components.Schemas["GroupView"].Value.Properties["data"].Value.Required = []string{"name", "id"}
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseRequiredPropertyUpdatedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm, checker.INFO)
+	require.Empty(t, errs)
+}
+
 // adding a required write-only property to response body is detected
 func TestResponseRequiredWriteOnlyPropertyAdded(t *testing.T) {
 	s1, err := open("../data/checker/response_required_property_added_base.yaml")
@@ -13,6 +13,10 @@ const (
 	ResponseNonSuccessStatusRemovedId = "response-non-success-status-removed"
 	ResponseSuccessStatusAddedId      = "response-success-status-added"
 	ResponseNonSuccessStatusAddedId   = "response-non-success-status-added"
+	// the removal of a deprecated response is reported alike for success and non-success statuses
+	ResponseStatusRemovedWithDeprecationId = "response-status-removed-with-deprecation"
+	ResponseStatusRemovedBeforeSunsetId    = "response-status-removed-before-sunset"
+	ResponseStatusSunsetParseId            = "response-status-sunset-parse"
 )
 
 func ResponseSuccessStatusUpdatedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
@@ -77,13 +81,19 @@ func responseStatusUpdated(diffReport *diff.Diff, operationsSources *diff.Operat
 				}
 
 				if filter(status) {
+					removal, ok := getResponseStatusRemoval(config, operationItem.Base, responseStatus, id)
+					if !ok {
+						continue
+					}
 					baseSource := responseSource(operationsSources, operationItem.Base, responseStatus)
 					var revisionSource *Source
-					result = append(result, opInfo.NewApiChange(
-						id,
-						[]any{responseStatus},
+					change := opInfo.NewApiChange(
+						removal.id,
+						removal.args,
 						"",
-					).WithSources(baseSource, revisionSource))
+					).WithSources(baseSource, revisionSource)
+					change.Level = removal.level
+					result = append(result, change)
 				}
 			}
 
@@ -108,3 +118,21 @@ func responseStatusUpdated(diffReport *diff.Diff, operationsSources *diff.Operat
 	}
 	return result
 }
+
+// getResponseStatusRemoval returns the change reported when a response is removed, and false if it was removed after
+// its sunset date.
+// A response has no deprecated field, so it is deprecated with the x-deprecated extension.
+func getResponseStatusRemoval(config *Config, op *openapi3.Operation, responseStatus, withoutDeprecationId string) (removal, bool) {
+	ids := removalIds{
+		withoutDeprecation: withoutDeprecationId,
+		withDeprecation:    ResponseStatusRemovedWithDeprecationId,
+		beforeSunset:       ResponseStatusRemovedBeforeSunsetId,
+		sunsetParse:        ResponseStatusSunsetParseId,
+	}
+
+	var extensions map[string]any
+	if responseRef := op.Responses.Value(responseStatus); responseRef != nil && responseRef.Value != nil {
+		extensions = responseRef.Value.Extensions
+	}
+	return ids.getRemoval(config, false, extensions, responseStatus)
+}
@@ -1,8 +1,10 @@
 package checker_test
 
 import (
+	"reflect"
 	"testing"
 
+	"cloud.google.com/go/civil"
 	"github.com/oasdiff/oasdiff/checker"
 	"github.com/oasdiff/oasdiff/diff"
 	"github.com/oasdiff/oasdiff/load"
@@ -100,3 +102,122 @@ func TestResponseSuccessStatusRemoved(t *testing.T) {
 		OperationId: "createOneGroup",
 	}, errs)
 }
+
+// removing a deprecated success status without a sunset date is not breaking
+func TestResponseSuccessStatusRemovedWithDeprecation(t *testing.T) {
+	s1, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Value("200").Value.Extensions = map[string]any{"x-deprecated": true}
+	s2.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Delete("200")
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseSuccessStatusUpdatedCheck), d, osm, checker.INFO)
+	requireSingleApiChange(t, checker.ApiChange{
+		Id:          checker.ResponseStatusRemovedWithDeprecationId,
+		Args:        []any{"200"},
+		Operation:   "POST",
+		Path:        "/api/v1.0/groups",
+		Source:      load.NewSource("../data/checker/response_status_base.yaml"),
+		OperationId: "createOneGroup",
+	}, errs)
+	require.Equal(t, checker.INFO, errs[0].GetLevel())
+}
+
+// removing a deprecated non-success status before its sunset date is breaking
+func TestResponseNonSuccessStatusRemovedBeforeSunset(t *testing.T) {
+	s1, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Value("409").Value.Extensions = map[string]any{"x-deprecated": true, "x-sunset": "2026-12-31"}
+	s2.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Delete("409")
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseNonSuccessStatusUpdatedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm, checker.INFO)
+	requireSingleApiChange(t, checker.ApiChange{
+		Id:          checker.ResponseStatusRemovedBeforeSunsetId,
+		Args:        []any{"409", civil.Date{Year: 2026, Month: 12, Day: 31}},
+		Operation:   "POST",
+		Path:        "/api/v1.0/groups",
+		Source:      load.NewSource("../data/checker/response_status_base.yaml"),
+		OperationId: "createOneGroup",
+	}, errs)
+	// removing the non-success response without deprecation is only info, so removing it before its sunset date isn't worse
+	require.Equal(t, checker.INFO, errs[0].GetLevel())
+}
+
+// a level set for the before-sunset rule is honoured
+func TestResponseNonSuccessStatusRemovedBeforeSunset_CustomLevel(t *testing.T) {
+	s1, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Value("409").Value.Extensions = map[string]any{"x-deprecated": true, "x-sunset": "2026-12-31"}
+	s2.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Delete("409")
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	config := singleCheckConfig(checker.ResponseNonSuccessStatusUpdatedCheck,
+		checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18}),
+		checker.WithSeverityLevels(map[string]checker.Level{checker.ResponseStatusRemovedBeforeSunsetId: checker.ERR}))
+	errs := checker.CheckBackwardCompatibilityUntilLevel(config, d, osm, checker.INFO)
+	change := requireSingleChange(t, errs, checker.ResponseStatusRemovedBeforeSunsetId)
+	require.Equal(t, checker.ERR, change.GetLevel())
+}
+
+// removing a deprecated success status after its sunset date is fine
+func TestResponseSuccessStatusRemovedAfterSunset(t *testing.T) {
+	s1, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Value("200").Value.Extensions = map[string]any{"x-deprecated": true, "x-sunset": "2026-09-30"}
+	s2.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Delete("200")
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseSuccessStatusUpdatedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm, checker.INFO)
+	require.Empty(t, errs)
+}
+
+// removing a deprecated success status with an invalid sunset date is breaking
+func TestResponseSuccessStatusRemovedSunsetParse(t *testing.T) {
+	s1, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/checker/response_status_base.yaml")
+	require.NoError(t, err)
+
+	s1.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Value("200").Value.Extensions = map[string]any{"x-deprecated": true, "x-sunset": "next month"}
+	s2.Spec.Paths.Value("/api/v1.0/groups").Post.Responses.Delete("200")
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibilityUntilLevel(singleCheckConfig(checker.ResponseSuccessStatusUpdatedCheck), d, osm, checker.INFO)
+	change := requireSingleChange(t, errs, checker.ResponseStatusSunsetParseId)
+	require.Equal(t, checker.ERR, change.GetLevel())
+	require.Equal(t, "failed to parse sunset date for the response with the status `200`: `sunset date doesn't conform with RFC3339: next month`", change.GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+// the rules of deprecated responses are registered with both checks that report them
+func TestResponseStatusRemovalRulesRegisteredWithBothChecks(t *testing.T) {
+	for _, id := range []string{checker.ResponseStatusRemovedWithDeprecationId, checker.ResponseStatusRemovedBeforeSunsetId, checker.ResponseStatusSunsetParseId} {
+		handlers := []uintptr{}
+		for _, rule := range checker.GetAllRules() {
+			if rule.Id == id {
+				handlers = append(handlers, reflect.ValueOf(rule.Handler).Pointer())
+			}
+		}
+		require.ElementsMatch(t, []uintptr{
+			reflect.ValueOf(checker.ResponseSuccessStatusUpdatedCheck).Pointer(),
+			reflect.ValueOf(checker.ResponseNonSuccessStatusUpdatedCheck).Pointer(),
+		}, handlers, id)
+	}
+}
@@ -0,0 +1,90 @@
+package checker
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/diff"
+)
+
+const (
+	APISunsetDatePassedId              = "api-sunset-date-passed"
+	RequestParameterSunsetDatePassedId = "request-parameter-sunset-date-passed"
+	RequestPropertySunsetDatePassedId  = "request-property-sunset-date-passed"
+	ResponsePropertySunsetDatePassedId = "response-property-sunset-date-passed"
+)
+
+// SunsetDatePassedCheck reports the deprecated endpoints, parameters and properties of the revision whose sunset date
+// has passed: they can be removed, and should be.
+// Unlike the other checks, it judges the revision as a whole rather than its changes, so an element is reported
+// whether or not it changed. The sunset dates are compared with the config's as-of date.
+func SunsetDatePassedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+
+	paths := diffReport.RevisionPaths
+	if paths == nil && diffReport.PathsDiff != nil {
+		paths = diffReport.PathsDiff.Revision
+	}
+	if paths == nil {
+		return result
+	}
+
+	for _, deprecation := range getPathsDeprecations(paths, "", config.getAsOf()) {
+		if !deprecation.Overdue || !config.StabilityLevel.IsIncluded(deprecation.Stability) {
+			continue
+		}
+
+		op := paths.Value(deprecation.Path).GetOperation(deprecation.Operation)
+		result = append(result, newSunsetDatePassedChange(deprecation, config, operationsSources, op))
+	}
+
+	return result
+}
+
+func newSunsetDatePassedChange(deprecation Deprecation, config *Config, operationsSources *diff.OperationsSourcesMap, op *openapi3.Operation) ApiChange {
+	var id string
+	var args []any
+	switch deprecation.Kind {
+	case DeprecatedEndpoint:
+		id, args = APISunsetDatePassedId, []any{*deprecation.Sunset}
+	case DeprecatedParameter:
+		id, args = RequestParameterSunsetDatePassedId, []any{deprecation.In, deprecation.Name, *deprecation.Sunset}
+	default:
+		if deprecation.In == "response" {
+			id, args = ResponsePropertySunsetDatePassedId, []any{deprecation.Name, deprecation.Status, *deprecation.Sunset}
+		} else {
+			id, args = RequestPropertySunsetDatePassedId, []any{deprecation.Name, *deprecation.Sunset}
+		}
+	}
+
+	// the deprecation's source is shared with the caller, so it is copied before the file is filled in
+	var revisionSource *Source
+	if deprecation.Source != nil {
+		source := *deprecation.Source
+		if source.File == "" {
+			source.File = getOperationFile(operationsSources, op)
+		}
+		revisionSource = &source
+	}
+
+	return NewApiChange(id, config, args, "", operationsSources, op, deprecation.Operation, deprecation.Path).
+		WithSources(nil, revisionSource).
+		WithDetails(formatMediaTypeDetails(deprecation.MediaType, countMediaTypes(deprecation, op)))
+}
+
+// countMediaTypes returns the number of media types of the request body or response that a deprecated property is in
+func countMediaTypes(deprecation Deprecation, op *openapi3.Operation) int {
+	switch {
+	case deprecation.Kind != DeprecatedProperty:
+		return 0
+	case deprecation.In == "request":
+		if op.RequestBody == nil || op.RequestBody.Value == nil {
+			return 0
+		}
+		return len(op.RequestBody.Value.Content)
+	default:
+		response := op.Responses.Value(deprecation.Status)
+		if response == nil || response.Value == nil {
+			return 0
+		}
+		return len(response.Value.Content)
+	}
+}
@@ -0,0 +1,151 @@
+package checker_test
+
+import (
+	"testing"
+
+	"cloud.google.com/go/civil"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/stretchr/testify/require"
+)
+
+// getSunsetDatePassedChanges diffs the deprecations spec against itself with a new version, so that no path changes
+func getSunsetDatePassedChanges(t *testing.T, opts ...checker.Option) checker.Changes {
+	t.Helper()
+
+	s1, err := open("../data/deprecations/openapi.yaml", newLoaderWithOriginTracking())
+	require.NoError(t, err)
+	s1.Spec.Info.Version = "1.3.0"
+
+	s2, err := open("../data/deprecations/openapi.yaml", newLoaderWithOriginTracking())
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	require.Nil(t, d.PathsDiff)
+
+	return checker.CheckBackwardCompatibility(singleCheckConfig(checker.SunsetDatePassedCheck, opts...), d, osm)
+}
+
+// an endpoint whose sunset date has passed is reported even though it didn't change
+func TestSunsetDatePassed_Endpoint(t *testing.T) {
+	errs := getSunsetDatePassedChanges(t, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18}))
+
+	change := requireSingleChange(t, errs, checker.APISunsetDatePassedId)
+	require.Equal(t, checker.WARN, change.GetLevel())
+	require.Equal(t, "DELETE", change.GetOperation())
+	require.Equal(t, "/pets/{id}", change.GetPath())
+	require.Equal(t, "the endpoint has passed its sunset date `2026-09-30` and should be removed", change.GetUncolorizedText(checker.NewDefaultLocalizer()))
+	require.Equal(t, "../data/deprecations/openapi.yaml", change.GetRevisionSource().File)
+	require.Equal(t, 55, change.GetRevisionSource().Line)
+}
+
+// identical specs have no diff, so the check runs on their context
+func TestSunsetDatePassed_IdenticalSpecs(t *testing.T) {
+	s1, err := open("../data/deprecations/openapi.yaml", newLoaderWithOriginTracking())
+	require.NoError(t, err)
+
+	s2, err := open("../data/deprecations/openapi.yaml", newLoaderWithOriginTracking())
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	require.Nil(t, d)
+
+	revisionContext, err := diff.GetRevisionContext(diff.NewConfig(), s1.Spec, s2.Spec)
+	require.NoError(t, err)
+
+	config := singleCheckConfig(checker.SunsetDatePassedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18}))
+	errs := checker.CheckBackwardCompatibility(config, revisionContext, osm)
+
+	change := requireSingleChange(t, errs, checker.APISunsetDatePassedId)
+	require.Equal(t, "/pets/{id}", change.GetPath())
+	require.Equal(t, "../data/deprecations/openapi.yaml", change.GetRevisionSource().File)
+}
+
+// the paths of identical specs are filtered like those of a diff
+func TestSunsetDatePassed_IdenticalSpecsUnmatchPath(t *testing.T) {
+	s1, err := open("../data/deprecations/openapi.yaml", newLoaderWithOriginTracking())
+	require.NoError(t, err)
+
+	s2, err := open("../data/deprecations/openapi.yaml", newLoaderWithOriginTracking())
+	require.NoError(t, err)
+
+	diffConfig := diff.NewConfig()
+	diffConfig.UnmatchPath = "^/pets/"
+	revisionContext, err := diff.GetRevisionContext(diffConfig, s1.Spec, s2.Spec)
+	require.NoError(t, err)
+	require.NotNil(t, s2.Spec.Paths.Value("/pets/{id}"))
+
+	config := singleCheckConfig(checker.SunsetDatePassedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18}))
+	require.Empty(t, checker.CheckBackwardCompatibility(config, revisionContext, nil))
+}
+
+// library callers may pass no operation sources
+func TestSunsetDatePassed_NoOperationsSources(t *testing.T) {
+	s1, err := open("../data/deprecations/openapi.yaml", newLoaderWithOriginTracking())
+	require.NoError(t, err)
+	s1.Spec.Info.Version = "1.3.0"
+
+	s2, err := open("../data/deprecations/openapi.yaml", newLoaderWithOriginTracking())
+	require.NoError(t, err)
+
+	d, err := diff.Get(diff.NewConfig(), s1.Spec, s2.Spec)
+	require.NoError(t, err)
+
+	config := singleCheckConfig(checker.SunsetDatePassedCheck, checker.WithAsOf(civil.Date{Year: 2027, Month: 3, Day: 2}))
+	require.Len(t, checker.CheckBackwardCompatibility(config, d, nil), 5)
+}
+
+func TestSunsetDatePassed_ParametersAndProperties(t *testing.T) {
+	errs := getSunsetDatePassedChanges(t, checker.WithAsOf(civil.Date{Year: 2027, Month: 3, Day: 2}))
+	require.Len(t, errs, 5)
+
+	localizer := checker.NewDefaultLocalizer()
+	texts := make([]string, len(errs))
+	for i, err := range errs {
+		texts[i] = err.GetOperation() + " " + err.GetPath() + ": " + err.GetUncolorizedText(localizer)
+	}
+	require.ElementsMatch(t, []string{
+		"GET /pets: the `header` request parameter `X-Legacy-Tenant` has passed its sunset date `2026-12-31` and should be removed",
+		"GET /pets: the `query` request parameter `page` has passed its sunset date `2026-11-01` and should be removed",
+		"GET /pets: the response property `items/tag` for the response status `200` has passed its sunset date `2027-03-01` and should be removed",
+		"POST /pets: the `header` request parameter `X-Legacy-Tenant` has passed its sunset date `2026-12-31` and should be removed",
+		"DELETE /pets/{id}: the endpoint has passed its sunset date `2026-09-30` and should be removed",
+	}, texts)
+}
+
+// a sunset date that is today hasn't passed yet
+func TestSunsetDatePassed_SunsetToday(t *testing.T) {
+	errs := getSunsetDatePassedChanges(t, checker.WithAsOf(civil.Date{Year: 2026, Month: 9, Day: 30}))
+	require.Empty(t, errs)
+}
+
+// the stability level filters out the deprecations of less stable operations, like the beta DELETE /pets/{id}
+func TestSunsetDatePassed_StabilityLevel(t *testing.T) {
+	errs := getSunsetDatePassedChanges(t, checker.WithAsOf(civil.Date{Year: 2027, Month: 1, Day: 1}), checker.WithStabilityLevel("stable"))
+	require.Len(t, errs, 3)
+	for _, err := range errs {
+		require.Equal(t, checker.RequestParameterSunsetDatePassedId, err.GetId())
+	}
+}
+
+// the paths of the revision are reported with their rewritten prefix
+func TestSunsetDatePassed_PathPrefix(t *testing.T) {
+	s1, err := open("../data/deprecations/openapi.yaml")
+	require.NoError(t, err)
+	s1.Spec.Info.Version = "1.3.0"
+
+	s2, err := open("../data/deprecations/openapi.yaml")
+	require.NoError(t, err)
+
+	config := diff.NewConfig()
+	config.PathPrefixBase = "/v1"
+	config.PathPrefixRevision = "/v1"
+	d, osm, err := diff.GetWithOperationsSourcesMap(config, s1, s2)
+	require.NoError(t, err)
+
+	errs := checker.CheckBackwardCompatibility(singleCheckConfig(checker.SunsetDatePassedCheck, checker.WithAsOf(civil.Date{Year: 2026, Month: 10, Day: 18})), d, osm)
+	change := requireSingleChange(t, errs, checker.APISunsetDatePassedId)
+	require.Equal(t, "/v1/pets/{id}", change.GetPath())
+}
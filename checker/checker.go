@@ -4,7 +4,9 @@ package checker
 import (
 	"maps"
 	"slices"
+	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oasdiff/oasdiff/diff"
 )
 
@@ -31,6 +33,7 @@ func CheckBackwardCompatibilityUntilLevel(config *Config, diffReport *diff.Diff,
 	result = applyStabilityLevelPolicy(config, diffReport, result, operationsSources)
 
 	mergeWebhookOperationsIntoPathsDiff(diffReport)
+	mergeAsyncAPIMessagesIntoPathsDiff(diffReport)
 
 	for _, check := range config.Checks {
 		if check == nil {
@@ -56,7 +59,7 @@ func CheckBackwardCompatibilityUntilLevel(config *Config, diffReport *diff.Diff,
 
 	filteredResult := make(Changes, 0)
 	for _, change := range result {
-		if config.getLogLevel(change.GetId()) >= level {
+		if change.GetLevel() >= level {
 			filteredResult = append(filteredResult, change)
 		}
 	}
@@ -75,8 +78,9 @@ func CheckBackwardCompatibilityUntilLevel(config *Config, diffReport *diff.Diff,
 // Mutation surface (must be cloned):
 //   - PathsDiff struct itself (Deleted/Modified are reassigned)
 //   - PathsDiff.Deleted slice (truncated in-place)
-//   - PathsDiff.Modified map (webhook entries inserted, see
-//     mergeWebhookOperationsIntoPathsDiff)
+//   - PathsDiff.Modified map (webhook and AsyncAPI channel entries inserted,
+//     see mergeWebhookOperationsIntoPathsDiff and
+//     mergeAsyncAPIMessagesIntoPathsDiff)
 //   - For each PathDiff in PathsDiff.Modified: a fresh PathDiff
 //     because OperationsDiff.Deleted gets truncated and
 //     OperationsDiff.Modified has keys deleted.
@@ -130,3 +134,81 @@ func mergeWebhookOperationsIntoPathsDiff(diffReport *diff.Diff) {
 		diffReport.PathsDiff.Modified["webhook:"+name] = pathDiff
 	}
 }
+
+// mergeAsyncAPIMessagesIntoPathsDiff merges the modified messages of an
+// AsyncAPI diff into PathsDiff.Modified, so that the request and response
+// checks report changes to their payloads. Each modified channel becomes a path
+// keyed by its address, and each operation on it a method keyed by its action
+// in upper case. The messages an operation receives become media types of its
+// request body, keyed by message name, and those it sends become responses,
+// keyed by message name in place of the status code, with a media type per
+// content type.
+func mergeAsyncAPIMessagesIntoPathsDiff(diffReport *diff.Diff) {
+	if diffReport.AsyncAPIDiff == nil || diffReport.AsyncAPIDiff.ChannelsDiff == nil {
+		return
+	}
+	if diffReport.PathsDiff == nil {
+		diffReport.PathsDiff = &diff.PathsDiff{Modified: diff.ModifiedPaths{}}
+	}
+	if diffReport.PathsDiff.Modified == nil {
+		diffReport.PathsDiff.Modified = diff.ModifiedPaths{}
+	}
+
+	for address, channelDiff := range diffReport.AsyncAPIDiff.ChannelsDiff.Modified {
+		operations := diff.ModifiedOperations{}
+		for action, operationDiff := range channelDiff.OperationsDiff.Modified {
+			if operationDiff.MessagesDiff == nil || len(operationDiff.MessagesDiff.Modified) == 0 {
+				continue
+			}
+			methodDiff := &diff.MethodDiff{
+				Base:     operationDiff.Base.OpenAPI,
+				Revision: operationDiff.Revision.OpenAPI,
+			}
+			if operationDiff.Revision.IsRequest() {
+				methodDiff.RequestBodyDiff = getAsyncAPIRequestBodyDiff(operationDiff.MessagesDiff)
+			} else {
+				methodDiff.ResponsesDiff = getAsyncAPIResponsesDiff(operationDiff.MessagesDiff)
+			}
+			operations[strings.ToUpper(action)] = methodDiff
+		}
+		if len(operations) == 0 {
+			continue
+		}
+		diffReport.PathsDiff.Modified[address] = &diff.PathDiff{
+			OperationsDiff: &diff.OperationsDiff{Modified: operations},
+			Base:           &openapi3.PathItem{},
+			Revision:       &openapi3.PathItem{},
+		}
+	}
+}
+
+func getAsyncAPIRequestBodyDiff(messagesDiff *diff.MessagesDiff) *diff.RequestBodyDiff {
+	mediaTypes := diff.ModifiedMediaTypes{}
+	for name, messageDiff := range messagesDiff.Modified {
+		if messageDiff.PayloadDiff != nil {
+			mediaTypes[name] = &diff.MediaTypeDiff{SchemaDiff: messageDiff.PayloadDiff}
+		}
+	}
+	return &diff.RequestBodyDiff{
+		ContentDiff: &diff.ContentDiff{MediaTypeModified: mediaTypes},
+	}
+}
+
+func getAsyncAPIResponsesDiff(messagesDiff *diff.MessagesDiff) *diff.ResponsesDiff {
+	responses := diff.ModifiedResponses{}
+	for name, messageDiff := range messagesDiff.Modified {
+		if messageDiff.PayloadDiff == nil {
+			continue
+		}
+		responses[name] = &diff.ResponseDiff{
+			ContentDiff: &diff.ContentDiff{
+				MediaTypeModified: diff.ModifiedMediaTypes{
+					messageDiff.Revision.ContentType: &diff.MediaTypeDiff{SchemaDiff: messageDiff.PayloadDiff},
+				},
+			},
+			Base:     &openapi3.Response{},
+			Revision: &openapi3.Response{},
+		}
+	}
+	return &diff.ResponsesDiff{Modified: responses}
+}
@@ -25,6 +25,17 @@ type ComponentChange struct {
 	SourceColumnEnd int
 }
 
+// NewComponentChange creates a new ComponentChange of the given component, like ComponentSchemas
+func NewComponentChange(id string, config *Config, args []any, comment string, component string) ComponentChange {
+	return ComponentChange{
+		Id:        id,
+		Level:     config.getLogLevel(id),
+		Args:      args,
+		Comment:   comment,
+		Component: component,
+	}
+}
+
 // WithSources returns a copy of the ComponentChange with BaseSource and RevisionSource populated
 func (c ComponentChange) WithSources(baseSource, revisionSource *Source) ComponentChange {
 	c.BaseSource = baseSource
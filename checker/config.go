@@ -1,6 +1,12 @@
 package checker
 
-import "log"
+import (
+	"log"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
 
 type Config struct {
 	Checks              BackwardCompatibilityChecks
@@ -9,6 +15,10 @@ type Config struct {
 	LogLevels           map[string]Level
 	Attributes          []string
 	StabilityLevel      StabilityLevel
+	// AsOf is the date that sunset dates are compared with; the zero date means today
+	AsOf civil.Date
+	// customizedLevels holds the rules whose levels were set with WithSeverityLevels, even to their defaults
+	customizedLevels map[string]bool
 }
 
 const (
@@ -46,6 +56,19 @@ func WithSeverityLevels(severityLevels map[string]Level) Option {
 	}
 }
 
+// WithCustomRules adds the checks of the given custom rules, at the rules' levels.
+// Apply it before WithSeverityLevels, which can only override the levels of known rules.
+func WithCustomRules(rules *CustomRules) Option {
+	return func(c *Config) {
+		// rulesToChecks can't be used: the checks of custom rules are method values of one method, which it would merge
+		c.Checks = slices.Clone(c.Checks)
+		for _, rule := range rules.GetRules() {
+			c.Checks = append(c.Checks, rule.Handler)
+			c.LogLevels[rule.Id] = rule.Level
+		}
+	}
+}
+
 // WithDeprecation sets the number of days before sunset for deprecation warnings.
 func WithDeprecation(deprecationDaysBeta uint, deprecationDaysStable uint) Option {
 	return func(c *Config) {
@@ -85,6 +108,24 @@ func WithStabilityLevel(level string) Option {
 	}
 }
 
+// WithAsOf compares sunset dates with the given date instead of today, for reproducible results.
+// If the date is the zero date, the config is unchanged.
+func WithAsOf(date civil.Date) Option {
+	return func(c *Config) {
+		if !date.IsZero() {
+			c.AsOf = date
+		}
+	}
+}
+
+// getAsOf returns the date that sunset dates are compared with
+func (config *Config) getAsOf() civil.Date {
+	if config == nil || config.AsOf.IsZero() {
+		return civil.DateOf(time.Now())
+	}
+	return config.AsOf
+}
+
 func (config *Config) getLogLevel(checkId string) Level {
 	level, ok := config.LogLevels[checkId]
 
@@ -96,10 +137,28 @@ func (config *Config) getLogLevel(checkId string) Level {
 
 }
 
+// getPreciseLevel returns the level of a change whose severity the check determined from the change itself,
+// unless the user customized the level of the change's rule
+func (config *Config) getPreciseLevel(checkId string, level Level) Level {
+	if config.isLevelCustomized(checkId) {
+		return config.getLogLevel(checkId)
+	}
+	return level
+}
+
+// isLevelCustomized reports whether the user set the level of a rule, with WithSeverityLevels or by changing LogLevels directly
+func (config *Config) isLevelCustomized(checkId string) bool {
+	return config.customizedLevels[checkId] || config.getLogLevel(checkId) != GetCheckLevels()[checkId]
+}
+
 func (config *Config) setLogLevel(checkId string, level Level) {
 	if _, ok := config.LogLevels[checkId]; !ok {
 		log.Fatal("failed to set log level with invalid check id: ", checkId)
 	}
 
 	config.LogLevels[checkId] = level
+	if config.customizedLevels == nil {
+		config.customizedLevels = map[string]bool{}
+	}
+	config.customizedLevels[checkId] = true
 }
@@ -8,8 +8,8 @@ import (
 )
 
 const (
-	numOfChecks = 126
-	numOfIds    = 509
+	numOfChecks = 128
+	numOfIds    = 556
 )
 
 func TestNewConfig(t *testing.T) {
@@ -0,0 +1,511 @@
+package checker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"go.yaml.in/yaml/v3"
+)
+
+// ConsumerUsage is the part of the API that one consumer uses. It is loaded by
+// LoadConsumerUsage from one of these formats:
+//
+// A usage file, in YAML or JSON:
+//
+//	consumer: mobile-app
+//	operations:
+//	  - listUsers                  # an operationId
+//	  - GET /users/{userId}        # a method and a path
+//	  - operationId: createUser
+//	    fields: [name, address/city]
+//
+// The operations alone, as a YAML or JSON list, or as plain text with one
+// operation per line.
+//
+// An OpenAPI spec holding the subset of the API that the consumer uses: its
+// operations, and the properties of their request and response schemas.
+//
+// A HAR file recorded from the consumer's traffic: the requests' methods and
+// URLs, and the properties of their JSON request and response bodies.
+type ConsumerUsage struct {
+	Consumer   string          `yaml:"consumer" json:"consumer"`
+	Operations []UsedOperation `yaml:"operations" json:"operations"`
+}
+
+// UsedOperation is an operation that a consumer uses, identified by its
+// OperationId or by its Method and Path, where a path parameter matches any
+// segment. Fields are the "/"-separated paths of the properties that the
+// consumer sends or reads; when there are none, the consumer is considered to
+// use all of them.
+type UsedOperation struct {
+	OperationId string   `yaml:"operationId,omitempty" json:"operationId,omitempty"`
+	Method      string   `yaml:"method,omitempty" json:"method,omitempty"`
+	Path        string   `yaml:"path,omitempty" json:"path,omitempty"`
+	Fields      []string `yaml:"fields,omitempty" json:"fields,omitempty"`
+
+	// recorded marks an operation taken from a request URL, whose path may
+	// start with the server's base path
+	recorded bool
+}
+
+// UnmarshalYAML accepts an operation as a mapping, or as a string: a method
+// and a path, e.g. "GET /users", or otherwise an operationId.
+func (op *UsedOperation) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		parsed, err := parseUsedOperation(value.Value)
+		if err != nil {
+			return err
+		}
+		*op = parsed
+		return nil
+	}
+
+	type plain UsedOperation
+	return value.Decode((*plain)(op))
+}
+
+func parseUsedOperation(s string) (UsedOperation, error) {
+	s = strings.TrimSpace(s)
+	if method, path, found := strings.Cut(s, " "); found && isHTTPMethod(method) {
+		return UsedOperation{Method: method, Path: strings.TrimSpace(path)}, nil
+	}
+	if s == "" || strings.ContainsAny(s, " \t") {
+		return UsedOperation{}, fmt.Errorf("invalid operation %q, expected an operationId or a method and a path", s)
+	}
+	return UsedOperation{OperationId: s}, nil
+}
+
+func isHTTPMethod(s string) bool {
+	return slices.Contains([]string{
+		http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+	}, strings.ToUpper(s))
+}
+
+func (op UsedOperation) validate() error {
+	if op.OperationId == "" && (op.Method == "" || op.Path == "") {
+		return errors.New("an operation must have an operationId, or a method and a path")
+	}
+	return nil
+}
+
+// LoadConsumerUsage loads the usage of a consumer (see ConsumerUsage). The
+// consumer is named by the usage file's "consumer" key or, failing that, by the
+// file's name without its extension.
+func LoadConsumerUsage(file string) (*ConsumerUsage, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := parseConsumerUsage(file, data)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range usage.Operations {
+		if err := op.validate(); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i+1, err)
+		}
+	}
+
+	if usage.Consumer == "" {
+		usage.Consumer = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	return usage, nil
+}
+
+func parseConsumerUsage(file string, data []byte) (*ConsumerUsage, error) {
+	var probe any
+	if err := yaml.Unmarshal(data, &probe); err != nil {
+		// not YAML nor JSON, so plain text
+		return parseConsumerUsageLines(data)
+	}
+
+	switch doc := probe.(type) {
+	case map[string]any:
+		if _, ok := doc["openapi"]; ok {
+			return loadConsumerSpec(file)
+		}
+		if _, ok := doc["log"]; ok {
+			return parseHAR(data)
+		}
+		if _, ok := doc["operations"]; !ok {
+			return nil, errors.New(`expected a usage file with an "operations" key, an OpenAPI spec or a HAR file`)
+		}
+		var usage ConsumerUsage
+		if err := yaml.Unmarshal(data, &usage); err != nil {
+			return nil, err
+		}
+		return &usage, nil
+	case []any:
+		var usage ConsumerUsage
+		if err := yaml.Unmarshal(data, &usage.Operations); err != nil {
+			return nil, err
+		}
+		return &usage, nil
+	default:
+		// YAML folds the lines of a plain text file into one string
+		return parseConsumerUsageLines(data)
+	}
+}
+
+func parseConsumerUsageLines(data []byte) (*ConsumerUsage, error) {
+	usage := ConsumerUsage{}
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		op, err := parseUsedOperation(line)
+		if err != nil {
+			return nil, err
+		}
+		usage.Operations = append(usage.Operations, op)
+	}
+	return &usage, nil
+}
+
+// loadConsumerSpec returns the operations of an OpenAPI spec, with the
+// properties of their request and response schemas as fields.
+func loadConsumerSpec(file string) (*ConsumerUsage, error) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	spec, err := loader.LoadFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := ConsumerUsage{}
+	if spec.Paths == nil {
+		return &usage, nil
+	}
+	for _, path := range spec.Paths.InMatchingOrder() {
+		operations := spec.Paths.Value(path).Operations()
+		for _, method := range slices.Sorted(maps.Keys(operations)) {
+			operation := operations[method]
+			fields := map[string]struct{}{}
+			if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+				addContentFields(fields, operation.RequestBody.Value.Content)
+			}
+			if operation.Responses != nil {
+				for _, response := range operation.Responses.Map() {
+					if response.Value != nil {
+						addContentFields(fields, response.Value.Content)
+					}
+				}
+			}
+			usage.Operations = append(usage.Operations, UsedOperation{
+				OperationId: operation.OperationID,
+				Method:      method,
+				Path:        path,
+				Fields:      sortedKeys(fields),
+			})
+		}
+	}
+	return &usage, nil
+}
+
+func addContentFields(fields map[string]struct{}, content openapi3.Content) {
+	for _, mediaType := range content {
+		if mediaType != nil && mediaType.Schema != nil {
+			addSchemaFields(fields, "", mediaType.Schema.Value, nil)
+		}
+	}
+}
+
+// addSchemaFields adds the paths of the schema's properties, recursively. The
+// stack holds the schemas being walked, to stop at recursive schemas.
+func addSchemaFields(fields map[string]struct{}, prefix string, schema *openapi3.Schema, stack []*openapi3.Schema) {
+	if schema == nil || slices.Contains(stack, schema) {
+		return
+	}
+	stack = append(stack, schema)
+
+	for name, property := range schema.Properties {
+		path := joinPath(prefix, name)
+		fields[path] = struct{}{}
+		if property != nil {
+			addSchemaFields(fields, path, property.Value, stack)
+		}
+	}
+	if schema.Items != nil {
+		addSchemaFields(fields, prefix, schema.Items.Value, stack)
+	}
+	for _, schemas := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, ref := range schemas {
+			if ref != nil {
+				addSchemaFields(fields, prefix, ref.Value, stack)
+			}
+		}
+	}
+	if schema.AdditionalProperties.Schema != nil {
+		addSchemaFields(fields, prefix, schema.AdditionalProperties.Schema.Value, stack)
+	}
+}
+
+type harFile struct {
+	Log struct {
+		Entries []struct {
+			Request struct {
+				Method   string `json:"method"`
+				URL      string `json:"url"`
+				PostData struct {
+					MimeType string `json:"mimeType"`
+					Text     string `json:"text"`
+				} `json:"postData"`
+			} `json:"request"`
+			Response struct {
+				Content struct {
+					MimeType string `json:"mimeType"`
+					Text     string `json:"text"`
+				} `json:"content"`
+			} `json:"response"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+// parseHAR returns the requests of a HAR file as operations, one per method
+// and URL path, with the properties of their JSON bodies as fields.
+func parseHAR(data []byte) (*ConsumerUsage, error) {
+	var har harFile
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, fmt.Errorf("invalid HAR file: %w", err)
+	}
+
+	usage := ConsumerUsage{}
+	index := map[string]int{}
+	fields := []map[string]struct{}{}
+	for i, entry := range har.Log.Entries {
+		u, err := url.Parse(entry.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("HAR entry %d: %w", i+1, err)
+		}
+
+		method := strings.ToUpper(entry.Request.Method)
+		key := method + " " + u.Path
+		j, ok := index[key]
+		if !ok {
+			j = len(usage.Operations)
+			index[key] = j
+			usage.Operations = append(usage.Operations, UsedOperation{Method: method, Path: u.Path, recorded: true})
+			fields = append(fields, map[string]struct{}{})
+		}
+
+		addJSONFields(fields[j], entry.Request.PostData.MimeType, entry.Request.PostData.Text)
+		addJSONFields(fields[j], entry.Response.Content.MimeType, entry.Response.Content.Text)
+	}
+
+	for j := range usage.Operations {
+		usage.Operations[j].Fields = sortedKeys(fields[j])
+	}
+	return &usage, nil
+}
+
+// addJSONFields adds the paths of the properties of a JSON body; other bodies have none.
+func addJSONFields(fields map[string]struct{}, mimeType, text string) {
+	if text == "" || !strings.Contains(mimeType, "json") {
+		return
+	}
+	var value any
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return
+	}
+	addValueFields(fields, "", value)
+}
+
+func addValueFields(fields map[string]struct{}, prefix string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for name, property := range v {
+			path := joinPath(prefix, name)
+			fields[path] = struct{}{}
+			addValueFields(fields, path, property)
+		}
+	case []any:
+		for _, item := range v {
+			addValueFields(fields, prefix, item)
+		}
+	}
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// FilterConsumerUsage returns the changes that affect at least one of the
+// consumers, each with the names of the consumers it affects (see
+// Change.GetConsumers). A change to an operation affects the consumers that use
+// the operation; a change to a property affects only those that use the
+// property, unless it requires every caller to send something new. A change to
+// the API's security affects every consumer; other changes outside operations,
+// such as changes to components, are reported through the operations they
+// affect, and are dropped.
+func FilterConsumerUsage(changes Changes, usages []*ConsumerUsage) Changes {
+	rules := map[string]BackwardCompatibilityRule{}
+	for _, rule := range GetAllRules() {
+		rules[rule.Id] = rule
+	}
+
+	result := make(Changes, 0, len(changes))
+	for _, change := range changes {
+		consumers := []string{}
+		for _, usage := range usages {
+			if usage.affectedBy(change, rules) {
+				consumers = append(consumers, usage.Consumer)
+			}
+		}
+		if len(consumers) > 0 {
+			result = append(result, withConsumers(change, consumers))
+		}
+	}
+	return result
+}
+
+func (usage *ConsumerUsage) affectedBy(change Change, rules map[string]BackwardCompatibilityRule) bool {
+	switch change.(type) {
+	case SecurityChange:
+		return true
+	case ApiChange:
+	default:
+		return false
+	}
+
+	for _, op := range usage.Operations {
+		if !op.matchesOperation(change) {
+			continue
+		}
+		field, scoped := fieldScope(change, rules)
+		if !scoped || len(op.Fields) == 0 || op.usesField(field) {
+			return true
+		}
+	}
+	return false
+}
+
+func (op UsedOperation) matchesOperation(change Change) bool {
+	if op.OperationId != "" && op.OperationId == change.GetOperationId() {
+		return true
+	}
+	return op.Method != "" &&
+		strings.EqualFold(op.Method, change.GetOperation()) &&
+		matchPathTemplate(change.GetPath(), op.Path, op.recorded)
+}
+
+// matchPathTemplate reports whether path matches the template, where a path
+// parameter in either matches any segment. With base, path may start with
+// extra segments, such as a server's base path in a request URL.
+func matchPathTemplate(template, path string, base bool) bool {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	if base && len(pathSegments) > len(templateSegments) {
+		pathSegments = pathSegments[len(pathSegments)-len(templateSegments):]
+	}
+	if len(pathSegments) != len(templateSegments) {
+		return false
+	}
+
+	for i, segment := range templateSegments {
+		if isPathParam(segment) || isPathParam(pathSegments[i]) {
+			continue
+		}
+		if segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isPathParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// requiredByAllRules are the property changes that affect every caller of the
+// operation, whether it used to send the property or not.
+var requiredByAllRules = map[string]bool{
+	NewRequiredRequestPropertyId:               true,
+	NewRequiredRequestPropertyWithDefaultId:    true,
+	RequestPropertyBecameRequiredId:            true,
+	RequestPropertyBecameRequiredWithDefaultId: true,
+}
+
+// fieldScope returns the property that a change is about, if it only affects
+// the consumers that use the property.
+func fieldScope(change Change, rules map[string]BackwardCompatibilityRule) (string, bool) {
+	id := change.GetId()
+	rule, ok := rules[id]
+	if !ok || rule.Area != AreaSchema || requiredByAllRules[id] {
+		return "", false
+	}
+
+	field, ok := getChangedProperty(id, change.GetArgs())
+	if !ok {
+		return "", false
+	}
+	return normalizeField(field), true
+}
+
+// usesField reports whether the consumer uses the field, a property inside it,
+// or the property that contains it.
+func (op UsedOperation) usesField(field string) bool {
+	for _, used := range op.Fields {
+		used = normalizeField(used)
+		if used == field || strings.HasPrefix(used, field+"/") || strings.HasPrefix(field, used+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeField drops the segments of a property path that name schema
+// keywords rather than properties, e.g. "data/items/allOf[#/components/schemas/User]/name"
+// becomes "data/name".
+func normalizeField(field string) string {
+	// a subschema's name may contain slashes, so it is dropped before splitting
+	field = subschemaSegment.ReplaceAllString(field, "")
+
+	segments := []string{}
+	for _, segment := range strings.Split(field, "/") {
+		if segment == "" || segment == "items" || segment == "additionalProperties" {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+var subschemaSegment = regexp.MustCompile(`(allOf|anyOf|oneOf)\[[^\]]*\]`)
+
+func withConsumers(change Change, consumers []string) Change {
+	switch c := change.(type) {
+	case ApiChange:
+		c.Consumers = consumers
+		return c
+	case SecurityChange:
+		c.Consumers = consumers
+		return c
+	case ComponentChange:
+		c.Consumers = consumers
+		return c
+	case InfoChange:
+		c.Consumers = consumers
+		return c
+	}
+	return change
+}
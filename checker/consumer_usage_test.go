@@ -0,0 +1,189 @@
+package checker_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConsumerUsage_UsageFile(t *testing.T) {
+	usage, err := checker.LoadConsumerUsage("../data/consumer-usage/mobile.yaml")
+	require.NoError(t, err)
+	require.Equal(t, &checker.ConsumerUsage{
+		Consumer: "mobile-app",
+		Operations: []checker.UsedOperation{
+			{OperationId: "listUsers", Fields: []string{"name", "email"}},
+			{Method: "GET", Path: "/users/{id}"},
+		},
+	}, usage)
+}
+
+func TestLoadConsumerUsage_List(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ios.json")
+	require.NoError(t, os.WriteFile(file, []byte(`["listUsers", {"method": "post", "path": "/users"}]`), 0644))
+
+	usage, err := checker.LoadConsumerUsage(file)
+	require.NoError(t, err)
+	require.Equal(t, &checker.ConsumerUsage{
+		Consumer: "ios",
+		Operations: []checker.UsedOperation{
+			{OperationId: "listUsers"},
+			{Method: "post", Path: "/users"},
+		},
+	}, usage)
+}
+
+func TestLoadConsumerUsage_Text(t *testing.T) {
+	usage, err := checker.LoadConsumerUsage("../data/consumer-usage/cli.txt")
+	require.NoError(t, err)
+	require.Equal(t, &checker.ConsumerUsage{
+		Consumer: "cli",
+		Operations: []checker.UsedOperation{
+			{OperationId: "listUsers"},
+			{Method: "DELETE", Path: "/users/{userId}"},
+		},
+	}, usage)
+}
+
+func TestLoadConsumerUsage_Spec(t *testing.T) {
+	usage, err := checker.LoadConsumerUsage("../data/consumer-usage/web.yaml")
+	require.NoError(t, err)
+	require.Equal(t, &checker.ConsumerUsage{
+		Consumer: "web",
+		Operations: []checker.UsedOperation{
+			{Method: "POST", Path: "/users", Fields: []string{"name"}},
+			{Method: "DELETE", Path: "/users/{userId}", Fields: []string{}},
+		},
+	}, usage)
+}
+
+func TestLoadConsumerUsage_HAR(t *testing.T) {
+	usage, err := checker.LoadConsumerUsage("../data/consumer-usage/partner.har")
+	require.NoError(t, err)
+	require.Equal(t, "partner", usage.Consumer)
+	require.Len(t, usage.Operations, 1)
+	require.Equal(t, "GET", usage.Operations[0].Method)
+	require.Equal(t, "/v1/users", usage.Operations[0].Path)
+	require.Equal(t, []string{"name", "phone"}, usage.Operations[0].Fields)
+}
+
+func TestLoadConsumerUsage_Invalid(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "usage.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("operations:\n  - fields: [name]\n"), 0644))
+
+	_, err := checker.LoadConsumerUsage(file)
+	require.EqualError(t, err, "operation 1: an operation must have an operationId, or a method and a path")
+}
+
+func TestLoadConsumerUsage_UnknownMapping(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "usage.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("ops: [listUsers]\n"), 0644))
+
+	_, err := checker.LoadConsumerUsage(file)
+	require.Error(t, err)
+}
+
+func consumerChange(id, operation, path string, args ...any) checker.ApiChange {
+	return checker.ApiChange{Id: id, Level: checker.WARN, Operation: operation, Path: path, Args: args}
+}
+
+func TestFilterConsumerUsage(t *testing.T) {
+	usages := []*checker.ConsumerUsage{
+		{Consumer: "mobile", Operations: []checker.UsedOperation{{OperationId: "listUsers", Fields: []string{"data/name"}}}},
+		{Consumer: "web", Operations: []checker.UsedOperation{{Method: "get", Path: "/users"}}},
+	}
+
+	listUsers := func(change checker.ApiChange) checker.ApiChange {
+		change.OperationId = "listUsers"
+		return change
+	}
+
+	nameRemoved := listUsers(consumerChange(checker.ResponseOptionalPropertyRemovedId, "GET", "/users", "data/items/name", "200"))
+	emailRemoved := listUsers(consumerChange(checker.ResponseOptionalPropertyRemovedId, "GET", "/users", "data/items/email", "200"))
+	dataRemoved := listUsers(consumerChange(checker.ResponseOptionalPropertyRemovedId, "GET", "/users", "data", "200"))
+	roleRequired := listUsers(consumerChange(checker.NewRequiredRequestPropertyId, "GET", "/users", "role"))
+	other := consumerChange(checker.APIRemovedWithoutDeprecationId, "DELETE", "/users/{id}")
+	security := checker.SecurityChange{Id: checker.APIGlobalSecurityAddedCheckId, Level: checker.INFO}
+	component := checker.ComponentChange{Id: checker.APISchemasRemovedId, Level: checker.INFO}
+
+	result := checker.FilterConsumerUsage(checker.Changes{nameRemoved, emailRemoved, dataRemoved, roleRequired, other, security, component}, usages)
+	require.Len(t, result, 5)
+
+	require.Equal(t, []string{"mobile", "web"}, checker.GetConsumers(result[0]))
+	require.Equal(t, nameRemoved.Args, result[0].GetArgs())
+	require.Equal(t, []string{"web"}, checker.GetConsumers(result[1]))
+	require.Equal(t, emailRemoved.Args, result[1].GetArgs())
+	require.Equal(t, []string{"mobile", "web"}, checker.GetConsumers(result[2]))
+	require.Equal(t, dataRemoved.Args, result[2].GetArgs())
+	require.Equal(t, []string{"mobile", "web"}, checker.GetConsumers(result[3]))
+	require.Equal(t, checker.NewRequiredRequestPropertyId, result[3].GetId())
+	require.Equal(t, []string{"mobile", "web"}, checker.GetConsumers(result[4]))
+	require.Equal(t, checker.APIGlobalSecurityAddedCheckId, result[4].GetId())
+}
+
+func TestFilterConsumerUsage_RecordedPath(t *testing.T) {
+	usage, err := checker.LoadConsumerUsage("../data/consumer-usage/partner.har")
+	require.NoError(t, err)
+
+	matching := consumerChange(checker.APIRemovedWithoutDeprecationId, "GET", "/users")
+	other := consumerChange(checker.APIRemovedWithoutDeprecationId, "GET", "/v1")
+
+	result := checker.FilterConsumerUsage(checker.Changes{matching, other}, []*checker.ConsumerUsage{usage})
+	require.Len(t, result, 1)
+	require.Equal(t, "/users", result[0].GetPath())
+}
+
+func TestFilterConsumerUsage_PathParams(t *testing.T) {
+	usage := &checker.ConsumerUsage{Consumer: "cli", Operations: []checker.UsedOperation{{Method: "DELETE", Path: "/users/{id}"}}}
+
+	matching := consumerChange(checker.APIRemovedWithoutDeprecationId, "DELETE", "/users/{userId}")
+	other := consumerChange(checker.APIRemovedWithoutDeprecationId, "DELETE", "/users/{userId}/roles")
+
+	result := checker.FilterConsumerUsage(checker.Changes{matching, other}, []*checker.ConsumerUsage{usage})
+	require.Len(t, result, 1)
+	require.Equal(t, "/users/{userId}", result[0].GetPath())
+}
+
+// the enum and pattern rules name the property by their second argument
+func TestFilterConsumerUsage_EnumAndPattern(t *testing.T) {
+	s1, err := open("../data/consumer-usage/base.yaml")
+	require.NoError(t, err)
+	s2, err := open("../data/consumer-usage/base.yaml")
+	require.NoError(t, err)
+
+	properties := s1.Spec.Paths.Value("/users").Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties
+	properties["status"] = &openapi3.SchemaRef{Value: openapi3.NewStringSchema().WithEnum("active", "inactive")}
+	properties["code"] = &openapi3.SchemaRef{Value: openapi3.NewStringSchema()}
+
+	properties = s2.Spec.Paths.Value("/users").Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties
+	properties["status"] = &openapi3.SchemaRef{Value: openapi3.NewStringSchema().WithEnum("active")}
+	properties["code"] = &openapi3.SchemaRef{Value: openapi3.NewStringSchema().WithPattern("^[a-z]+$")}
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	changes := checker.CheckBackwardCompatibility(allChecksConfig(), d, osm)
+	require.Len(t, changes, 2)
+
+	usages := []*checker.ConsumerUsage{
+		{Consumer: "status", Operations: []checker.UsedOperation{{OperationId: "createUser", Fields: []string{"status"}}}},
+		{Consumer: "code", Operations: []checker.UsedOperation{{OperationId: "createUser", Fields: []string{"code"}}}},
+		{Consumer: "name", Operations: []checker.UsedOperation{{OperationId: "createUser", Fields: []string{"name"}}}},
+	}
+	result := checker.FilterConsumerUsage(changes, usages)
+	require.Len(t, result, 2)
+	for _, change := range result {
+		switch change.GetId() {
+		case checker.RequestPropertyEnumValueRemovedId:
+			require.Equal(t, []string{"status"}, checker.GetConsumers(change))
+		case checker.RequestPropertyPatternAddedId:
+			require.Equal(t, []string{"code"}, checker.GetConsumers(change))
+		default:
+			require.Fail(t, "unexpected change", change.GetId())
+		}
+	}
+}
@@ -0,0 +1,596 @@
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/diff"
+	"go.yaml.in/yaml/v3"
+)
+
+// CustomRule is a rule declared in a custom rules file rather than written in Go.
+// It matches endpoints that were added or removed, or changes to a field of the request and response schemas,
+// and reports each match as an ApiChange with the rule's id, level and message.
+type CustomRule struct {
+	Id          string          `yaml:"id"`
+	Level       string          `yaml:"level"`
+	Direction   string          `yaml:"direction,omitempty"`
+	Area        string          `yaml:"area,omitempty"`
+	Kind        string          `yaml:"kind,omitempty"`
+	Action      string          `yaml:"action,omitempty"`
+	Description string          `yaml:"description,omitempty"`
+	Message     string          `yaml:"message"`
+	Comment     string          `yaml:"comment,omitempty"`
+	Match       CustomRuleMatch `yaml:"match"`
+}
+
+// CustomRuleMatch selects the changes that a custom rule reports: either endpoints or schema changes, in the operations
+// whose path, method and extensions match
+type CustomRuleMatch struct {
+	// Path is a regular expression that the path of the operation must match
+	Path string `yaml:"path,omitempty"`
+	// Methods are the methods of the operation, any method if empty
+	Methods []string `yaml:"methods,omitempty"`
+	// Extension is an extension that the operation must have
+	Extension string `yaml:"extension,omitempty"`
+	// WithoutExtension is an extension that the operation must not have
+	WithoutExtension string `yaml:"without-extension,omitempty"`
+	// Endpoint matches endpoints that were added or removed
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// Schema matches changes to a field of the request and response schemas
+	Schema *CustomSchemaMatch `yaml:"schema,omitempty"`
+}
+
+// CustomSchemaMatch selects changes to a field of the request and response schemas, and of their properties
+type CustomSchemaMatch struct {
+	// In is request or response, both if empty
+	In string `yaml:"in,omitempty"`
+	// Field is the changed field, named as in the diff output, e.g. maxItems or enum
+	Field string `yaml:"field"`
+	// Change is added, removed or modified, any change if empty.
+	// A value of a list field, like enum, is added or removed; the value of another field is added when it had none before,
+	// removed when it has none after, and modified otherwise.
+	Change string `yaml:"change,omitempty"`
+	// Type is a type that the schema must have, before or after the change
+	Type string `yaml:"type,omitempty"`
+	// Extension is an extension that the schema must have, before or after the change
+	Extension string `yaml:"extension,omitempty"`
+	// WithoutExtension is an extension that the schema must not have, before nor after the change
+	WithoutExtension string `yaml:"without-extension,omitempty"`
+}
+
+// CustomRules is the content of a custom rules file
+type CustomRules struct {
+	Rules []CustomRule `yaml:"rules"`
+
+	// compiled holds the parsed form of each rule, in the order of Rules
+	compiled []*customRule
+}
+
+const (
+	customEndpointAdded   = "added"
+	customEndpointRemoved = "removed"
+
+	customChangeAdded    = "added"
+	customChangeRemoved  = "removed"
+	customChangeModified = "modified"
+
+	customInRequest  = "request"
+	customInResponse = "response"
+)
+
+// customMessageVariables are the variables that the message of a custom rule can refer to
+var customMessageVariables = []string{"Operation", "OperationId", "Path", "Status", "MediaType", "Property", "Field", "From", "To", "Value"}
+
+// customRule is a custom rule, parsed and checked
+type customRule struct {
+	CustomRule
+	rule    BackwardCompatibilityRule
+	path    *regexp.Regexp
+	message *template.Template
+	// field is the index of the matched field in diff.SchemaDiff
+	field int
+}
+
+// LoadCustomRules reads a custom rules file
+func LoadCustomRules(file string) (*CustomRules, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	return GetCustomRules(f)
+}
+
+// GetCustomRules reads custom rules from a reader and checks them
+func GetCustomRules(source io.Reader) (*CustomRules, error) {
+	decoder := yaml.NewDecoder(source)
+	decoder.KnownFields(true)
+
+	result := CustomRules{}
+	if err := decoder.Decode(&result); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	builtinIds := GetCheckLevels()
+	ids := map[string]bool{}
+	for i, rule := range result.Rules {
+		if _, ok := builtinIds[rule.Id]; ok {
+			return nil, fmt.Errorf("rule #%d: id %q is already used by a built-in rule", i+1, rule.Id)
+		}
+		if ids[rule.Id] {
+			return nil, fmt.Errorf("rule #%d: id %q is used by an earlier rule", i+1, rule.Id)
+		}
+		ids[rule.Id] = true
+
+		compiled, err := newCustomRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule #%d: %w", i+1, err)
+		}
+		result.compiled = append(result.compiled, compiled)
+	}
+
+	return &result, nil
+}
+
+// GetRules returns the custom rules as backward compatibility rules, each with the check that reports its changes
+func (rules *CustomRules) GetRules() BackwardCompatibilityRules {
+	if rules == nil {
+		return nil
+	}
+
+	result := make(BackwardCompatibilityRules, len(rules.compiled))
+	for i, rule := range rules.compiled {
+		result[i] = rule.rule
+	}
+	return result
+}
+
+// GetIds returns the ids of the custom rules
+func (rules *CustomRules) GetIds() []string {
+	return rulesToIIs(rules.GetRules())
+}
+
+func newCustomRule(rule CustomRule) (*customRule, error) {
+	if rule.Id == "" {
+		return nil, errors.New("missing id")
+	}
+
+	result := customRule{CustomRule: rule}
+
+	level, err := NewLevel(rule.Level)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rule.Id, err)
+	}
+
+	direction, err := parseCustomClassification("direction", rule.Direction, DirectionRequest, DirectionNone)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rule.Id, err)
+	}
+	area, err := parseCustomClassification("area", rule.Area, AreaSchema, AreaNone)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rule.Id, err)
+	}
+	kind, err := parseCustomClassification("kind", rule.Kind, KindExistence, KindNone)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rule.Id, err)
+	}
+	action, err := parseCustomClassification("action", rule.Action, ActionAdd, ActionNone)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rule.Id, err)
+	}
+
+	result.rule = BackwardCompatibilityRule{
+		Id:          rule.Id,
+		Level:       level,
+		Description: rule.Description,
+		Handler:     result.check,
+		Direction:   direction,
+		Area:        area,
+		Kind:        kind,
+		Action:      action,
+	}
+
+	if err := result.parseMatch(); err != nil {
+		return nil, fmt.Errorf("%s: %w", rule.Id, err)
+	}
+
+	if err := result.parseMessage(); err != nil {
+		return nil, fmt.Errorf("%s: %w", rule.Id, err)
+	}
+
+	return &result, nil
+}
+
+// parseCustomClassification returns the value between first and last whose name is the given one, or last if the name is empty
+func parseCustomClassification[T interface {
+	~int8
+	fmt.Stringer
+}](what, name string, first, last T) (T, error) {
+	if name == "" {
+		return last, nil
+	}
+
+	names := []string{}
+	for value := first; value <= last; value++ {
+		if value.String() == name {
+			return value, nil
+		}
+		names = append(names, value.String())
+	}
+	return last, fmt.Errorf("invalid %s %q, expected one of: %s", what, name, strings.Join(names, ", "))
+}
+
+func (rule *customRule) parseMatch() error {
+	match := rule.Match
+
+	if match.Path != "" {
+		path, err := regexp.Compile(match.Path)
+		if err != nil {
+			return fmt.Errorf("invalid path: %w", err)
+		}
+		rule.path = path
+	}
+
+	switch {
+	case match.Endpoint == "" && match.Schema == nil:
+		return errors.New("match needs an endpoint or a schema")
+	case match.Endpoint != "" && match.Schema != nil:
+		return errors.New("match can't have both an endpoint and a schema")
+	case match.Schema != nil:
+		return rule.parseSchemaMatch()
+	}
+
+	if match.Endpoint != customEndpointAdded && match.Endpoint != customEndpointRemoved {
+		return fmt.Errorf("invalid endpoint %q, expected added or removed", match.Endpoint)
+	}
+	return nil
+}
+
+func (rule *customRule) parseSchemaMatch() error {
+	schema := rule.Match.Schema
+
+	if schema.In != "" && schema.In != customInRequest && schema.In != customInResponse {
+		return fmt.Errorf("invalid schema in %q, expected request or response", schema.In)
+	}
+
+	if schema.Change != "" && schema.Change != customChangeAdded && schema.Change != customChangeRemoved && schema.Change != customChangeModified {
+		return fmt.Errorf("invalid schema change %q, expected added, removed or modified", schema.Change)
+	}
+
+	field, ok := getCustomSchemaField(schema.Field)
+	if !ok {
+		return fmt.Errorf("invalid schema field %q", schema.Field)
+	}
+	rule.field = field
+
+	return nil
+}
+
+// parseMessage parses the message template, and checks it by rendering it with every variable
+func (rule *customRule) parseMessage() error {
+	if rule.Message == "" {
+		return errors.New("missing message")
+	}
+
+	message, err := template.New(rule.Id).Option("missingkey=error").Parse(rule.Message)
+	if err != nil {
+		return fmt.Errorf("invalid message: %w", err)
+	}
+
+	data := map[string]any{}
+	for _, name := range customMessageVariables {
+		data[name] = ""
+	}
+	if err := message.Execute(io.Discard, data); err != nil {
+		return fmt.Errorf("invalid message: %w", err)
+	}
+
+	rule.message = message
+	return nil
+}
+
+var (
+	customValueDiffType    = reflect.TypeFor[*diff.ValueDiff]()
+	customEnumDiffType     = reflect.TypeFor[*diff.EnumDiff]()
+	customStringsDiffType  = reflect.TypeFor[*diff.StringsDiff]()
+	customRequiredDiffType = reflect.TypeFor[*diff.RequiredPropertiesDiff]()
+)
+
+// getCustomSchemaField returns the index of the field of diff.SchemaDiff with the given name, if it is a value or a list of values
+func getCustomSchemaField(name string) (int, bool) {
+	schemaDiffType := reflect.TypeFor[diff.SchemaDiff]()
+	for i := range schemaDiffType.NumField() {
+		field := schemaDiffType.Field(i)
+		if tag, _, _ := strings.Cut(field.Tag.Get("yaml"), ","); tag != name {
+			continue
+		}
+		switch field.Type {
+		case customValueDiffType, customEnumDiffType, customStringsDiffType, customRequiredDiffType:
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// customFieldChange is a change to a schema field: a changed value, or a value that was added to or removed from a list
+type customFieldChange struct {
+	change string
+	args   map[string]any
+}
+
+// getCustomFieldChanges returns the changes to the field of the schema with the given index
+func getCustomFieldChanges(schemaDiff *diff.SchemaDiff, field int) []customFieldChange {
+	switch fieldDiff := reflect.ValueOf(schemaDiff).Elem().Field(field).Interface().(type) {
+	case *diff.ValueDiff:
+		if fieldDiff == nil {
+			return nil
+		}
+		change := customChangeModified
+		switch {
+		case fieldDiff.From == nil:
+			change = customChangeAdded
+		case fieldDiff.To == nil:
+			change = customChangeRemoved
+		}
+		return []customFieldChange{{change: change, args: map[string]any{"From": fieldDiff.From, "To": fieldDiff.To}}}
+	case *diff.EnumDiff:
+		if fieldDiff == nil {
+			return nil
+		}
+		return getCustomListChanges(fieldDiff.Added, fieldDiff.Deleted)
+	case *diff.StringsDiff:
+		if fieldDiff == nil {
+			return nil
+		}
+		return getCustomListChanges(toAnySlice(fieldDiff.Added), toAnySlice(fieldDiff.Deleted))
+	case *diff.RequiredPropertiesDiff:
+		if fieldDiff == nil {
+			return nil
+		}
+		return getCustomListChanges(toAnySlice(fieldDiff.Added), toAnySlice(fieldDiff.Deleted))
+	}
+	return nil
+}
+
+func getCustomListChanges(added, deleted []any) []customFieldChange {
+	result := []customFieldChange{}
+	for _, value := range added {
+		result = append(result, customFieldChange{change: customChangeAdded, args: map[string]any{"Value": value}})
+	}
+	for _, value := range deleted {
+		result = append(result, customFieldChange{change: customChangeRemoved, args: map[string]any{"Value": value}})
+	}
+	return result
+}
+
+func toAnySlice(values []string) []any {
+	result := make([]any, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
+
+// check reports the changes that the rule matches
+func (rule *customRule) check(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+	if diffReport == nil || diffReport.PathsDiff == nil {
+		return result
+	}
+
+	if rule.Match.Schema != nil {
+		return rule.checkSchemas(diffReport, operationsSources, config)
+	}
+
+	pathsDiff := diffReport.PathsDiff
+	if rule.Match.Endpoint == customEndpointAdded {
+		for _, path := range pathsDiff.Added {
+			for method, operation := range pathsDiff.Revision.Value(path).Operations() {
+				result = rule.appendEndpoint(result, config, operationsSources, operation, method, path, NewEmptySource(), NewSourceFromOrigin(operationsSources, operation, operation.Origin))
+			}
+		}
+		for path, pathDiff := range pathsDiff.Modified {
+			for method, operation := range pathDiff.Revision.Operations() {
+				if pathDiff.Base.GetOperation(method) == nil {
+					result = rule.appendEndpoint(result, config, operationsSources, operation, method, path, NewEmptySource(), NewSourceFromOrigin(operationsSources, operation, operation.Origin))
+				}
+			}
+		}
+		return result
+	}
+
+	for _, path := range pathsDiff.Deleted {
+		for method, operation := range pathsDiff.Base.Value(path).Operations() {
+			result = rule.appendEndpoint(result, config, operationsSources, operation, method, path, NewSourceFromOrigin(operationsSources, operation, operation.Origin), NewEmptySource())
+		}
+	}
+	for path, pathDiff := range pathsDiff.Modified {
+		for method, operation := range pathDiff.Base.Operations() {
+			if pathDiff.Revision.GetOperation(method) == nil {
+				result = rule.appendEndpoint(result, config, operationsSources, operation, method, path, NewSourceFromOrigin(operationsSources, operation, operation.Origin), NewEmptySource())
+			}
+		}
+	}
+	return result
+}
+
+func (rule *customRule) appendEndpoint(result Changes, config *Config, operationsSources *diff.OperationsSourcesMap, operation *openapi3.Operation, method, path string, baseSource, revisionSource *Source) Changes {
+	if !rule.matchOperation(method, path, operation) {
+		return result
+	}
+
+	return append(result, rule.newChange(
+		NewApiChange(rule.Id, config, nil, "", operationsSources, operation, method, path),
+		map[string]any{},
+	).WithSources(baseSource, revisionSource))
+}
+
+func (rule *customRule) checkSchemas(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+
+	processor := func(info mediaTypeInfo) {
+		if !rule.matchOperation(info.method, info.path, info.operationItem.Revision) {
+			return
+		}
+
+		rule.checkSchema(&result, info, "", info.schemaDiff, info.newChange)
+		info.walkProperties(func(p propertyInfo) {
+			rule.checkSchema(&result, info, propertyFullName(p.propertyPath, p.propertyName), p.propertyDiff, p.newChange)
+		})
+	}
+
+	if rule.Match.Schema.In != customInResponse {
+		walkModifiedRequestBodySchemas(diffReport, operationsSources, config, processor)
+	}
+	if rule.Match.Schema.In != customInRequest {
+		walkModifiedResponseSchemas(diffReport, operationsSources, config, processor)
+	}
+
+	return result
+}
+
+func (rule *customRule) checkSchema(result *Changes, info mediaTypeInfo, property string, schemaDiff *diff.SchemaDiff, newChange func(id string, args []any, comment string) ApiChange) {
+	if !rule.matchSchema(schemaDiff) {
+		return
+	}
+
+	field := rule.Match.Schema.Field
+	for _, fieldChange := range getCustomFieldChanges(schemaDiff, rule.field) {
+		if rule.Match.Schema.Change != "" && rule.Match.Schema.Change != fieldChange.change {
+			continue
+		}
+
+		args := fieldChange.args
+		args["MediaType"] = info.mediaType
+		args["Property"] = property
+		args["Field"] = field
+		if info.responseStatus != "" {
+			args["Status"] = info.responseStatus
+		}
+
+		var baseSource, revisionSource *Source
+		switch value, ok := args["Value"]; {
+		case ok && fieldChange.change == customChangeAdded:
+			baseSource, revisionSource = SchemaAddedItemSources(info.operationsSources, info.operationItem, schemaDiff, field, fmt.Sprintf("%v", value))
+		case ok:
+			baseSource, revisionSource = SchemaDeletedItemSources(info.operationsSources, info.operationItem, schemaDiff, field, fmt.Sprintf("%v", value))
+		default:
+			baseSource, revisionSource = SchemaFieldSources(info.operationsSources, info.operationItem, schemaDiff, field)
+		}
+
+		*result = append(*result, rule.newChange(newChange(rule.Id, nil, ""), args).WithSources(baseSource, revisionSource))
+	}
+}
+
+// newChange sets the message, comment and args of a change that the rule reports
+func (rule *customRule) newChange(change ApiChange, args map[string]any) ApiChange {
+	names := []string{}
+	for _, name := range customMessageVariables {
+		if _, ok := args[name]; ok {
+			names = append(names, name)
+		}
+	}
+
+	change.Args = make([]any, len(names))
+	for i, name := range names {
+		change.Args[i] = args[name]
+	}
+	change.Comment = rule.Comment
+	change.message = &customMessage{template: rule.message, names: names}
+	return change
+}
+
+// matchOperation reports whether the operation matches the path, methods and extensions of the rule
+func (rule *customRule) matchOperation(method, path string, operation *openapi3.Operation) bool {
+	match := rule.Match
+
+	if rule.path != nil && !rule.path.MatchString(path) {
+		return false
+	}
+
+	if len(match.Methods) > 0 && !slices.ContainsFunc(match.Methods, func(m string) bool { return strings.EqualFold(m, method) }) {
+		return false
+	}
+
+	return matchCustomExtensions(match.Extension, match.WithoutExtension, operation.Extensions)
+}
+
+// matchSchema reports whether the schema, before or after the change, matches the type and extensions of the rule
+func (rule *customRule) matchSchema(schemaDiff *diff.SchemaDiff) bool {
+	match := rule.Match.Schema
+	schemas := []*openapi3.Schema{}
+	for _, schema := range []*openapi3.Schema{schemaDiff.Base, schemaDiff.Revision} {
+		if schema != nil {
+			schemas = append(schemas, schema)
+		}
+	}
+
+	if match.Type != "" && !slices.ContainsFunc(schemas, func(schema *openapi3.Schema) bool { return schema.Type.Includes(match.Type) }) {
+		return false
+	}
+
+	hasExtension := func(name string) bool {
+		return slices.ContainsFunc(schemas, func(schema *openapi3.Schema) bool {
+			_, ok := schema.Extensions[name]
+			return ok
+		})
+	}
+
+	if match.Extension != "" && !hasExtension(match.Extension) {
+		return false
+	}
+
+	return match.WithoutExtension == "" || !hasExtension(match.WithoutExtension)
+}
+
+func matchCustomExtensions(extension, withoutExtension string, extensions map[string]any) bool {
+	if extension != "" {
+		if _, ok := extensions[extension]; !ok {
+			return false
+		}
+	}
+
+	if withoutExtension != "" {
+		if _, ok := extensions[withoutExtension]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// customMessage is the message of a change reported by a custom rule: a template, and the names of the change's args in it
+type customMessage struct {
+	template *template.Template
+	names    []string
+}
+
+// render renders the message of the change, with the change's args formatted by format
+func (message *customMessage) render(c ApiChange, format func([]any) []any) string {
+	data := map[string]any{}
+	for _, name := range customMessageVariables {
+		data[name] = ""
+	}
+
+	values := format(append([]any{c.Operation, c.OperationId, c.Path}, c.Args...))
+	data["Operation"], data["OperationId"], data["Path"] = values[0], values[1], values[2]
+	for i, name := range message.names {
+		data[name] = values[3+i]
+	}
+
+	var builder strings.Builder
+	if err := message.template.Execute(&builder, data); err != nil {
+		return c.Id
+	}
+	return builder.String()
+}
@@ -0,0 +1,157 @@
+package checker_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/stretchr/testify/require"
+)
+
+func getCustomRulesChanges(t *testing.T, rulesFile string, opts ...checker.Option) checker.Changes {
+	t.Helper()
+
+	loader := newLoaderWithOriginTracking()
+	s1, err := open("../data/custom-rules/base.yaml", loader)
+	require.NoError(t, err)
+	s2, err := open("../data/custom-rules/revision.yaml", loader)
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+
+	rules, err := checker.LoadCustomRules(rulesFile)
+	require.NoError(t, err)
+
+	// only the custom rules are checked
+	config := checker.NewConfig(checker.BackwardCompatibilityChecks{}, append([]checker.Option{checker.WithCustomRules(rules)}, opts...)...)
+	return checker.CheckBackwardCompatibilityUntilLevel(config, d, osm, checker.INFO)
+}
+
+func getCustomChange(changes checker.Changes, id, path string) *checker.ApiChange {
+	for _, change := range changes {
+		if change.GetId() == id && change.GetPath() == path {
+			apiChange := change.(checker.ApiChange)
+			return &apiChange
+		}
+	}
+	return nil
+}
+
+func TestCustomRules_EndpointAdded(t *testing.T) {
+	changes := getCustomRulesChanges(t, "../data/custom-rules/rules.yaml")
+
+	change := getCustomChange(changes, "endpoint-added-without-owner", "/pets")
+	require.NotNil(t, change)
+	require.Equal(t, "POST", change.Operation)
+	require.Equal(t, checker.ERR, change.Level)
+	require.Equal(t, "endpoint `POST` `/pets` was added without an owner", change.GetUncolorizedText(checker.NewDefaultLocalizer()))
+	require.Equal(t, "Set x-owner to the team that owns the endpoint.", change.GetComment(checker.NewDefaultLocalizer()))
+	require.Equal(t, 18, change.GetRevisionSource().Line)
+
+	// the new endpoint with an owner is not reported
+	require.Nil(t, getCustomChange(changes, "endpoint-added-without-owner", "/stores"))
+}
+
+func TestCustomRules_SchemaFieldRemoved(t *testing.T) {
+	changes := getCustomRulesChanges(t, "../data/custom-rules/rules.yaml")
+
+	change := getCustomChange(changes, "response-array-max-items-removed", "/owners")
+	require.NotNil(t, change)
+	require.Equal(t, "removed maxItems `10` from the array `names` of the response with status `200`", change.GetUncolorizedText(checker.NewDefaultLocalizer()))
+	require.Equal(t, []any{"200", "application/json", "names", "maxItems", uint64(10), nil}, change.Args)
+	require.Equal(t, 33, change.GetBaseSource().Line)
+
+	// the body schema itself is matched too
+	require.NotNil(t, getCustomChange(changes, "response-array-max-items-removed", "/pets"))
+}
+
+func TestCustomRules_SchemaExtension(t *testing.T) {
+	changes := getCustomRulesChanges(t, "../data/custom-rules/rules.yaml")
+
+	found := []string{}
+	for _, change := range changes {
+		if change.GetId() == "public-enum-value-removed" {
+			found = append(found, change.GetUncolorizedText(checker.NewDefaultLocalizer()))
+		}
+	}
+
+	// the value removed from color isn't reported: color isn't public
+	require.Equal(t, []string{"removed the value `sold` from the enum of the public schema `items/status`"}, found)
+}
+
+func TestCustomRules_SeverityLevels(t *testing.T) {
+	rules, err := checker.LoadCustomRules("../data/custom-rules/rules.yaml")
+	require.NoError(t, err)
+
+	levels, err := checker.GetSeverityLevels(strings.NewReader("endpoint-added-without-owner none\npublic-enum-value-removed warn"), rules.GetIds()...)
+	require.NoError(t, err)
+
+	changes := getCustomRulesChanges(t, "../data/custom-rules/rules.yaml", checker.WithSeverityLevels(levels))
+	require.Nil(t, getCustomChange(changes, "endpoint-added-without-owner", "/pets"))
+	require.Equal(t, checker.WARN, getCustomChange(changes, "public-enum-value-removed", "/pets").Level)
+}
+
+func TestCustomRules_SeverityLevelsUnknownId(t *testing.T) {
+	_, err := checker.GetSeverityLevels(strings.NewReader("endpoint-added-without-owner none"))
+	require.EqualError(t, err, `invalid rule id "endpoint-added-without-owner" on line 1`)
+}
+
+func TestCustomRules_Ignore(t *testing.T) {
+	changes := getCustomRulesChanges(t, "../data/custom-rules/rules.yaml")
+	l := checker.NewDefaultLocalizer()
+
+	change := getCustomChange(changes, "response-array-max-items-removed", "/owners")
+	require.True(t, change.MatchIgnore("/owners", strings.ToLower("GET /owners "+change.GetUncolorizedText(l)), l))
+}
+
+func TestCustomRules_GetRules(t *testing.T) {
+	rules, err := checker.LoadCustomRules("../data/custom-rules/rules.yaml")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"endpoint-added-without-owner", "response-array-max-items-removed", "public-enum-value-removed"}, rules.GetIds())
+
+	rule := rules.GetRules()[1]
+	require.Equal(t, checker.ERR, rule.Level)
+	require.Equal(t, checker.DirectionResponse, rule.Direction)
+	require.Equal(t, checker.AreaSchema, rule.Area)
+	require.Equal(t, checker.KindConstraints, rule.Kind)
+	require.Equal(t, checker.ActionRemove, rule.Action)
+	require.Equal(t, "Response arrays must keep their maxItems", rule.Description)
+}
+
+func TestCustomRules_Empty(t *testing.T) {
+	rules, err := checker.GetCustomRules(strings.NewReader(""))
+	require.NoError(t, err)
+	require.Empty(t, rules.GetRules())
+}
+
+func TestCustomRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+		err   string
+	}{
+		{"unknown key", "rules:\n- id: a\n  levl: err", "field levl not found"},
+		{"missing id", "rules:\n- level: err", "rule #1: missing id"},
+		{"built-in id", "rules:\n- id: endpoint-added\n  level: err", `rule #1: id "endpoint-added" is already used by a built-in rule`},
+		{"duplicate id", "rules:\n- {id: a, level: err, message: m, match: {endpoint: added}}\n- {id: a, level: err, message: m, match: {endpoint: added}}", `rule #2: id "a" is used by an earlier rule`},
+		{"level", "rules:\n- id: a\n  level: fatal", "rule #1: a: invalid level fatal"},
+		{"area", "rules:\n- {id: a, level: err, area: body}", `rule #1: a: invalid area "body", expected one of: schema, parameters`},
+		{"no match", "rules:\n- {id: a, level: err, message: m}", "rule #1: a: match needs an endpoint or a schema"},
+		{"endpoint", "rules:\n- {id: a, level: err, message: m, match: {endpoint: changed}}", `rule #1: a: invalid endpoint "changed", expected added or removed`},
+		{"field", "rules:\n- {id: a, level: err, message: m, match: {schema: {field: properties}}}", `rule #1: a: invalid schema field "properties"`},
+		{"change", "rules:\n- {id: a, level: err, message: m, match: {schema: {field: enum, change: renamed}}}", `rule #1: a: invalid schema change "renamed"`},
+		{"path", "rules:\n- {id: a, level: err, message: m, match: {path: '(', endpoint: added}}", "rule #1: a: invalid path"},
+		{"no message", "rules:\n- {id: a, level: err, match: {endpoint: added}}", "rule #1: a: missing message"},
+		{"message variable", "rules:\n- {id: a, level: err, message: '{{.Method}}', match: {endpoint: added}}", `rule #1: a: invalid message`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := checker.GetCustomRules(strings.NewReader(test.rules))
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
@@ -35,3 +35,91 @@ func getSunsetDate(sunset any) (civil.Date, error) {
 
 	return civil.Date{}, fmt.Errorf("sunset date doesn't conform with RFC3339: %s", sunsetStr)
 }
+
+// isDeprecated returns true if an element is deprecated by its deprecated field or, for elements without one, like
+// responses and media types, by the x-deprecated extension
+func isDeprecated(deprecated bool, extensions map[string]any) bool {
+	if deprecated {
+		return true
+	}
+
+	switch value := extensions[diff.XDeprecatedExtension].(type) {
+	case bool:
+		return value
+	case json.RawMessage:
+		var result bool
+		return json.Unmarshal(value, &result) == nil && result
+	}
+	return false
+}
+
+// getDeprecatedScopes returns the x-deprecated-scopes extension of an OAuth flow: the deprecated scopes mapped to
+// their sunset dates, or to nothing if they have none
+func getDeprecatedScopes(extensions map[string]any) map[string]any {
+	switch value := extensions[diff.XDeprecatedScopesExtension].(type) {
+	case map[string]any:
+		return value
+	case json.RawMessage:
+		var result map[string]any
+		if json.Unmarshal(value, &result) == nil {
+			return result
+		}
+	}
+	return nil
+}
+
+// removalIds are the ids of the changes reported when an element that can be deprecated is removed
+type removalIds struct {
+	withoutDeprecation string
+	withDeprecation    string
+	beforeSunset       string
+	sunsetParse        string
+}
+
+// removal is the change reported when an element is removed
+type removal struct {
+	id    string
+	args  []any
+	level Level
+}
+
+// getRemoval returns the change reported when an element is removed, given the args that identify it:
+// the sunset date is added to them if the element is removed before it, and the parse error if the date is invalid.
+// It returns false if the element was removed after its sunset date, as it should be.
+func (ids removalIds) getRemoval(config *Config, deprecated bool, extensions map[string]any, args ...any) (removal, bool) {
+	if !isDeprecated(deprecated, extensions) {
+		return ids.newRemoval(config, ids.withoutDeprecation, args), true
+	}
+
+	sunset, ok := getSunset(extensions)
+	return ids.getDeprecatedRemoval(config, sunset, ok, args...)
+}
+
+// getDeprecatedRemoval is getRemoval for an element known to be deprecated, with its sunset date if it has one
+func (ids removalIds) getDeprecatedRemoval(config *Config, sunset any, hasSunset bool, args ...any) (removal, bool) {
+	if !hasSunset || sunset == nil || sunset == "" {
+		return ids.newRemoval(config, ids.withDeprecation, args), true
+	}
+
+	date, err := getSunsetDate(sunset)
+	if err != nil {
+		return ids.newRemoval(config, ids.sunsetParse, append(args, err)), true
+	}
+
+	if config.getAsOf().Before(date) {
+		return ids.newRemoval(config, ids.beforeSunset, append(args, date)), true
+	}
+
+	return removal{}, false
+}
+
+// newRemoval returns a removal at the level of its rule.
+// Deprecating an element must not make its removal worse, so unless the user set the level of the rule, the removal of
+// a deprecated element isn't reported at a higher level than the removal of the same element without deprecation.
+func (ids removalIds) newRemoval(config *Config, id string, args []any) removal {
+	level := config.getLogLevel(id)
+	if id != ids.withoutDeprecation && !config.isLevelCustomized(id) {
+		level = min(level, config.getLogLevel(ids.withoutDeprecation))
+	}
+	return removal{id: id, args: args, level: level}
+}
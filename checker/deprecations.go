@@ -0,0 +1,235 @@
+package checker
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"cloud.google.com/go/civil"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/load"
+)
+
+// DeprecationKind is the kind of a deprecated element of a spec
+type DeprecationKind string
+
+const (
+	DeprecatedEndpoint  DeprecationKind = "endpoint"
+	DeprecatedParameter DeprecationKind = "parameter"
+	DeprecatedProperty  DeprecationKind = "property"
+)
+
+// Deprecation is an endpoint, a parameter or a request or response property that is marked as deprecated
+type Deprecation struct {
+	Kind        DeprecationKind
+	Operation   string
+	Path        string
+	OperationId string
+	// In is the location of a parameter (path, query, header or cookie), or "request" or "response" for a property
+	In string
+	// Status is the response status of a response property
+	Status    string
+	MediaType string
+	// Name is the name of a parameter, or the path of a property, like "items/name"
+	Name string
+	// Stability is the x-stability-level of the operation, or "" if it has none
+	Stability string
+	// Sunset is the x-sunset date, or nil if there is none or it can't be parsed
+	Sunset *civil.Date
+	// SunsetError is why x-sunset couldn't be parsed
+	SunsetError string
+	// DaysRemaining is the number of days from the report date to the sunset date; it is negative after the sunset
+	DaysRemaining int
+	// Overdue is true when the sunset date has passed, and the deprecated element can be removed
+	Overdue bool
+	Source  *Source
+}
+
+// Deprecations is the deprecated elements of a spec, by path, operation and location
+type Deprecations []Deprecation
+
+// GetDeprecations returns the deprecated endpoints, parameters and request and response properties of a spec,
+// with their sunset dates counted from asOf.
+// The deprecated parameters and properties of a deprecated endpoint are listed too: they may have their own sunset.
+func GetDeprecations(spec *load.SpecInfo, asOf civil.Date) Deprecations {
+	if spec == nil || spec.Spec == nil {
+		return Deprecations{}
+	}
+	return getPathsDeprecations(spec.Spec.Paths, spec.Url, asOf)
+}
+
+// getPathsDeprecations returns the deprecations of the paths; file is the source file of the elements whose origin
+// has none
+func getPathsDeprecations(paths *openapi3.Paths, file string, asOf civil.Date) Deprecations {
+	result := Deprecations{}
+
+	collector := deprecationCollector{
+		file: file,
+		asOf: asOf,
+	}
+
+	pathItems := paths.Map()
+	for _, path := range slices.Sorted(maps.Keys(pathItems)) {
+		pathItem := pathItems[path]
+		operations := pathItem.Operations()
+		for _, method := range slices.Sorted(maps.Keys(operations)) {
+			result = append(result, collector.getOperationDeprecations(path, method, pathItem, operations[method])...)
+		}
+	}
+
+	return result
+}
+
+type deprecationCollector struct {
+	file string
+	asOf civil.Date
+}
+
+func (c deprecationCollector) getOperationDeprecations(path, method string, pathItem *openapi3.PathItem, op *openapi3.Operation) Deprecations {
+	result := Deprecations{}
+
+	// an invalid x-stability-level is reported by validate and breaking, not here
+	stability, _ := getStabilityLevel(op.Extensions)
+
+	newDeprecation := func(kind DeprecationKind, extensions map[string]any, origin *openapi3.Origin) Deprecation {
+		return c.newDeprecation(Deprecation{
+			Kind:        kind,
+			Operation:   method,
+			Path:        path,
+			OperationId: op.OperationID,
+			Stability:   stability,
+		}, extensions, origin)
+	}
+
+	if op.Deprecated {
+		result = append(result, newDeprecation(DeprecatedEndpoint, op.Extensions, op.Origin))
+	}
+
+	for _, paramRef := range getOperationParameters(pathItem, op) {
+		param := paramRef.Value
+		if !param.Deprecated {
+			continue
+		}
+		deprecation := newDeprecation(DeprecatedParameter, param.Extensions, param.Origin)
+		deprecation.In = param.In
+		deprecation.Name = param.Name
+		result = append(result, deprecation)
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		content := op.RequestBody.Value.Content
+		for _, mediaType := range slices.Sorted(maps.Keys(content)) {
+			walkDeprecatedProperties(content[mediaType].Schema, "", map[*openapi3.Schema]bool{}, func(name string, schema *openapi3.Schema) {
+				deprecation := newDeprecation(DeprecatedProperty, schema.Extensions, schema.Origin)
+				deprecation.In = "request"
+				deprecation.MediaType = mediaType
+				deprecation.Name = name
+				result = append(result, deprecation)
+			})
+		}
+	}
+
+	if op.Responses != nil {
+		responses := op.Responses.Map()
+		for _, status := range slices.Sorted(maps.Keys(responses)) {
+			response := responses[status]
+			if response == nil || response.Value == nil {
+				continue
+			}
+			content := response.Value.Content
+			for _, mediaType := range slices.Sorted(maps.Keys(content)) {
+				walkDeprecatedProperties(content[mediaType].Schema, "", map[*openapi3.Schema]bool{}, func(name string, schema *openapi3.Schema) {
+					deprecation := newDeprecation(DeprecatedProperty, schema.Extensions, schema.Origin)
+					deprecation.In = "response"
+					deprecation.Status = status
+					deprecation.MediaType = mediaType
+					deprecation.Name = name
+					result = append(result, deprecation)
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+// newDeprecation completes a deprecation with its sunset date and source
+func (c deprecationCollector) newDeprecation(deprecation Deprecation, extensions map[string]any, origin *openapi3.Origin) Deprecation {
+	if sunset, ok := getSunset(extensions); ok {
+		if date, err := getSunsetDate(sunset); err != nil {
+			deprecation.SunsetError = err.Error()
+		} else {
+			deprecation.Sunset = &date
+			deprecation.DaysRemaining = date.DaysSince(c.asOf)
+			deprecation.Overdue = deprecation.DaysRemaining < 0
+		}
+	}
+
+	deprecation.Source = sourceFromOrigin(origin)
+	if deprecation.Source != nil && deprecation.Source.File == "" {
+		deprecation.Source.File = c.file
+	}
+
+	return deprecation
+}
+
+// getOperationParameters returns the parameters of an operation, including those of its path that it doesn't override,
+// sorted by location and name
+func getOperationParameters(pathItem *openapi3.PathItem, op *openapi3.Operation) openapi3.Parameters {
+	result := openapi3.Parameters{}
+	for _, paramRef := range op.Parameters {
+		if paramRef != nil && paramRef.Value != nil {
+			result = append(result, paramRef)
+		}
+	}
+	for _, paramRef := range pathItem.Parameters {
+		if paramRef != nil && paramRef.Value != nil && result.GetByInAndName(paramRef.Value.In, paramRef.Value.Name) == nil {
+			result = append(result, paramRef)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b *openapi3.ParameterRef) int {
+		return cmp.Or(cmp.Compare(a.Value.In, b.Value.In), cmp.Compare(a.Value.Name, b.Value.Name))
+	})
+
+	return result
+}
+
+// walkDeprecatedProperties calls processor for every deprecated property of a schema, at any depth.
+// The properties of allOf, anyOf and oneOf subschemas are properties of the schema itself, and those of array items
+// are under "items", as in the changes of the property checks.
+func walkDeprecatedProperties(schemaRef *openapi3.SchemaRef, propertyPath string, visited map[*openapi3.Schema]bool, processor func(name string, schema *openapi3.Schema)) {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return
+	}
+	schema := schemaRef.Value
+
+	// recursive schemas are walked once on each branch
+	if visited[schema] {
+		return
+	}
+	visited[schema] = true
+	defer delete(visited, schema)
+
+	for _, subschemas := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, subschema := range subschemas {
+			walkDeprecatedProperties(subschema, propertyPath, visited, processor)
+		}
+	}
+
+	if schema.Items != nil {
+		walkDeprecatedProperties(schema.Items, joinPath(propertyPath, "items"), visited, processor)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		property := schema.Properties[name]
+		if property == nil || property.Value == nil {
+			continue
+		}
+		fullName := propertyFullName(propertyPath, name)
+		if property.Value.Deprecated {
+			processor(fullName, property.Value)
+		}
+		walkDeprecatedProperties(property, fullName, visited, processor)
+	}
+}
@@ -0,0 +1,83 @@
+package checker_test
+
+import (
+	"testing"
+
+	"cloud.google.com/go/civil"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/stretchr/testify/require"
+)
+
+func getDeprecations(t *testing.T) checker.Deprecations {
+	t.Helper()
+
+	spec, err := open("../data/deprecations/openapi.yaml", newLoaderWithOriginTracking())
+	require.NoError(t, err)
+
+	return checker.GetDeprecations(spec, civil.Date{Year: 2026, Month: 10, Day: 18})
+}
+
+func TestDeprecations_All(t *testing.T) {
+	deprecations := getDeprecations(t)
+
+	type item struct {
+		kind      checker.DeprecationKind
+		operation string
+		path      string
+		in        string
+		name      string
+	}
+	items := make([]item, len(deprecations))
+	for i, d := range deprecations {
+		items[i] = item{d.Kind, d.Operation, d.Path, d.In, d.Name}
+	}
+
+	require.Equal(t, []item{
+		{checker.DeprecatedParameter, "GET", "/pets", "header", "X-Legacy-Tenant"},
+		{checker.DeprecatedParameter, "GET", "/pets", "query", "page"},
+		{checker.DeprecatedProperty, "GET", "/pets", "response", "items/owner/phone"},
+		{checker.DeprecatedProperty, "GET", "/pets", "response", "items/tag"},
+		{checker.DeprecatedParameter, "POST", "/pets", "header", "X-Legacy-Tenant"},
+		{checker.DeprecatedProperty, "POST", "/pets", "request", "nickname"},
+		{checker.DeprecatedEndpoint, "DELETE", "/pets/{id}", "", ""},
+		{checker.DeprecatedEndpoint, "GET", "/stores", "", ""},
+	}, items)
+}
+
+func TestDeprecations_Sunset(t *testing.T) {
+	deprecations := getDeprecations(t)
+
+	page := deprecations[1]
+	require.Equal(t, &civil.Date{Year: 2026, Month: 11, Day: 1}, page.Sunset)
+	require.Equal(t, 14, page.DaysRemaining)
+	require.False(t, page.Overdue)
+	require.Equal(t, checker.StabilityStable, page.Stability)
+	require.Equal(t, "listPets", page.OperationId)
+
+	tag := deprecations[3]
+	require.Equal(t, "200", tag.Status)
+	require.Equal(t, "application/json", tag.MediaType)
+	require.Equal(t, 134, tag.DaysRemaining)
+}
+
+func TestDeprecations_Overdue(t *testing.T) {
+	endpoint := getDeprecations(t)[6]
+	require.Equal(t, -18, endpoint.DaysRemaining)
+	require.True(t, endpoint.Overdue)
+	require.Equal(t, checker.StabilityBeta, endpoint.Stability)
+	require.Equal(t, "../data/deprecations/openapi.yaml", endpoint.Source.File)
+	require.Equal(t, 55, endpoint.Source.Line)
+}
+
+func TestDeprecations_NoSunset(t *testing.T) {
+	deprecations := getDeprecations(t)
+
+	phone := deprecations[2]
+	require.Nil(t, phone.Sunset)
+	require.Empty(t, phone.SunsetError)
+	require.False(t, phone.Overdue)
+
+	nickname := deprecations[5]
+	require.Nil(t, nickname.Sunset)
+	require.Equal(t, "sunset date doesn't conform with RFC3339: next month", nickname.SunsetError)
+}
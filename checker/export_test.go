@@ -0,0 +1,4 @@
+package checker
+
+// ResetRegistry lets the tests of package checker_test register rules without leaking them into other tests
+var ResetRegistry = resetRegistry
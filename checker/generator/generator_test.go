@@ -27,7 +27,7 @@ func TestTreeGenerator(t *testing.T) {
 	require.NoError(t, err)
 	slices.Sort(result)
 	WriteToFile(t, "messages.yaml", result)
-	require.Len(t, result, 263)
+	require.Len(t, result, 275)
 	badId, unique := isUninueIds(result)
 	require.True(t, unique, badId)
 }
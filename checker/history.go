@@ -0,0 +1,99 @@
+package checker
+
+// Release is one spec in a sequence of releases, compared with the release
+// before it.
+type Release struct {
+	// Version is the spec's info.version
+	Version string
+	// Source names where the spec was loaded from, e.g. a file or a git revision
+	Source string
+	// Changes are the changes from the previous release to this one
+	Changes Changes
+	// Reverse are the changes from this release back to the previous one: the
+	// changes that a later release undoing this one would report
+	Reverse Changes
+}
+
+// History is the changelog of a sequence of releases, oldest first.
+type History []ReleaseHistory
+
+// ReleaseHistory is the changes of one release, each with its history.
+type ReleaseHistory struct {
+	Version string
+	Source  string
+	Changes []HistoryChange
+}
+
+// HistoryChange is a change with the releases it relates to, by fingerprint.
+// Introduced is the release where the same change first appeared: this one,
+// or an earlier one if the change was reverted in between and is now
+// reintroduced. Reverts is the release whose change this one undoes, and
+// RevertedIn the first later release that undoes it.
+type HistoryChange struct {
+	Change
+	Introduced string
+	Reverts    string
+	RevertedIn string
+}
+
+// NewHistory relates the changes of a sequence of releases by fingerprint. A
+// change reverts an earlier release when it is one of that release's reverse
+// changes, and it is reverted by the first later release whose reverse changes
+// include it.
+func NewHistory(releases []Release) History {
+	forward := make([]map[string]bool, len(releases))
+	reverse := make([]map[string]bool, len(releases))
+	for i, release := range releases {
+		forward[i] = fingerprints(release.Changes)
+		reverse[i] = fingerprints(release.Reverse)
+	}
+
+	history := make(History, len(releases))
+	for i, release := range releases {
+		changes := make([]HistoryChange, len(release.Changes))
+		for j, change := range release.Changes {
+			fingerprint := Fingerprint(change)
+			changes[j] = HistoryChange{
+				Change:     change,
+				Introduced: release.Version,
+			}
+
+			for k := range i {
+				if forward[k][fingerprint] {
+					changes[j].Introduced = releases[k].Version
+					break
+				}
+			}
+
+			for k := i - 1; k >= 0; k-- {
+				if reverse[k][fingerprint] {
+					changes[j].Reverts = releases[k].Version
+					break
+				}
+			}
+
+			for k := i + 1; k < len(releases); k++ {
+				if reverse[k][fingerprint] {
+					changes[j].RevertedIn = releases[k].Version
+					break
+				}
+			}
+		}
+
+		history[i] = ReleaseHistory{
+			Version: release.Version,
+			Source:  release.Source,
+			Changes: changes,
+		}
+	}
+
+	return history
+}
+
+func fingerprints(changes Changes) map[string]bool {
+	result := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		result[Fingerprint(change)] = true
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/stretchr/testify/require"
+)
+
+func historyChange(id string) checker.ApiChange {
+	return checker.ApiChange{Id: id, Level: checker.INFO, Operation: "GET", Path: "/api/users"}
+}
+
+func TestHistory(t *testing.T) {
+	added := historyChange(checker.EndpointAddedId)
+	removed := historyChange(checker.APIPathRemovedWithoutDeprecationId)
+
+	history := checker.NewHistory([]checker.Release{
+		{Version: "1.1", Changes: checker.Changes{added}, Reverse: checker.Changes{removed}},
+		{Version: "1.2", Changes: checker.Changes{removed}, Reverse: checker.Changes{added}},
+		{Version: "1.3", Changes: checker.Changes{added}, Reverse: checker.Changes{removed}},
+	})
+	require.Len(t, history, 3)
+
+	require.Equal(t, "1.1", history[0].Version)
+	require.Equal(t, checker.HistoryChange{Change: added, Introduced: "1.1", RevertedIn: "1.2"}, history[0].Changes[0])
+	require.Equal(t, checker.HistoryChange{Change: removed, Introduced: "1.2", Reverts: "1.1", RevertedIn: "1.3"}, history[1].Changes[0])
+	require.Equal(t, checker.HistoryChange{Change: added, Introduced: "1.1", Reverts: "1.2"}, history[2].Changes[0])
+}
+
+func TestHistoryUnrelatedChanges(t *testing.T) {
+	history := checker.NewHistory([]checker.Release{
+		{Version: "1.1", Changes: checker.Changes{historyChange(checker.EndpointAddedId)}},
+		{Version: "1.2", Changes: checker.Changes{historyChange(checker.EndpointDeprecatedId)}},
+	})
+
+	require.Equal(t, checker.HistoryChange{Change: historyChange(checker.EndpointDeprecatedId), Introduced: "1.2"}, history[1].Changes[0])
+}
@@ -2,8 +2,8 @@ package checker
 
 import (
 	"bufio"
-	"os"
 	"strings"
+	"time"
 )
 
 func ignoreLinePath(ignoreLine string) string {
@@ -24,15 +24,20 @@ func ignoreLinePath(ignoreLine string) string {
 	return ignoreComponents[pathIndex]
 }
 
+// ProcessIgnoredBackwardCompatibilityErrors removes the changes of the given
+// level that the ignore file suppresses, in either ignore file format. Use
+// ProcessIgnoreFile to also get the expired and stale entries of a structured file.
 func ProcessIgnoredBackwardCompatibilityErrors(level Level, errs Changes, ignoreFile string, l Localizer) (Changes, error) {
+	result, _, err := ProcessIgnoreFile(level, errs, ignoreFile, l, time.Now())
+	return result, err
+}
+
+// processIgnoreLines applies a free-text ignore file: a change is ignored when
+// a line contains its path and its localized text, case-insensitively.
+func processIgnoreLines(level Level, errs Changes, ignoreText string, l Localizer) Changes {
 	result := make(Changes, 0)
 
-	ignore, err := os.Open(ignoreFile)
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = ignore.Close() }()
-	ignoreScanner := bufio.NewScanner(ignore)
+	ignoreScanner := bufio.NewScanner(strings.NewReader(ignoreText))
 
 	ignoredErrs := make([]bool, len(errs))
 	for ignoreScanner.Scan() {
@@ -55,5 +60,5 @@ func ProcessIgnoredBackwardCompatibilityErrors(level Level, errs Changes, ignore
 			result = append(result, err)
 		}
 	}
-	return result, nil
+	return result
 }
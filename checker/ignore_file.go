@@ -0,0 +1,200 @@
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// IgnoreFile is the structured ignore file format, in YAML or JSON:
+//
+//	ignore:
+//	  - id: response-property-removed
+//	    operation: GET
+//	    path: /api/v1/users
+//	    reason: the property was never populated
+//	    owner: team-users
+//	    expires: 2026-12-31
+//	    ticket: API-1234
+//	  - fingerprint: 3f2a9c1b7e04
+//
+// Unlike the free-text format, entries are matched on the change's rule id,
+// operation, path and fingerprint rather than on its localized text, so they
+// survive wording changes.
+type IgnoreFile struct {
+	Ignore []IgnoreEntry `yaml:"ignore" json:"ignore"`
+}
+
+// IgnoreEntry suppresses the changes that match all of its non-empty matchers:
+// Id, Operation, Path and Fingerprint. Reason, Owner and Ticket are
+// documentation; Expires (YYYY-MM-DD) is the last day the entry applies.
+type IgnoreEntry struct {
+	Id          string `yaml:"id,omitempty" json:"id,omitempty"`
+	Operation   string `yaml:"operation,omitempty" json:"operation,omitempty"`
+	Path        string `yaml:"path,omitempty" json:"path,omitempty"`
+	Fingerprint string `yaml:"fingerprint,omitempty" json:"fingerprint,omitempty"`
+	Reason      string `yaml:"reason,omitempty" json:"reason,omitempty"`
+	Owner       string `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Expires     string `yaml:"expires,omitempty" json:"expires,omitempty"`
+	Ticket      string `yaml:"ticket,omitempty" json:"ticket,omitempty"`
+}
+
+const ignoreDateLayout = "2006-01-02"
+
+// IgnoreReport lists the entries of a structured ignore file that need
+// attention: expired entries, which no longer suppress anything, and stale
+// entries, which matched no change.
+type IgnoreReport struct {
+	Expired []IgnoreEntry
+	Stale   []IgnoreEntry
+}
+
+// Empty reports whether there is nothing to report.
+func (r *IgnoreReport) Empty() bool {
+	return r == nil || (len(r.Expired) == 0 && len(r.Stale) == 0)
+}
+
+func (entry IgnoreEntry) validate() error {
+	if entry.Id == "" && entry.Fingerprint == "" {
+		return errors.New("an entry must have an id or a fingerprint")
+	}
+	if entry.Expires != "" {
+		if _, err := time.Parse(ignoreDateLayout, entry.Expires); err != nil {
+			return fmt.Errorf("invalid expires date %q, expected YYYY-MM-DD", entry.Expires)
+		}
+	}
+	return nil
+}
+
+// isExpired reports whether the entry's last day is before asOf's date.
+func (entry IgnoreEntry) isExpired(asOf time.Time) bool {
+	if entry.Expires == "" {
+		return false
+	}
+	// both are YYYY-MM-DD so lexical order is chronological order
+	return entry.Expires < asOf.Format(ignoreDateLayout)
+}
+
+func (entry IgnoreEntry) matches(change Change) bool {
+	if entry.Id != "" && entry.Id != change.GetId() {
+		return false
+	}
+	if entry.Operation != "" && !strings.EqualFold(entry.Operation, change.GetOperation()) {
+		return false
+	}
+	if entry.Path != "" && entry.Path != change.GetPath() {
+		return false
+	}
+	if entry.Fingerprint != "" && entry.Fingerprint != Fingerprint(change) {
+		return false
+	}
+	return true
+}
+
+// String describes the entry by its matchers, for reports.
+func (entry IgnoreEntry) String() string {
+	parts := []string{}
+	for _, field := range []struct{ name, value string }{
+		{"id", entry.Id},
+		{"operation", entry.Operation},
+		{"path", entry.Path},
+		{"fingerprint", entry.Fingerprint},
+		{"owner", entry.Owner},
+		{"ticket", entry.Ticket},
+	} {
+		if field.value != "" {
+			parts = append(parts, field.name+"="+field.value)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseIgnoreFile returns the structured ignore file in data, or nil if data is
+// in the free-text format. A document is structured when it is a mapping with
+// an "ignore" key; free text never parses as one.
+func parseIgnoreFile(data []byte) (*IgnoreFile, error) {
+	var probe map[string]any
+	if err := yaml.Unmarshal(data, &probe); err != nil {
+		return nil, nil
+	}
+	if _, ok := probe["ignore"]; !ok {
+		return nil, nil
+	}
+
+	var ignoreFile IgnoreFile
+	if err := yaml.Unmarshal(data, &ignoreFile); err != nil {
+		return nil, err
+	}
+	for i, entry := range ignoreFile.Ignore {
+		if err := entry.validate(); err != nil {
+			return nil, fmt.Errorf("ignore entry %d: %w", i+1, err)
+		}
+	}
+	return &ignoreFile, nil
+}
+
+// ProcessIgnoreFile removes the changes of the given level that the ignore file
+// suppresses. The file is either structured (see IgnoreFile) or free text, one
+// change per line. For a structured file, entries that expired before asOf are
+// not applied, and they are returned with the stale entries in the report. The
+// report is nil for a free-text file.
+func ProcessIgnoreFile(level Level, errs Changes, ignoreFile string, l Localizer, asOf time.Time) (Changes, *IgnoreReport, error) {
+	data, err := os.ReadFile(ignoreFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	structured, err := parseIgnoreFile(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if structured == nil {
+		return processIgnoreLines(level, errs, string(data), l), nil, nil
+	}
+
+	result, report := processIgnoreEntries(level, errs, structured.Ignore, asOf)
+	return result, report, nil
+}
+
+func processIgnoreEntries(level Level, errs Changes, entries []IgnoreEntry, asOf time.Time) (Changes, *IgnoreReport) {
+	result := make(Changes, 0)
+	report := &IgnoreReport{}
+
+	active := make([]IgnoreEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.isExpired(asOf) {
+			report.Expired = append(report.Expired, entry)
+			continue
+		}
+		active = append(active, entry)
+	}
+
+	used := make([]bool, len(active))
+	for _, err := range errs {
+		ignored := false
+		if err.GetLevel() == level {
+			for i, entry := range active {
+				if entry.matches(err) {
+					used[i] = true
+					ignored = true
+				}
+			}
+		}
+		if !ignored {
+			result = append(result, err)
+		}
+	}
+
+	for i, entry := range active {
+		if !used[i] {
+			report.Stale = append(report.Stale, entry)
+		}
+	}
+
+	return result, report
+}
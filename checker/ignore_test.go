@@ -1,7 +1,10 @@
 package checker_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/oasdiff/oasdiff/checker"
 	"github.com/oasdiff/oasdiff/diff"
@@ -67,3 +70,74 @@ func TestIgnoreComponent(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, len(errs)-2, len(ignored))
 }
+
+func TestIgnoreStructured(t *testing.T) {
+	s1 := l(t, 1)
+	s2 := l(t, 3)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibility(allChecksConfig(), d, osm)
+	require.Equal(t, 6, len(errs))
+
+	errs, report, err := checker.ProcessIgnoreFile(checker.ERR, errs, "../data/ignore-err-example.yaml", checker.NewDefaultLocalizer(), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, 4, len(errs))
+	for _, e := range errs {
+		require.NotEqual(t, "response-success-status-removed", e.GetId())
+	}
+
+	require.Len(t, report.Expired, 1)
+	require.Equal(t, "api-removed-without-deprecation", report.Expired[0].Id)
+	require.Len(t, report.Stale, 2)
+	require.Equal(t, "request-parameter-removed", report.Stale[0].Id)
+	require.Equal(t, "000000000000", report.Stale[1].Fingerprint)
+}
+
+func TestIgnoreStructuredJSONFingerprint(t *testing.T) {
+	s1 := l(t, 1)
+	s2 := l(t, 3)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibility(allChecksConfig(), d, osm)
+	require.Equal(t, 6, len(errs))
+
+	errs, err = checker.ProcessIgnoredBackwardCompatibilityErrors(checker.WARN, errs, "../data/ignore-warn-example.json", checker.NewDefaultLocalizer())
+	require.NoError(t, err)
+	require.Equal(t, 5, len(errs))
+	for _, e := range errs {
+		require.NotEqual(t, "eb0210c439e3", checker.Fingerprint(e))
+	}
+}
+
+func TestIgnoreStructuredExpired(t *testing.T) {
+	s1 := l(t, 1)
+	s2 := l(t, 3)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibility(allChecksConfig(), d, osm)
+
+	// the entry expires at the end of 2999-12-31
+	_, report, err := checker.ProcessIgnoreFile(checker.WARN, errs, "../data/ignore-warn-example.json", checker.NewDefaultLocalizer(), time.Date(2999, 12, 31, 23, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Empty(t, report.Expired)
+
+	errs, report, err = checker.ProcessIgnoreFile(checker.WARN, errs, "../data/ignore-warn-example.json", checker.NewDefaultLocalizer(), time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, 6, len(errs))
+	require.Len(t, report.Expired, 1)
+}
+
+func TestIgnoreStructuredInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ignore.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("ignore:\n  - reason: no matcher\n"), 0o600))
+
+	_, _, err := checker.ProcessIgnoreFile(checker.ERR, nil, path, checker.NewDefaultLocalizer(), time.Now())
+	require.EqualError(t, err, "ignore entry 1: an entry must have an id or a fingerprint")
+
+	require.NoError(t, os.WriteFile(path, []byte("ignore:\n  - id: api-removed-without-deprecation\n    expires: 31.12.2026\n"), 0o600))
+	_, _, err = checker.ProcessIgnoreFile(checker.ERR, nil, path, checker.NewDefaultLocalizer(), time.Now())
+	require.EqualError(t, err, `ignore entry 1: invalid expires date "31.12.2026", expected YYYY-MM-DD`)
+}
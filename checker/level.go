@@ -73,23 +73,25 @@ func (level Level) IsBreaking() bool {
 	return level == ERR || level == WARN
 }
 
-// ProcessSeverityLevels reads a file with severity levels and returns a map of severity levels
-func ProcessSeverityLevels(file string) (map[string]Level, error) {
+// ProcessSeverityLevels reads a file with severity levels and returns a map of severity levels.
+// Besides the built-in rules, the file can set the levels of the custom rules with the given ids.
+func ProcessSeverityLevels(file string, customRuleIds ...string) (map[string]Level, error) {
 	f, err := os.Open(file)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = f.Close() }()
 
-	return GetSeverityLevels(f)
+	return GetSeverityLevels(f, customRuleIds...)
 }
 
-// GetSeverityLevels reads severity levels from a reader and returns a map of severity levels
-func GetSeverityLevels(source io.Reader) (map[string]Level, error) {
+// GetSeverityLevels reads severity levels from a reader and returns a map of severity levels.
+// Besides the built-in rules, the levels of the custom rules with the given ids can be set.
+func GetSeverityLevels(source io.Reader, customRuleIds ...string) (map[string]Level, error) {
 
 	result := map[string]Level{}
 
-	validIds := utils.StringSetFromSlice(GetAllRuleIds())
+	validIds := utils.StringSetFromSlice(append(GetAllRuleIds(), customRuleIds...))
 
 	scanner := bufio.NewScanner(source)
 
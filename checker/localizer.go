@@ -16,12 +16,22 @@ func NewLocalizer(locale string) Localizer {
 	locales := localizations.New(locale, localizations.LangDefault)
 
 	return func(originalKey string, args ...any) string {
+		// messages registered with RegisterLocalizations take precedence over the built-in ones
+		if pattern, ok := getRegisteredMessage(locale, originalKey); ok {
+			return fmt.Sprintf(pattern, args...)
+		}
+
 		key := "messages." + originalKey
 		pattern := locales.Get(key)
 
-		// if key not found, return original key
-		// TODO: improve localizations to return error when key not found
 		if pattern == key {
+			pattern, ok := getRegisteredMessage(localizations.LangDefault, originalKey)
+			if ok {
+				return fmt.Sprintf(pattern, args...)
+			}
+
+			// if key not found, return original key
+			// TODO: improve localizations to return error when key not found
 			return originalKey
 		}
 
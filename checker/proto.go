@@ -0,0 +1,325 @@
+package checker
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/oasdiff/oasdiff/protobuf"
+)
+
+const (
+	ProtoRPCAddedId                       = "proto-rpc-added"
+	ProtoRPCRemovedId                     = "proto-rpc-removed"
+	ProtoRPCRequestTypeChangedId          = "proto-rpc-request-type-changed"
+	ProtoRPCResponseTypeChangedId         = "proto-rpc-response-type-changed"
+	ProtoRPCStreamingChangedId            = "proto-rpc-streaming-changed"
+	ProtoRPCHTTPBindingChangedId          = "proto-rpc-http-binding-changed"
+	ProtoFieldAddedId                     = "proto-field-added"
+	ProtoRequiredFieldAddedId             = "proto-required-field-added"
+	ProtoFieldRemovedId                   = "proto-field-removed"
+	ProtoFieldRemovedWithoutReservationId = "proto-field-removed-without-reservation"
+	ProtoFieldNumberReusedId              = "proto-field-number-reused"
+	ProtoFieldRenamedId                   = "proto-field-renamed"
+	ProtoFieldTypeChangedId               = "proto-field-type-changed"
+	ProtoFieldCardinalityChangedId        = "proto-field-cardinality-changed"
+	ProtoFieldBecameRequiredId            = "proto-field-became-required"
+	ProtoFieldBecameOptionalId            = "proto-field-became-optional"
+	ProtoEnumValueAddedId                 = "proto-enum-value-added"
+	ProtoEnumValueRemovedId               = "proto-enum-value-removed"
+)
+
+// ComponentProtobuf is the component of changes to messages and enums that no RPC uses
+const ComponentProtobuf = "protobuf"
+
+// CheckProtoCompatibility compares two protobuf descriptor sets for wire
+// compatibility: RPCs added, removed or changed, and changes to the fields of
+// messages and the values of enums that would break existing clients or servers.
+//
+// Changes to a message or an enum are reported for each RPC that sends or
+// receives it, directly or nested. RPCs transcoded to REST by a
+// google.api.http annotation are reported at their REST endpoint, so that
+// they line up with the changes to the OpenAPI spec that describes it; other
+// RPCs are reported at their gRPC path as POST.
+//
+// baseSource and revisionSource are the locations the descriptor sets were
+// loaded from; they are reported as the sources of the changes.
+func CheckProtoCompatibility(config *Config, base, revision *protobuf.DescriptorSet, baseSource, revisionSource string) Changes {
+	c := protoChecker{
+		config:         config,
+		base:           base,
+		revision:       revision,
+		baseSource:     baseSource,
+		revisionSource: revisionSource,
+		baseUsers:      getProtoTypeUsers(base),
+		revisionUsers:  getProtoTypeUsers(revision),
+		result:         make(Changes, 0),
+	}
+
+	c.checkMethods()
+
+	for _, name := range slices.Sorted(maps.Keys(base.Messages)) {
+		if revisionMessage, ok := revision.Messages[name]; ok {
+			c.checkMessage(base.Messages[name], revisionMessage)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(base.Enums)) {
+		if revisionEnum, ok := revision.Enums[name]; ok {
+			c.checkEnum(base.Enums[name], revisionEnum)
+		}
+	}
+
+	return c.result
+}
+
+type protoChecker struct {
+	config                     *Config
+	base, revision             *protobuf.DescriptorSet
+	baseSource, revisionSource string
+	// baseUsers and revisionUsers map the names of messages and enums to the RPCs that use them
+	baseUsers, revisionUsers map[string][]string
+	result                   Changes
+}
+
+func (c *protoChecker) checkMethods() {
+	for _, name := range slices.Sorted(maps.Keys(c.revision.Methods)) {
+		if _, ok := c.base.Methods[name]; !ok {
+			c.result = append(c.result, c.newMethodChange(ProtoRPCAddedId, []any{name}, c.revision.Methods[name], c.revisionSource))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.base.Methods)) {
+		baseMethod := c.base.Methods[name]
+		revisionMethod, ok := c.revision.Methods[name]
+		if !ok {
+			c.result = append(c.result, c.newMethodChange(ProtoRPCRemovedId, []any{name}, baseMethod, c.baseSource))
+			continue
+		}
+
+		if baseMethod.InputType != revisionMethod.InputType {
+			c.result = append(c.result, c.newMethodChange(ProtoRPCRequestTypeChangedId, []any{name, baseMethod.InputType, revisionMethod.InputType}, revisionMethod, c.revisionSource))
+		}
+
+		if baseMethod.OutputType != revisionMethod.OutputType {
+			c.result = append(c.result, c.newMethodChange(ProtoRPCResponseTypeChangedId, []any{name, baseMethod.OutputType, revisionMethod.OutputType}, revisionMethod, c.revisionSource))
+		}
+
+		if baseStreaming, revisionStreaming := getStreaming(baseMethod), getStreaming(revisionMethod); baseStreaming != revisionStreaming {
+			c.result = append(c.result, c.newMethodChange(ProtoRPCStreamingChangedId, []any{name, baseStreaming, revisionStreaming}, revisionMethod, c.revisionSource))
+		}
+
+		if baseBindings, revisionBindings := getHTTPBindings(baseMethod), getHTTPBindings(revisionMethod); baseBindings != revisionBindings {
+			c.result = append(c.result, c.newMethodChange(ProtoRPCHTTPBindingChangedId, []any{name, baseBindings, revisionBindings}, revisionMethod, c.revisionSource))
+		}
+	}
+}
+
+func (c *protoChecker) checkMessage(baseMessage, revisionMessage *protobuf.Message) {
+	name := baseMessage.Name
+
+	for _, number := range slices.Sorted(maps.Keys(baseMessage.Fields)) {
+		baseField := baseMessage.Fields[number]
+		revisionField, ok := revisionMessage.Fields[number]
+		if !ok {
+			if revisionMessage.Reserved.Contains(number) {
+				c.addTypeChange(ProtoFieldRemovedId, []any{baseField.Name, number, name}, name, c.baseSource)
+			} else {
+				c.addTypeChange(ProtoFieldRemovedWithoutReservationId, []any{baseField.Name, number, name}, name, c.baseSource)
+			}
+			continue
+		}
+
+		compatible := isProtoWireCompatible(baseField, revisionField)
+		if baseField.Name != revisionField.Name {
+			if !compatible {
+				// the number now belongs to a different field
+				c.addTypeChange(ProtoFieldNumberReusedId, []any{revisionField.Name, number, name}, name, c.revisionSource)
+				continue
+			}
+			c.addTypeChange(ProtoFieldRenamedId, []any{baseField.Name, revisionField.Name, name}, name, c.revisionSource)
+		} else if !compatible {
+			c.addTypeChange(ProtoFieldTypeChangedId, []any{revisionField.Name, name, baseField.TypeString(), revisionField.TypeString()}, name, c.revisionSource)
+		}
+
+		if (baseField.Label == protobuf.LabelRepeated) != (revisionField.Label == protobuf.LabelRepeated) {
+			c.addTypeChange(ProtoFieldCardinalityChangedId, []any{revisionField.Name, name, getCardinality(baseField), getCardinality(revisionField)}, name, c.revisionSource)
+			continue
+		}
+
+		if baseField.Label != protobuf.LabelRequired && revisionField.Label == protobuf.LabelRequired {
+			c.addTypeChange(ProtoFieldBecameRequiredId, []any{revisionField.Name, name}, name, c.revisionSource)
+		}
+
+		if baseField.Label == protobuf.LabelRequired && revisionField.Label != protobuf.LabelRequired {
+			c.addTypeChange(ProtoFieldBecameOptionalId, []any{revisionField.Name, name}, name, c.revisionSource)
+		}
+	}
+
+	for _, number := range slices.Sorted(maps.Keys(revisionMessage.Fields)) {
+		if _, ok := baseMessage.Fields[number]; ok {
+			continue
+		}
+		revisionField := revisionMessage.Fields[number]
+
+		switch {
+		case baseMessage.Reserved.Contains(number):
+			c.addTypeChange(ProtoFieldNumberReusedId, []any{revisionField.Name, number, name}, name, c.revisionSource)
+		case revisionField.Label == protobuf.LabelRequired:
+			c.addTypeChange(ProtoRequiredFieldAddedId, []any{revisionField.Name, number, name}, name, c.revisionSource)
+		default:
+			c.addTypeChange(ProtoFieldAddedId, []any{revisionField.Name, number, name}, name, c.revisionSource)
+		}
+	}
+}
+
+func (c *protoChecker) checkEnum(baseEnum, revisionEnum *protobuf.Enum) {
+	name := baseEnum.Name
+
+	for _, number := range slices.Sorted(maps.Keys(revisionEnum.Values)) {
+		if _, ok := baseEnum.Values[number]; !ok {
+			c.addTypeChange(ProtoEnumValueAddedId, []any{revisionEnum.Values[number], number, name}, name, c.revisionSource)
+		}
+	}
+
+	for _, number := range slices.Sorted(maps.Keys(baseEnum.Values)) {
+		if _, ok := revisionEnum.Values[number]; !ok {
+			c.addTypeChange(ProtoEnumValueRemovedId, []any{baseEnum.Values[number], number, name}, name, c.baseSource)
+		}
+	}
+}
+
+// addTypeChange reports a change to a message or an enum for each RPC that uses
+// it, in either descriptor set, or as a component change if none does
+func (c *protoChecker) addTypeChange(id string, args []any, typeName, source string) {
+	users := slices.Concat(c.baseUsers[typeName], c.revisionUsers[typeName])
+	slices.Sort(users)
+	users = slices.Compact(users)
+
+	if len(users) == 0 {
+		c.result = append(c.result, ComponentChange{
+			Id:        id,
+			Level:     c.config.getLogLevel(id),
+			Args:      args,
+			Component: ComponentProtobuf,
+		})
+		return
+	}
+
+	for _, name := range users {
+		method, ok := c.revision.Methods[name]
+		if !ok {
+			method = c.base.Methods[name]
+		}
+		c.result = append(c.result, c.newMethodChange(id, args, method, source))
+	}
+}
+
+func (c *protoChecker) newMethodChange(id string, args []any, method *protobuf.Method, source string) ApiChange {
+	result := ApiChange{
+		Id:          id,
+		Level:       c.config.getLogLevel(id),
+		Args:        args,
+		OperationId: method.Name,
+		Operation:   "POST",
+		Path:        method.Path(),
+		Source:      load.NewSource(source),
+	}
+
+	if len(method.HTTP) > 0 {
+		result.Operation = method.HTTP[0].Method
+		result.Path = method.HTTP[0].Path
+		result.Details = "(gRPC " + method.Path() + ")"
+	}
+
+	return result
+}
+
+// getProtoTypeUsers maps each message and enum to the RPCs that send or receive it, directly or nested
+func getProtoTypeUsers(set *protobuf.DescriptorSet) map[string][]string {
+	result := map[string][]string{}
+
+	for _, name := range slices.Sorted(maps.Keys(set.Methods)) {
+		method := set.Methods[name]
+		visited := map[string]bool{}
+		queue := []string{method.InputType, method.OutputType}
+		for len(queue) > 0 {
+			typeName := queue[0]
+			queue = queue[1:]
+			if visited[typeName] {
+				continue
+			}
+			visited[typeName] = true
+			result[typeName] = append(result[typeName], name)
+
+			if message, ok := set.Messages[typeName]; ok {
+				for _, number := range slices.Sorted(maps.Keys(message.Fields)) {
+					if field := message.Fields[number]; field.TypeName != "" {
+						queue = append(queue, field.TypeName)
+					}
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// protoWireTypes groups the scalar types that can be changed to one another
+// without breaking the wire format, see:
+// https://protobuf.dev/programming-guides/proto3/#updating
+var protoWireTypes = map[protobuf.Type]int{
+	protobuf.TypeInt32:    1,
+	protobuf.TypeUint32:   1,
+	protobuf.TypeInt64:    1,
+	protobuf.TypeUint64:   1,
+	protobuf.TypeBool:     1,
+	protobuf.TypeEnum:     1,
+	protobuf.TypeSint32:   2,
+	protobuf.TypeSint64:   2,
+	protobuf.TypeFixed32:  3,
+	protobuf.TypeSfixed32: 3,
+	protobuf.TypeFixed64:  4,
+	protobuf.TypeSfixed64: 4,
+	protobuf.TypeString:   5,
+	protobuf.TypeBytes:    5,
+}
+
+func isProtoWireCompatible(baseField, revisionField *protobuf.Field) bool {
+	if baseField.Type == revisionField.Type {
+		return baseField.TypeName == revisionField.TypeName
+	}
+	group, ok := protoWireTypes[baseField.Type]
+	return ok && group == protoWireTypes[revisionField.Type]
+}
+
+func getCardinality(field *protobuf.Field) string {
+	if field.Label == protobuf.LabelRepeated {
+		return "repeated"
+	}
+	return "singular"
+}
+
+func getStreaming(method *protobuf.Method) string {
+	switch {
+	case method.ClientStreaming && method.ServerStreaming:
+		return "bidirectional streaming"
+	case method.ClientStreaming:
+		return "client streaming"
+	case method.ServerStreaming:
+		return "server streaming"
+	}
+	return "unary"
+}
+
+func getHTTPBindings(method *protobuf.Method) string {
+	if len(method.HTTP) == 0 {
+		return "none"
+	}
+	bindings := make([]string, len(method.HTTP))
+	for i, rule := range method.HTTP {
+		bindings[i] = rule.String()
+	}
+	return strings.Join(bindings, ", ")
+}
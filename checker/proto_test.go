@@ -0,0 +1,154 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/protobuf"
+	"github.com/stretchr/testify/require"
+)
+
+func getProtoChanges(t *testing.T, base, revision string) checker.Changes {
+	t.Helper()
+	s1, err := protobuf.Load(base)
+	require.NoError(t, err)
+	s2, err := protobuf.Load(revision)
+	require.NoError(t, err)
+	return checker.CheckProtoCompatibility(allChecksConfig(), s1, s2, base, revision)
+}
+
+func getProtoChangesById(t *testing.T, id string) checker.Changes {
+	t.Helper()
+	result := checker.Changes{}
+	for _, change := range getProtoChanges(t, "../data/proto/base.pb", "../data/proto/revision.pb") {
+		if change.GetId() == id {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+// RPCs transcoded to REST are reported at their HTTP binding, others at their gRPC path
+func TestProto_RPCRemoved(t *testing.T) {
+	errs := getProtoChangesById(t, checker.ProtoRPCRemovedId)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.ERR, errs[0].GetLevel())
+	require.Equal(t, "POST", errs[0].GetOperation())
+	require.Equal(t, "/acme.users.v1.Users/DeleteUser", errs[0].GetPath())
+	require.Equal(t, "acme.users.v1.Users.DeleteUser", errs[0].GetOperationId())
+	require.Equal(t, "../data/proto/base.pb", errs[0].GetSource())
+}
+
+func TestProto_RPCAdded(t *testing.T) {
+	errs := getProtoChangesById(t, checker.ProtoRPCAddedId)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.INFO, errs[0].GetLevel())
+	require.Equal(t, "POST", errs[0].GetOperation())
+	require.Equal(t, "/v1/users", errs[0].GetPath())
+	require.Equal(t, "added the RPC `acme.users.v1.Users.CreateUser` (gRPC /acme.users.v1.Users/CreateUser)", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
+}
+
+func TestProto_RPCChanged(t *testing.T) {
+	errs := getProtoChangesById(t, checker.ProtoRPCHTTPBindingChangedId)
+	require.Len(t, errs, 1)
+	require.Equal(t, []any{"acme.users.v1.Users.GetUser", "GET /v1/users/{id}", "GET /v1/accounts/{id}"}, errs[0].GetArgs())
+
+	errs = getProtoChangesById(t, checker.ProtoRPCRequestTypeChangedId)
+	require.Len(t, errs, 1)
+	require.Equal(t, []any{"acme.users.v1.Users.ListUsers", "acme.users.v1.ListUsersRequest", "acme.users.v1.ListUsersQuery"}, errs[0].GetArgs())
+
+	errs = getProtoChangesById(t, checker.ProtoRPCStreamingChangedId)
+	require.Len(t, errs, 1)
+	require.Equal(t, []any{"acme.users.v1.Users.WatchUsers", "server streaming", "bidirectional streaming"}, errs[0].GetArgs())
+
+	require.Empty(t, getProtoChangesById(t, checker.ProtoRPCResponseTypeChangedId))
+}
+
+// a change to a message is reported for each RPC that uses it, directly or nested
+func TestProto_FieldTypeChanged(t *testing.T) {
+	errs := getProtoChangesById(t, checker.ProtoFieldTypeChangedId)
+	require.Len(t, errs, 4)
+	require.Equal(t, []any{"age", "acme.users.v1.User", "int32", "string"}, errs[0].GetArgs())
+
+	operations := []string{}
+	for _, change := range errs {
+		require.Equal(t, checker.ERR, change.GetLevel())
+		operations = append(operations, change.GetOperation()+" "+change.GetPath())
+	}
+	require.Equal(t, []string{
+		"POST /v1/users",
+		"GET /v1/accounts/{id}",
+		"GET /v1/users",
+		"POST /acme.users.v1.Users/WatchUsers",
+	}, operations)
+}
+
+// changing to a wire-compatible type isn't reported: score from int32 to int64, next_page_token from string to bytes
+func TestProto_WireCompatibleTypeChange(t *testing.T) {
+	for _, change := range getProtoChanges(t, "../data/proto/base.pb", "../data/proto/revision.pb") {
+		require.NotContains(t, change.GetArgs(), "score")
+		require.NotContains(t, change.GetArgs(), "next_page_token")
+	}
+}
+
+func TestProto_FieldRemoved(t *testing.T) {
+	errs := getProtoChangesById(t, checker.ProtoFieldRemovedId)
+	require.Len(t, errs, 4)
+	require.Equal(t, checker.INFO, errs[0].GetLevel())
+	require.Equal(t, []any{"email", int32(5), "acme.users.v1.User"}, errs[0].GetArgs())
+
+	errs = getProtoChangesById(t, checker.ProtoFieldRemovedWithoutReservationId)
+	require.Len(t, errs, 4)
+	require.Equal(t, checker.WARN, errs[0].GetLevel())
+	require.Equal(t, []any{"nickname", int32(6), "acme.users.v1.User"}, errs[0].GetArgs())
+}
+
+func TestProto_FieldNumberReused(t *testing.T) {
+	errs := getProtoChangesById(t, checker.ProtoFieldNumberReusedId)
+	require.Len(t, errs, 4)
+	require.Equal(t, checker.ERR, errs[0].GetLevel())
+	require.Equal(t, []any{"legacy_id", int32(9), "acme.users.v1.User"}, errs[0].GetArgs())
+}
+
+func TestProto_FieldRenamedAndCardinality(t *testing.T) {
+	errs := getProtoChangesById(t, checker.ProtoFieldRenamedId)
+	require.Len(t, errs, 4)
+	require.Equal(t, checker.WARN, errs[0].GetLevel())
+	require.Equal(t, []any{"name", "display_name", "acme.users.v1.User"}, errs[0].GetArgs())
+
+	errs = getProtoChangesById(t, checker.ProtoFieldCardinalityChangedId)
+	require.Len(t, errs, 4)
+	require.Equal(t, []any{"tags", "acme.users.v1.User", "repeated", "singular"}, errs[0].GetArgs())
+}
+
+func TestProto_Enum(t *testing.T) {
+	errs := getProtoChangesById(t, checker.ProtoEnumValueAddedId)
+	require.Len(t, errs, 4)
+	require.Equal(t, []any{"DELETED", int32(3), "acme.users.v1.Status"}, errs[0].GetArgs())
+
+	errs = getProtoChangesById(t, checker.ProtoEnumValueRemovedId)
+	require.Len(t, errs, 4)
+	require.Equal(t, checker.WARN, errs[0].GetLevel())
+	require.Equal(t, []any{"SUSPENDED", int32(2), "acme.users.v1.Status"}, errs[0].GetArgs())
+}
+
+// messages that no RPC uses are reported as component changes
+func TestProto_Required(t *testing.T) {
+	errs := getProtoChangesById(t, checker.ProtoRequiredFieldAddedId)
+	require.Len(t, errs, 1)
+	require.IsType(t, checker.ComponentChange{}, errs[0])
+	require.Equal(t, checker.ERR, errs[0].GetLevel())
+	require.Equal(t, "added the required field `reason` with number `3` to the message `acme.legacy.v1.Audit`", errs[0].GetUncolorizedText(checker.NewDefaultLocalizer()))
+
+	errs = getProtoChangesById(t, checker.ProtoFieldBecameRequiredId)
+	require.Len(t, errs, 1)
+	require.Equal(t, []any{"actor", "acme.legacy.v1.Audit"}, errs[0].GetArgs())
+
+	errs = getProtoChangesById(t, checker.ProtoFieldBecameOptionalId)
+	require.Len(t, errs, 1)
+	require.Equal(t, checker.WARN, errs[0].GetLevel())
+}
+
+func TestProto_NoChanges(t *testing.T) {
+	require.Empty(t, getProtoChanges(t, "../data/proto/base.pb", "../data/proto/base.pb"))
+}
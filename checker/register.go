@@ -0,0 +1,82 @@
+package checker
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var (
+	registryMutex   sync.RWMutex
+	registeredRules BackwardCompatibilityRules
+	// registeredMessages maps a locale to the messages registered for it, by key
+	registeredMessages = map[string]map[string]string{}
+)
+
+// RegisterRule adds a rule to the rules returned by GetAllRules, so that it is checked along with the built-in rules
+// by the configs that NewConfig creates from GetAllChecks, and its level can be customized like theirs.
+// It is meant to be called from the init function of a package that provides rules, like database/sql.Register:
+// rules that are registered after a config was created are not checked by it.
+//
+// The rule's Description is the localization key of its description; it defaults to the rule's id followed by
+// "-description". The messages of the rule's changes and its description can be added with RegisterLocalizations.
+//
+// RegisterRule panics if the rule has no id or handler, or if its id is already used by another rule.
+func RegisterRule(rule BackwardCompatibilityRule) {
+	if rule.Id == "" {
+		panic("checker: RegisterRule called without a rule id")
+	}
+	if rule.Handler == nil {
+		panic(fmt.Sprintf("checker: RegisterRule called without a handler for rule %q", rule.Id))
+	}
+	if rule.Description == "" {
+		rule.Description = descriptionId(rule.Id)
+	}
+
+	// the lock is held from the lookup through the append, so that concurrent registrations of an id can't both pass
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if isRuleIdUsed(rule.Id) {
+		panic(fmt.Sprintf("checker: RegisterRule called twice for rule %q", rule.Id))
+	}
+	registeredRules = append(registeredRules, rule)
+}
+
+// isRuleIdUsed reports whether a built-in or registered rule has the id; the caller holds registryMutex
+func isRuleIdUsed(id string) bool {
+	hasId := func(r BackwardCompatibilityRule) bool { return r.Id == id }
+	return slices.ContainsFunc(getBuiltinRules(), hasId) || slices.ContainsFunc(registeredRules, hasId)
+}
+
+func getRegisteredRules() BackwardCompatibilityRules {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return slices.Clone(registeredRules)
+}
+
+// RegisterLocalizations adds messages for the given locale to the localizers that NewLocalizer creates.
+// The keys are the ids of the changes and the other keys that the localizer is called with, like "my-rule" and
+// "my-rule-description", and the messages are fmt patterns for the change's args, like the built-in ones.
+// A registered message takes precedence over a built-in one with the same key.
+// Messages that are missing for a locale fall back to those of the default locale (English).
+func RegisterLocalizations(locale string, messages map[string]string) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if registeredMessages[locale] == nil {
+		registeredMessages[locale] = map[string]string{}
+	}
+	for key, message := range messages {
+		registeredMessages[locale][key] = message
+	}
+}
+
+// getRegisteredMessage returns the message registered for the key in the locale
+func getRegisteredMessage(locale, key string) (string, bool) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	message, ok := registeredMessages[locale][key]
+	return message, ok
+}
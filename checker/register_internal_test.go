@@ -0,0 +1,146 @@
+package checker
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/stretchr/testify/require"
+)
+
+const testMaxItemsRemovedId = "test-response-property-max-items-removed"
+
+// testMaxItemsRemovedCheck is a check written the way a package outside checker writes one
+func testMaxItemsRemovedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config) Changes {
+	result := make(Changes, 0)
+	WalkModifiedResponseSchemas(diffReport, operationsSources, config, func(info MediaTypeInfo) {
+		info.WalkProperties(func(p PropertyInfo) {
+			maxItemsDiff := p.SchemaDiff().MaxItemsDiff
+			if maxItemsDiff == nil || maxItemsDiff.To != nil {
+				return
+			}
+			baseSource, revisionSource := SchemaFieldSources(operationsSources, info.OperationDiff(), p.SchemaDiff(), "maxItems")
+			result = append(result, p.NewChange(
+				testMaxItemsRemovedId,
+				[]any{p.FullName(), info.ResponseStatus()},
+				"",
+			).WithSources(baseSource, revisionSource))
+		})
+	})
+	return result
+}
+
+// resetRegistry restores the registry when the test ends, so that the registered rules don't leak into other tests
+func resetRegistry(t *testing.T) {
+	t.Cleanup(func() {
+		registryMutex.Lock()
+		defer registryMutex.Unlock()
+		registeredRules = nil
+		registeredMessages = map[string]map[string]string{}
+	})
+}
+
+func getTestDiff(t *testing.T) (*diff.Diff, *diff.OperationsSourcesMap) {
+	t.Helper()
+
+	loader := openapi3.NewLoader()
+	loader.IncludeOrigin = true
+	s1, err := load.NewSpecInfo(loader, load.NewSource("../data/custom-rules/base.yaml"))
+	require.NoError(t, err)
+	s2, err := load.NewSpecInfo(loader, load.NewSource("../data/custom-rules/revision.yaml"))
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	return d, osm
+}
+
+func TestRegisterRule(t *testing.T) {
+	resetRegistry(t)
+
+	builtin := len(GetAllRules())
+	RegisterRule(BackwardCompatibilityRule{
+		Id:        testMaxItemsRemovedId,
+		Level:     WARN,
+		Handler:   testMaxItemsRemovedCheck,
+		Direction: DirectionResponse,
+		Area:      AreaSchema,
+		Kind:      KindConstraints,
+		Action:    ActionRemove,
+	})
+	RegisterLocalizations("en", map[string]string{
+		testMaxItemsRemovedId:                  "removed maxItems from the response property %s for the response status %s",
+		testMaxItemsRemovedId + "-description": "maxItems removed from a response property",
+	})
+	RegisterLocalizations("es", map[string]string{
+		testMaxItemsRemovedId: "se eliminó maxItems de la propiedad de respuesta %s para el estado de respuesta %s",
+	})
+
+	rules := GetAllRules()
+	require.Len(t, rules, builtin+1)
+	require.Equal(t, testMaxItemsRemovedId+"-description", rules[builtin].Description)
+	require.Equal(t, WARN, GetCheckLevels()[testMaxItemsRemovedId])
+
+	d, osm := getTestDiff(t)
+	changes := CheckBackwardCompatibilityUntilLevel(NewConfig(GetAllChecks()), d, osm, INFO)
+
+	found := Changes{}
+	for _, change := range changes {
+		if change.GetId() == testMaxItemsRemovedId {
+			found = append(found, change)
+		}
+	}
+	require.Len(t, found, 1)
+	require.Equal(t, "/owners", found[0].GetPath())
+	require.Equal(t, WARN, found[0].GetLevel())
+	require.Equal(t, "removed maxItems from the response property `names` for the response status `200`", found[0].GetUncolorizedText(NewLocalizer("en")))
+	require.Equal(t, "se eliminó maxItems de la propiedad de respuesta `names` para el estado de respuesta `200`", found[0].GetUncolorizedText(NewLocalizer("es")))
+
+	// the description falls back to the default locale
+	require.Equal(t, "maxItems removed from a response property", NewLocalizer("es")(testMaxItemsRemovedId+"-description"))
+
+	// the level of a registered rule can be customized
+	config := NewConfig(GetAllChecks(), WithSeverityLevels(map[string]Level{testMaxItemsRemovedId: ERR}))
+	require.Equal(t, ERR, config.getLogLevel(testMaxItemsRemovedId))
+}
+
+func TestRegisterRule_Invalid(t *testing.T) {
+	resetRegistry(t)
+
+	require.PanicsWithValue(t, "checker: RegisterRule called without a rule id", func() {
+		RegisterRule(BackwardCompatibilityRule{Handler: testMaxItemsRemovedCheck})
+	})
+	require.PanicsWithValue(t, `checker: RegisterRule called without a handler for rule "test"`, func() {
+		RegisterRule(BackwardCompatibilityRule{Id: "test"})
+	})
+	require.PanicsWithValue(t, `checker: RegisterRule called twice for rule "endpoint-added"`, func() {
+		RegisterRule(BackwardCompatibilityRule{Id: EndpointAddedId, Handler: testMaxItemsRemovedCheck})
+	})
+}
+
+func TestRegisterRule_Concurrent(t *testing.T) {
+	resetRegistry(t)
+
+	const registrations = 10
+	var panics atomic.Int32
+	var wg sync.WaitGroup
+	for range registrations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if recover() != nil {
+					panics.Add(1)
+				}
+			}()
+			RegisterRule(BackwardCompatibilityRule{Id: testMaxItemsRemovedId, Handler: testMaxItemsRemovedCheck})
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, getRegisteredRules(), 1)
+	require.Equal(t, int32(registrations-1), panics.Load())
+}
@@ -0,0 +1,65 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/stretchr/testify/require"
+)
+
+const rulePackMaxItemsRemovedId = "rule-pack-response-property-max-items-removed"
+
+// rulePackMaxItemsRemovedCheck is the check of docs/GO.md, written with the exported API only
+func rulePackMaxItemsRemovedCheck(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *checker.Config) checker.Changes {
+	result := make(checker.Changes, 0)
+	checker.WalkModifiedResponseSchemas(diffReport, operationsSources, config, func(info checker.MediaTypeInfo) {
+		info.WalkProperties(func(p checker.PropertyInfo) {
+			if d := p.SchemaDiff().MaxItemsDiff; d == nil || d.To != nil {
+				return
+			}
+			baseSource, revisionSource := checker.SchemaFieldSources(operationsSources, info.OperationDiff(), p.SchemaDiff(), "maxItems")
+			result = append(result, p.NewChange(
+				rulePackMaxItemsRemovedId,
+				[]any{p.FullName(), info.ResponseStatus()},
+				"",
+			).WithSources(baseSource, revisionSource))
+		})
+	})
+	return result
+}
+
+// a rule registered from another package is checked along with the built-in rules
+func TestRegisterRule_ExternalPackage(t *testing.T) {
+	checker.ResetRegistry(t)
+
+	checker.RegisterRule(checker.BackwardCompatibilityRule{
+		Id:        rulePackMaxItemsRemovedId,
+		Level:     checker.ERR,
+		Handler:   rulePackMaxItemsRemovedCheck,
+		Direction: checker.DirectionResponse,
+		Area:      checker.AreaSchema,
+		Kind:      checker.KindConstraints,
+		Action:    checker.ActionRemove,
+	})
+	checker.RegisterLocalizations("en", map[string]string{
+		rulePackMaxItemsRemovedId: "removed maxItems from the response property %s for the response status %s",
+	})
+
+	loader := newLoaderWithOriginTracking()
+	s1, err := open("../data/custom-rules/base.yaml", loader)
+	require.NoError(t, err)
+	s2, err := open("../data/custom-rules/revision.yaml", loader)
+	require.NoError(t, err)
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	errs := checker.CheckBackwardCompatibility(checker.NewConfig(checker.GetAllChecks()), d, osm)
+
+	change := requireChange(t, errs, rulePackMaxItemsRemovedId)
+	require.Equal(t, checker.ERR, change.GetLevel())
+	require.Equal(t, "/owners", change.GetPath())
+	require.Equal(t, "removed maxItems from the response property `names` for the response status `200`", change.GetUncolorizedText(checker.NewDefaultLocalizer()))
+	require.Equal(t, "../data/custom-rules/base.yaml", change.GetBaseSource().File)
+	require.NotZero(t, change.GetBaseSource().Line)
+}
@@ -0,0 +1,26 @@
+package checker
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+const (
+	ResponsePropertyRemovedWithDeprecationId = "response-property-removed-with-deprecation"
+	ResponsePropertyRemovedBeforeSunsetId    = "response-property-removed-before-sunset"
+	ResponsePropertySunsetParseId            = "response-property-sunset-parse"
+)
+
+// getResponsePropertyRemoval returns the change reported when a response property is removed, and false if it was
+// removed after its sunset date.
+// The removal of a write-only property is reported as such, whether or not it was deprecated, since the property
+// wasn't in the responses anyway.
+func getResponsePropertyRemoval(config *Config, property *openapi3.Schema, withoutDeprecationId, writeOnlyId string, args ...any) (removal, bool) {
+	if property.WriteOnly {
+		return removal{id: writeOnlyId, args: args, level: config.getLogLevel(writeOnlyId)}, true
+	}
+
+	return removalIds{
+		withoutDeprecation: withoutDeprecationId,
+		withDeprecation:    ResponsePropertyRemovedWithDeprecationId,
+		beforeSunset:       ResponsePropertyRemovedBeforeSunsetId,
+		sunsetParse:        ResponsePropertySunsetParseId,
+	}.getRemoval(config, property.Deprecated, property.Extensions, args...)
+}
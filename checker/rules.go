@@ -35,6 +35,10 @@ const (
 	// metadata. It exists so the area taxonomy covers every top-level
 	// document section a future rule could target.
 	AreaServers
+	// AreaChannels is AsyncAPI's counterpart of AreaPaths: its rules concern
+	// the channels of an AsyncAPI document, the operations on them and the
+	// messages they carry.
+	AreaChannels
 	AreaNone
 )
 
@@ -102,6 +106,8 @@ func (a Area) String() string {
 		return "info"
 	case AreaServers:
 		return "servers"
+	case AreaChannels:
+		return "channels"
 	default:
 		return "none"
 	}
@@ -183,7 +189,12 @@ func newBackwardCompatibilityRule(id string, level Level, handler BackwardCompat
 
 type BackwardCompatibilityRules []BackwardCompatibilityRule
 
+// GetAllRules returns the built-in rules, followed by the rules added with RegisterRule
 func GetAllRules() BackwardCompatibilityRules {
+	return append(getBuiltinRules(), getRegisteredRules()...)
+}
+
+func getBuiltinRules() BackwardCompatibilityRules {
 	return BackwardCompatibilityRules{
 		// Request property deprecation checks
 		newBackwardCompatibilityRule(RequestPropertyDeprecatedId, INFO, RequestPropertyDeprecationCheck, DirectionRequest, AreaSchema, KindLifecycle, ActionChange),
@@ -203,12 +214,18 @@ func GetAllRules() BackwardCompatibilityRules {
 		newBackwardCompatibilityRule(EndpointAddedId, INFO, APIAddedCheck, DirectionNone, AreaPaths, KindExistence, ActionAdd),
 		// APIComponentsSecurityUpdatedCheck
 		newBackwardCompatibilityRule(APIComponentsSecurityRemovedId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(APIComponentsSecurityRemovedWithDeprecationId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(APIComponentsSecurityRemovedBeforeSunsetId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(APIComponentsSecuritySunsetParseId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindLifecycle, ActionChange),
 		newBackwardCompatibilityRule(APIComponentsSecurityAddedId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindExistence, ActionAdd),
 		newBackwardCompatibilityRule(APIComponentsSecurityComponentOauthUrlUpdatedId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindType, ActionChange),
 		newBackwardCompatibilityRule(APIComponentsSecurityTypeUpdatedId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindType, ActionChange),
 		newBackwardCompatibilityRule(APIComponentsSecurityOauthTokenUrlUpdatedId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindType, ActionChange),
 		newBackwardCompatibilityRule(APIComponentSecurityOauthScopeAddedId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindExistence, ActionAdd),
 		newBackwardCompatibilityRule(APIComponentSecurityOauthScopeRemovedId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(APIComponentSecurityOauthScopeRemovedWithDeprecationId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(APIComponentSecurityOauthScopeRemovedBeforeSunsetId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(APIComponentSecurityOauthScopeSunsetParseId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindLifecycle, ActionChange),
 		newBackwardCompatibilityRule(APIComponentSecurityOauthScopeUpdatedId, INFO, APIComponentsSecurityUpdatedCheck, DirectionNone, AreaSecurity, KindType, ActionChange),
 		// APISecurityUpdatedCheck
 		newBackwardCompatibilityRule(APISecurityRemovedCheckId, INFO, APISecurityUpdatedCheck, DirectionNone, AreaSecurity, KindExistence, ActionRemove),
@@ -257,6 +274,11 @@ func GetAllRules() BackwardCompatibilityRules {
 		// APISunsetChangedCheck
 		newBackwardCompatibilityRule(APISunsetDeletedId, ERR, APISunsetChangedCheck, DirectionNone, AreaPaths, KindLifecycle, ActionRemove),
 		newBackwardCompatibilityRule(APISunsetDateChangedTooSmallId, ERR, APISunsetChangedCheck, DirectionNone, AreaPaths, KindLifecycle, ActionChange),
+		// SunsetDatePassedCheck
+		newBackwardCompatibilityRule(APISunsetDatePassedId, WARN, SunsetDatePassedCheck, DirectionNone, AreaPaths, KindLifecycle, ActionNone),
+		newBackwardCompatibilityRule(RequestParameterSunsetDatePassedId, WARN, SunsetDatePassedCheck, DirectionRequest, AreaParameters, KindLifecycle, ActionNone),
+		newBackwardCompatibilityRule(RequestPropertySunsetDatePassedId, WARN, SunsetDatePassedCheck, DirectionRequest, AreaSchema, KindLifecycle, ActionNone),
+		newBackwardCompatibilityRule(ResponsePropertySunsetDatePassedId, WARN, SunsetDatePassedCheck, DirectionResponse, AreaSchema, KindLifecycle, ActionNone),
 		// RequestParameterSunsetChangedCheck
 		newBackwardCompatibilityRule(RequestParameterSunsetDeletedId, ERR, RequestParameterSunsetChangedCheck, DirectionRequest, AreaParameters, KindLifecycle, ActionChange),
 		newBackwardCompatibilityRule(RequestParameterSunsetDateChangedTooSmallId, ERR, RequestParameterSunsetChangedCheck, DirectionRequest, AreaParameters, KindLifecycle, ActionChange),
@@ -520,6 +542,9 @@ func GetAllRules() BackwardCompatibilityRules {
 		// ResponseHeaderRemovedCheck
 		newBackwardCompatibilityRule(RequiredResponseHeaderRemovedId, ERR, ResponseHeaderRemovedCheck, DirectionResponse, AreaHeaders, KindExistence, ActionRemove),
 		newBackwardCompatibilityRule(OptionalResponseHeaderRemovedId, WARN, ResponseHeaderRemovedCheck, DirectionResponse, AreaHeaders, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponseHeaderRemovedWithDeprecationId, INFO, ResponseHeaderRemovedCheck, DirectionResponse, AreaHeaders, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponseHeaderRemovedBeforeSunsetId, ERR, ResponseHeaderRemovedCheck, DirectionResponse, AreaHeaders, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponseHeaderSunsetParseId, ERR, ResponseHeaderRemovedCheck, DirectionResponse, AreaHeaders, KindLifecycle, ActionChange),
 		// ResponseHeaderAddedCheck
 		newBackwardCompatibilityRule(ResponseHeaderAddedId, INFO, ResponseHeaderAddedCheck, DirectionResponse, AreaHeaders, KindExistence, ActionAdd),
 		// ResponseHeaderTypeChangedCheck
@@ -532,6 +557,9 @@ func GetAllRules() BackwardCompatibilityRules {
 		newBackwardCompatibilityRule(ResponseHeaderBecameNotNullableId, INFO, ResponseHeaderBecameNullableCheck, DirectionResponse, AreaHeaders, KindRequiredness, ActionChange),
 		// ResponseMediaTypeUpdatedCheck
 		newBackwardCompatibilityRule(ResponseMediaTypeRemovedId, ERR, ResponseMediaTypeUpdatedCheck, DirectionResponse, AreaResponses, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponseMediaTypeRemovedWithDeprecationId, INFO, ResponseMediaTypeUpdatedCheck, DirectionResponse, AreaResponses, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponseMediaTypeRemovedBeforeSunsetId, ERR, ResponseMediaTypeUpdatedCheck, DirectionResponse, AreaResponses, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponseMediaTypeSunsetParseId, ERR, ResponseMediaTypeUpdatedCheck, DirectionResponse, AreaResponses, KindLifecycle, ActionChange),
 		newBackwardCompatibilityRule(ResponseMediaTypeAddedId, INFO, ResponseMediaTypeUpdatedCheck, DirectionResponse, AreaResponses, KindExistence, ActionAdd),
 		// ResponseMediaTypeNameUpdatedCheck
 		newBackwardCompatibilityRule(ResponseMediaTypeNameChangedId, INFO, ResponseMediaTypeNameUpdatedCheck, DirectionResponse, AreaResponses, KindType, ActionChange),
@@ -539,6 +567,9 @@ func GetAllRules() BackwardCompatibilityRules {
 		newBackwardCompatibilityRule(ResponseMediaTypeNameSpecializedId, INFO, ResponseMediaTypeNameUpdatedCheck, DirectionResponse, AreaResponses, KindType, ActionSpecialize),
 		// ResponseOptionalPropertyUpdatedCheck
 		newBackwardCompatibilityRule(ResponseOptionalPropertyRemovedId, WARN, ResponseOptionalPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponsePropertyRemovedWithDeprecationId, INFO, ResponseOptionalPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponsePropertyRemovedBeforeSunsetId, ERR, ResponseOptionalPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponsePropertySunsetParseId, ERR, ResponseOptionalPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindLifecycle, ActionChange),
 		newBackwardCompatibilityRule(ResponseOptionalWriteOnlyPropertyRemovedId, INFO, ResponseOptionalPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindExistence, ActionRemove),
 		newBackwardCompatibilityRule(ResponseOptionalPropertyAddedId, INFO, ResponseOptionalPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindExistence, ActionAdd),
 		newBackwardCompatibilityRule(ResponseOptionalWriteOnlyPropertyAddedId, INFO, ResponseOptionalPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindExistence, ActionAdd),
@@ -634,6 +665,9 @@ func GetAllRules() BackwardCompatibilityRules {
 		newBackwardCompatibilityRule(ResponsePropertyTypeCompatibleId, INFO, ResponsePropertyTypeChangedCheck, DirectionResponse, AreaSchema, KindType, ActionChange),
 		// ResponseRequiredPropertyUpdatedCheck
 		newBackwardCompatibilityRule(ResponseRequiredPropertyRemovedId, ERR, ResponseRequiredPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponsePropertyRemovedWithDeprecationId, INFO, ResponseRequiredPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponsePropertyRemovedBeforeSunsetId, ERR, ResponseRequiredPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponsePropertySunsetParseId, ERR, ResponseRequiredPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindLifecycle, ActionChange),
 		newBackwardCompatibilityRule(ResponseRequiredWriteOnlyPropertyRemovedId, INFO, ResponseRequiredPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindExistence, ActionRemove),
 		newBackwardCompatibilityRule(ResponseRequiredPropertyAddedId, INFO, ResponseRequiredPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindExistence, ActionAdd),
 		newBackwardCompatibilityRule(ResponseRequiredWriteOnlyPropertyAddedId, INFO, ResponseRequiredPropertyUpdatedCheck, DirectionResponse, AreaSchema, KindExistence, ActionAdd),
@@ -645,9 +679,15 @@ func GetAllRules() BackwardCompatibilityRules {
 		newBackwardCompatibilityRule(ResponseRequiredPropertyBecameNonReadOnlyId, INFO, ResponseRequiredPropertyWriteOnlyReadOnlyCheck, DirectionResponse, AreaSchema, KindMutability, ActionChange),
 		// ResponseSuccessStatusUpdatedCheck
 		newBackwardCompatibilityRule(ResponseSuccessStatusRemovedId, ERR, ResponseSuccessStatusUpdatedCheck, DirectionResponse, AreaResponses, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponseStatusRemovedWithDeprecationId, INFO, ResponseSuccessStatusUpdatedCheck, DirectionResponse, AreaResponses, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponseStatusRemovedBeforeSunsetId, ERR, ResponseSuccessStatusUpdatedCheck, DirectionResponse, AreaResponses, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponseStatusSunsetParseId, ERR, ResponseSuccessStatusUpdatedCheck, DirectionResponse, AreaResponses, KindLifecycle, ActionChange),
 		newBackwardCompatibilityRule(ResponseSuccessStatusAddedId, INFO, ResponseSuccessStatusUpdatedCheck, DirectionResponse, AreaResponses, KindExistence, ActionAdd),
 		// ResponseNonSuccessStatusUpdatedCheck
 		newBackwardCompatibilityRule(ResponseNonSuccessStatusRemovedId, INFO, ResponseNonSuccessStatusUpdatedCheck, DirectionResponse, AreaResponses, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponseStatusRemovedWithDeprecationId, INFO, ResponseNonSuccessStatusUpdatedCheck, DirectionResponse, AreaResponses, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponseStatusRemovedBeforeSunsetId, ERR, ResponseNonSuccessStatusUpdatedCheck, DirectionResponse, AreaResponses, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ResponseStatusSunsetParseId, ERR, ResponseNonSuccessStatusUpdatedCheck, DirectionResponse, AreaResponses, KindLifecycle, ActionChange),
 		newBackwardCompatibilityRule(ResponseNonSuccessStatusAddedId, INFO, ResponseNonSuccessStatusUpdatedCheck, DirectionResponse, AreaResponses, KindExistence, ActionAdd),
 		// APIOperationIdUpdatedCheck
 		newBackwardCompatibilityRule(APIOperationIdRemovedId, INFO, APIOperationIdUpdatedCheck, DirectionNone, AreaPaths, KindExistence, ActionRemove),
@@ -658,6 +698,34 @@ func GetAllRules() BackwardCompatibilityRules {
 		// WebhookUpdatedCheck
 		newBackwardCompatibilityRule(WebhookAddedId, INFO, WebhookUpdatedCheck, DirectionNone, AreaComponents, KindExistence, ActionAdd),
 		newBackwardCompatibilityRule(WebhookRemovedId, ERR, WebhookUpdatedCheck, DirectionNone, AreaComponents, KindExistence, ActionRemove),
+		// AsyncAPIUpdatedCheck
+		newBackwardCompatibilityRule(AsyncAPIChannelAddedId, INFO, AsyncAPIUpdatedCheck, DirectionNone, AreaChannels, KindExistence, ActionAdd),
+		newBackwardCompatibilityRule(AsyncAPIChannelRemovedId, ERR, AsyncAPIUpdatedCheck, DirectionNone, AreaChannels, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(AsyncAPIOperationAddedId, INFO, AsyncAPIUpdatedCheck, DirectionNone, AreaChannels, KindExistence, ActionAdd),
+		newBackwardCompatibilityRule(AsyncAPIOperationRemovedId, ERR, AsyncAPIUpdatedCheck, DirectionNone, AreaChannels, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(AsyncAPIMessageAddedId, INFO, AsyncAPIUpdatedCheck, DirectionNone, AreaChannels, KindExistence, ActionAdd),
+		newBackwardCompatibilityRule(AsyncAPIMessageRemovedId, ERR, AsyncAPIUpdatedCheck, DirectionNone, AreaChannels, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(AsyncAPIMessageContentTypeChangedId, ERR, AsyncAPIUpdatedCheck, DirectionNone, AreaChannels, KindType, ActionChange),
+		// Protobuf checks are run by CheckProtoCompatibility, on descriptor sets
+		// given alongside the specs.
+		newBackwardCompatibilityRule(ProtoRPCAddedId, INFO, nil, DirectionNone, AreaPaths, KindExistence, ActionAdd),
+		newBackwardCompatibilityRule(ProtoRPCRemovedId, ERR, nil, DirectionNone, AreaPaths, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ProtoRPCRequestTypeChangedId, ERR, nil, DirectionNone, AreaPaths, KindType, ActionChange),
+		newBackwardCompatibilityRule(ProtoRPCResponseTypeChangedId, ERR, nil, DirectionNone, AreaPaths, KindType, ActionChange),
+		newBackwardCompatibilityRule(ProtoRPCStreamingChangedId, ERR, nil, DirectionNone, AreaPaths, KindStructure, ActionChange),
+		newBackwardCompatibilityRule(ProtoRPCHTTPBindingChangedId, ERR, nil, DirectionNone, AreaPaths, KindStructure, ActionChange),
+		newBackwardCompatibilityRule(ProtoFieldAddedId, INFO, nil, DirectionNone, AreaSchema, KindExistence, ActionAdd),
+		newBackwardCompatibilityRule(ProtoRequiredFieldAddedId, ERR, nil, DirectionNone, AreaSchema, KindExistence, ActionAdd),
+		newBackwardCompatibilityRule(ProtoFieldRemovedId, INFO, nil, DirectionNone, AreaSchema, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ProtoFieldRemovedWithoutReservationId, WARN, nil, DirectionNone, AreaSchema, KindExistence, ActionRemove),
+		newBackwardCompatibilityRule(ProtoFieldNumberReusedId, ERR, nil, DirectionNone, AreaSchema, KindType, ActionChange),
+		newBackwardCompatibilityRule(ProtoFieldRenamedId, WARN, nil, DirectionNone, AreaSchema, KindStructure, ActionChange),
+		newBackwardCompatibilityRule(ProtoFieldTypeChangedId, ERR, nil, DirectionNone, AreaSchema, KindType, ActionChange),
+		newBackwardCompatibilityRule(ProtoFieldCardinalityChangedId, ERR, nil, DirectionNone, AreaSchema, KindStructure, ActionChange),
+		newBackwardCompatibilityRule(ProtoFieldBecameRequiredId, ERR, nil, DirectionNone, AreaSchema, KindRequiredness, ActionChange),
+		newBackwardCompatibilityRule(ProtoFieldBecameOptionalId, WARN, nil, DirectionNone, AreaSchema, KindRequiredness, ActionChange),
+		newBackwardCompatibilityRule(ProtoEnumValueAddedId, INFO, nil, DirectionNone, AreaSchema, KindValues, ActionAdd),
+		newBackwardCompatibilityRule(ProtoEnumValueRemovedId, WARN, nil, DirectionNone, AreaSchema, KindValues, ActionRemove),
 		// APIComponentsSchemaRemovedCheck
 		newBackwardCompatibilityRule(APISchemasRemovedId, INFO, APIComponentsSchemaRemovedCheck, DirectionNone, AreaComponents, KindExistence, ActionRemove),
 		// ResponseParameterEnumValueRemovedCheck
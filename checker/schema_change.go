@@ -0,0 +1,123 @@
+package checker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TwiN/go-color"
+	"github.com/oasdiff/oasdiff/load"
+)
+
+// SchemaChange represents a change to a standalone JSON Schema document, as
+// reported by CheckSchemaCompatibility.
+// Pointer locates the change in the document as a JSON pointer in URI fragment
+// form, e.g. #/properties/address/properties/zip, or # for the root schema.
+type SchemaChange struct {
+	CommonChange
+
+	Id      string
+	Args    []any
+	Comment string
+	Level   Level
+	Pointer string
+	Source  *load.Source
+}
+
+func (c SchemaChange) GetSection() string {
+	return "schema"
+}
+
+func (c SchemaChange) IsBreaking() bool {
+	return c.GetLevel().IsBreaking()
+}
+
+func (c SchemaChange) MatchIgnore(ignorePath, ignoreLine string, l Localizer) bool {
+	return strings.Contains(ignoreLine, strings.ToLower(c.GetUncolorizedText(l))) &&
+		strings.Contains(ignoreLine, strings.ToLower(c.Pointer))
+}
+
+func (c SchemaChange) GetId() string {
+	return c.Id
+}
+
+func (c SchemaChange) GetText(l Localizer) string {
+	return l(c.Id, colorizedValues(c.Args)...)
+}
+
+func (c SchemaChange) GetArgs() []any {
+	return c.Args
+}
+
+func (c SchemaChange) GetUncolorizedText(l Localizer) string {
+	return l(c.Id, quotedValues(c.Args)...)
+}
+
+func (c SchemaChange) GetComment(l Localizer) string {
+	return l(c.Comment)
+}
+
+func (c SchemaChange) GetLevel() Level {
+	return c.Level
+}
+
+func (SchemaChange) GetOperation() string {
+	return ""
+}
+
+func (SchemaChange) GetOperationId() string {
+	return ""
+}
+
+// GetPath returns the JSON pointer of the change
+func (c SchemaChange) GetPath() string {
+	return c.Pointer
+}
+
+func (c SchemaChange) GetSource() string {
+	if c.Source == nil {
+		return ""
+	}
+	return c.Source.DisplayPath()
+}
+
+func (c SchemaChange) GetSourceFile() string {
+	if c.Source == nil || !c.Source.IsFile() {
+		return ""
+	}
+	return c.Source.String()
+}
+
+func (SchemaChange) GetSourceLine() int {
+	return 0
+}
+
+func (SchemaChange) GetSourceLineEnd() int {
+	return 0
+}
+
+func (SchemaChange) GetSourceColumn() int {
+	return 0
+}
+
+func (SchemaChange) GetSourceColumnEnd() int {
+	return 0
+}
+
+func (c SchemaChange) SingleLineError(l Localizer, colorMode ColorMode) string {
+	const format = "%s %s %s, %s schema %s %s [%s]. %s"
+
+	if isColorEnabled(colorMode) {
+		return fmt.Sprintf(format, c.Level.PrettyString(), l("at"), c.GetSource(), l("in"), color.InGreen(c.Pointer), c.GetText(l), color.InYellow(c.Id), c.GetComment(l))
+	}
+	return fmt.Sprintf(format, c.Level.String(), l("at"), c.GetSource(), l("in"), c.Pointer, c.GetUncolorizedText(l), c.Id, c.GetComment(l))
+}
+
+func (c SchemaChange) MultiLineError(l Localizer, colorMode ColorMode) string {
+	const format = "%s\t[%s] %s %s\n\t%s schema %s\n\t\t%s%s"
+
+	if isColorEnabled(colorMode) {
+		return fmt.Sprintf(format, c.Level.PrettyString(), color.InYellow(c.Id), l("at"), c.GetSource(), l("in"), color.InGreen(c.Pointer), c.GetText(l), multiLineComment(c.GetComment(l)))
+	}
+
+	return fmt.Sprintf(format, c.Level.String(), c.Id, l("at"), c.GetSource(), l("in"), c.Pointer, c.GetUncolorizedText(l), multiLineComment(c.GetComment(l)))
+}
@@ -0,0 +1,190 @@
+package checker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/oasdiff/oasdiff/load"
+)
+
+// CheckSchemaCompatibility runs the checks on the diff of two standalone JSON
+// Schema documents, wrapped in specs by load.NewSpecInfoFromSchema, and reports
+// their changes as SchemaChanges, located by JSON pointers into the documents.
+//
+// The documents are checked as request bodies or as responses, depending on how
+// they were wrapped, so the request rules apply to schemas of the data that a
+// service accepts, and the response rules to those of the data it produces.
+func CheckSchemaCompatibility(config *Config, diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, level Level) Changes {
+	pointers := getSchemaPointers(getWrappedSchemaDiff(diffReport))
+
+	changes := CheckBackwardCompatibilityUntilLevel(config, diffReport, operationsSources, level)
+	result := make(Changes, 0, len(changes))
+	for _, change := range changes {
+		apiChange, ok := change.(ApiChange)
+		if !ok {
+			continue
+		}
+		result = append(result, SchemaChange{
+			CommonChange: apiChange.CommonChange,
+			Id:           apiChange.Id,
+			Args:         apiChange.Args,
+			Comment:      apiChange.Comment,
+			Level:        apiChange.Level,
+			Pointer:      pointers.find(apiChange),
+			Source:       apiChange.Source,
+		})
+	}
+	return result
+}
+
+// getWrappedSchemaDiff returns the diff of the schemas that load.NewSpecInfoFromSchema wrapped
+func getWrappedSchemaDiff(diffReport *diff.Diff) *diff.SchemaDiff {
+	if diffReport == nil || diffReport.PathsDiff == nil {
+		return nil
+	}
+	pathDiff := diffReport.PathsDiff.Modified[load.SchemaPath]
+	if pathDiff == nil || pathDiff.OperationsDiff == nil {
+		return nil
+	}
+	methodDiff := pathDiff.OperationsDiff.Modified[load.SchemaMethod]
+	if methodDiff == nil {
+		return nil
+	}
+
+	var contentDiff *diff.ContentDiff
+	if methodDiff.RequestBodyDiff != nil {
+		contentDiff = methodDiff.RequestBodyDiff.ContentDiff
+	} else if methodDiff.ResponsesDiff != nil {
+		if responseDiff := methodDiff.ResponsesDiff.Modified[load.SchemaStatus]; responseDiff != nil {
+			contentDiff = responseDiff.ContentDiff
+		}
+	}
+	if contentDiff == nil {
+		return nil
+	}
+
+	if mediaTypeDiff := contentDiff.MediaTypeModified[load.SchemaMediaType]; mediaTypeDiff != nil {
+		return mediaTypeDiff.SchemaDiff
+	}
+	return nil
+}
+
+// schemaPointers maps the property paths that the checks report, e.g.
+// address/zip, to the JSON pointers of the subschemas they name
+type schemaPointers map[string]string
+
+const rootPointer = "#"
+
+// getSchemaPointers walks a schema diff the way processModifiedPropertiesDiff
+// does, to map the property paths that it builds to JSON pointers
+func getSchemaPointers(schemaDiff *diff.SchemaDiff) schemaPointers {
+	result := schemaPointers{"": rootPointer}
+	if schemaDiff != nil {
+		result.add("", rootPointer, schemaDiff)
+	}
+	return result
+}
+
+func (pointers schemaPointers) add(propertyPath, pointer string, schemaDiff *diff.SchemaDiff) {
+	// a property can be named like a keyword, e.g. items, making its path
+	// ambiguous; properties are added first and win
+	if _, ok := pointers[propertyPath]; !ok {
+		pointers[propertyPath] = pointer
+	}
+
+	if schemaDiff.PropertiesDiff != nil {
+		for name, v := range schemaDiff.PropertiesDiff.Modified {
+			pointers.add(joinPath(propertyPath, name), pointer+"/properties/"+escapePointer(name), v)
+		}
+	}
+
+	if schemaDiff.AllOfDiff != nil {
+		for _, v := range schemaDiff.AllOfDiff.Modified {
+			pointers.add(joinPath(propertyPath, fmt.Sprintf("allOf[%s]", v)), fmt.Sprintf("%s/allOf/%d", pointer, v.Revision.Index), v.Diff)
+		}
+	}
+
+	if schemaDiff.AnyOfDiff != nil {
+		for _, v := range schemaDiff.AnyOfDiff.Modified {
+			pointers.add(joinPath(propertyPath, fmt.Sprintf("anyOf[%s]", v)), fmt.Sprintf("%s/anyOf/%d", pointer, v.Revision.Index), v.Diff)
+		}
+	}
+
+	if schemaDiff.OneOfDiff != nil {
+		for _, v := range schemaDiff.OneOfDiff.Modified {
+			pointers.add(joinPath(propertyPath, fmt.Sprintf("oneOf[%s]", v)), fmt.Sprintf("%s/oneOf/%d", pointer, v.Revision.Index), v.Diff)
+		}
+	}
+
+	if schemaDiff.ItemsDiff != nil {
+		pointers.add(joinPath(propertyPath, "items"), pointer+"/items", schemaDiff.ItemsDiff)
+	}
+
+	if schemaDiff.AdditionalPropertiesDiff != nil {
+		pointers.add(joinPath(propertyPath, "additionalProperties"), pointer+"/additionalProperties", schemaDiff.AdditionalPropertiesDiff)
+	}
+
+	if schemaDiff.PrefixItemsDiff != nil {
+		for _, v := range schemaDiff.PrefixItemsDiff.Modified {
+			pointers.add(fmt.Sprintf("%s/prefixItems[%s]", propertyPath, v), fmt.Sprintf("%s/prefixItems/%d", pointer, v.Revision.Index), v.Diff)
+		}
+	}
+
+	for keyword, subschemaDiff := range map[string]*diff.SchemaDiff{
+		"contains":              schemaDiff.ContainsDiff,
+		"propertyNames":         schemaDiff.PropertyNamesDiff,
+		"unevaluatedItems":      schemaDiff.UnevaluatedItemsDiff,
+		"unevaluatedProperties": schemaDiff.UnevaluatedPropertiesDiff,
+		"if":                    schemaDiff.IfDiff,
+		"then":                  schemaDiff.ThenDiff,
+		"else":                  schemaDiff.ElseDiff,
+		"not":                   schemaDiff.NotDiff,
+		"contentSchema":         schemaDiff.ContentSchemaDiff,
+	} {
+		if subschemaDiff != nil {
+			pointers.add(propertyPath+"/"+keyword, pointer+"/"+keyword, subschemaDiff)
+		}
+	}
+
+	if schemaDiff.PatternPropertiesDiff != nil {
+		for pattern, v := range schemaDiff.PatternPropertiesDiff.Modified {
+			pointers.add(fmt.Sprintf("%s/patternProperties[%s]", propertyPath, pattern), pointer+"/patternProperties/"+escapePointer(pattern), v)
+		}
+	}
+
+	if schemaDiff.DependentSchemasDiff != nil {
+		for name, v := range schemaDiff.DependentSchemasDiff.Modified {
+			pointers.add(fmt.Sprintf("%s/dependentSchemas[%s]", propertyPath, name), pointer+"/dependentSchemas/"+escapePointer(name), v)
+		}
+	}
+}
+
+// find returns the JSON pointer of the property that a change names, or the
+// root pointer if it names none: a modified property, or a property added to
+// or removed from one.
+func (pointers schemaPointers) find(change ApiChange) string {
+	propertyPath, ok := getChangedProperty(change.Id, change.Args)
+	if !ok {
+		return rootPointer
+	}
+	propertyPath = strings.TrimSuffix(propertyPath, "/")
+
+	if pointer, ok := pointers[propertyPath]; ok {
+		return pointer
+	}
+
+	parent, name := "", propertyPath
+	if i := strings.LastIndex(propertyPath, "/"); i >= 0 {
+		parent, name = propertyPath[:i], propertyPath[i+1:]
+	}
+	if pointer, ok := pointers[parent]; ok {
+		return pointer + "/properties/" + escapePointer(name)
+	}
+	return rootPointer
+}
+
+// escapePointer escapes a reference token of a JSON pointer: https://www.rfc-editor.org/rfc/rfc6901#section-3
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
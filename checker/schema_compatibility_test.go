@@ -0,0 +1,97 @@
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/stretchr/testify/require"
+)
+
+func getSchemaChanges(t *testing.T, asRequest bool) checker.Changes {
+	t.Helper()
+	s1 := loadSchema(t, "../data/json-schema/base.json", asRequest)
+	s2 := loadSchema(t, "../data/json-schema/revision.json", asRequest)
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	return checker.CheckSchemaCompatibility(allChecksConfig(), d, osm, checker.INFO)
+}
+
+func loadSchema(t *testing.T, path string, asRequest bool) *load.SpecInfo {
+	t.Helper()
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	specInfo, err := load.NewSpecInfoFromSchema(loader, load.NewSource(path), asRequest)
+	require.NoError(t, err)
+	return specInfo
+}
+
+func getSchemaChangeById(t *testing.T, changes checker.Changes, id string, pointer string) checker.SchemaChange {
+	t.Helper()
+	for _, change := range changes {
+		if change.GetId() == id && change.GetPath() == pointer {
+			return change.(checker.SchemaChange)
+		}
+	}
+	require.Failf(t, "change not found", "%s at %s", id, pointer)
+	return checker.SchemaChange{}
+}
+
+// CheckSchemaCompatibility applies the request rules to schemas of accepted data
+func TestSchemaCompatibility_Request(t *testing.T) {
+	changes := getSchemaChanges(t, true)
+	require.Len(t, changes, 5)
+
+	change := getSchemaChangeById(t, changes, "request-property-became-required", "#/properties/address/properties/zip")
+	require.Equal(t, checker.ERR, change.GetLevel())
+	require.Equal(t, "schema", change.GetSection())
+	require.Equal(t, "../data/json-schema/revision.json", change.GetSource())
+
+	getSchemaChangeById(t, changes, "request-property-max-decreased", "#/properties/quantity")
+	getSchemaChangeById(t, changes, "request-property-removed", "#/properties/note")
+}
+
+// CheckSchemaCompatibility applies the response rules to schemas of produced data
+func TestSchemaCompatibility_Response(t *testing.T) {
+	changes := getSchemaChanges(t, false)
+	require.Len(t, changes, 3)
+
+	change := getSchemaChangeById(t, changes, "response-optional-property-removed", "#/properties/note")
+	require.Equal(t, checker.WARN, change.GetLevel())
+	require.Equal(t, "warning at ../data/json-schema/revision.json, in schema #/properties/note removed the optional property `note` from the response with the `200` status [response-optional-property-removed]. ", change.SingleLineError(checker.NewDefaultLocalizer(), checker.ColorNever))
+}
+
+func TestSchemaCompatibility_NoChanges(t *testing.T) {
+	s1 := loadSchema(t, "../data/json-schema/base.json", true)
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s1)
+	require.NoError(t, err)
+	require.Empty(t, checker.CheckSchemaCompatibility(allChecksConfig(), d, osm, checker.INFO))
+}
+
+// the enum and pattern rules name the property by their second argument
+func TestSchemaCompatibility_EnumAndPattern(t *testing.T) {
+	s1 := loadSchema(t, "../data/json-schema/base.json", true)
+	s2 := loadSchema(t, "../data/json-schema/base.json", true)
+
+	properties := getWrappedSchema(s1).Properties
+	properties["status"] = &openapi3.SchemaRef{Value: openapi3.NewStringSchema().WithEnum("active", "inactive")}
+	properties["code"] = &openapi3.SchemaRef{Value: openapi3.NewStringSchema()}
+
+	properties = getWrappedSchema(s2).Properties
+	properties["status"] = &openapi3.SchemaRef{Value: openapi3.NewStringSchema().WithEnum("active")}
+	properties["code"] = &openapi3.SchemaRef{Value: openapi3.NewStringSchema().WithPattern("^[a-z]+$")}
+
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	changes := checker.CheckSchemaCompatibility(allChecksConfig(), d, osm, checker.INFO)
+	require.Len(t, changes, 2)
+
+	getSchemaChangeById(t, changes, checker.RequestPropertyEnumValueRemovedId, "#/properties/status")
+	getSchemaChangeById(t, changes, checker.RequestPropertyPatternAddedId, "#/properties/code")
+}
+
+func getWrappedSchema(specInfo *load.SpecInfo) *openapi3.Schema {
+	return specInfo.Spec.Paths.Value(load.SchemaPath).GetOperation(load.SchemaMethod).RequestBody.Value.Content[load.SchemaMediaType].Schema.Value
+}
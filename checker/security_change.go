@@ -24,6 +24,16 @@ type SecurityChange struct {
 	SourceColumnEnd int
 }
 
+// NewSecurityChange creates a new SecurityChange
+func NewSecurityChange(id string, config *Config, args []any, comment string) SecurityChange {
+	return SecurityChange{
+		Id:      id,
+		Level:   config.getLogLevel(id),
+		Args:    args,
+		Comment: comment,
+	}
+}
+
 // WithSources returns a copy of the SecurityChange with BaseSource and RevisionSource populated
 func (c SecurityChange) WithSources(baseSource, revisionSource *Source) SecurityChange {
 	c.BaseSource = baseSource
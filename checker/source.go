@@ -37,14 +37,22 @@ func (s *Source) WithEnd(endLine, endColumn int) *Source {
 	return s
 }
 
+// getOperationFile returns the file that the operation was loaded from, or an empty string when the caller passed no sources
+func getOperationFile(operationsSources *diff.OperationsSourcesMap, operation *openapi3.Operation) string {
+	if operationsSources == nil {
+		return ""
+	}
+	return (*operationsSources)[operation]
+}
+
 func NewSourceFromOrigin(operationsSources *diff.OperationsSourcesMap, operation *openapi3.Operation, origin *openapi3.Origin) *Source {
 	if origin == nil || origin.Key == nil {
-		return &Source{File: (*operationsSources)[operation]}
+		return &Source{File: getOperationFile(operationsSources, operation)}
 	}
 
 	file := displayFilePath(origin.Key.File)
 	if file == "" {
-		file = (*operationsSources)[operation]
+		file = getOperationFile(operationsSources, operation)
 	}
 
 	return &Source{
@@ -64,7 +72,7 @@ func NewSourceFromField(operationsSources *diff.OperationsSourcesMap, operation
 	if location, ok := origin.Fields.Lookup(field); ok {
 		file := displayFilePath(location.File)
 		if file == "" {
-			file = (*operationsSources)[operation]
+			file = getOperationFile(operationsSources, operation)
 		}
 		return &Source{
 			File:      file,
@@ -115,7 +123,7 @@ func NewSourceFromSequenceItem(operationsSources *diff.OperationsSourcesMap, ope
 		if item.Name == value {
 			file := displayFilePath(item.File)
 			if file == "" {
-				file = (*operationsSources)[operation]
+				file = getOperationFile(operationsSources, operation)
 			}
 			return &Source{
 				File:      file,
@@ -366,7 +374,7 @@ func subschemaSource(operationsSources *diff.OperationsSourcesMap, operation *op
 
 	file := displayFilePath(origin.Key.File)
 	if file == "" {
-		file = (*operationsSources)[operation]
+		file = getOperationFile(operationsSources, operation)
 	}
 
 	return &Source{
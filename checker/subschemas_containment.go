@@ -0,0 +1,41 @@
+package checker
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/diff"
+)
+
+// getUncontainedSubschemas returns the added or deleted subschemas whose values may not be accepted by the other side of the change.
+// The indices of subschemas refer to from, and to are the subschemas on the other side.
+// For example, a subschema removed from a request's anyOf that is contained in a remaining subschema doesn't break clients.
+// With oneOf, a value must match exactly one subschema, so the subschema must also be disjoint from the other subschemas of to.
+func getUncontainedSubschemas(subschemas diff.Subschemas, from, to openapi3.SchemaRefs, oneOf bool) diff.Subschemas {
+	result := diff.Subschemas{}
+	for _, subschema := range subschemas {
+		if subschema.Index < 0 || subschema.Index >= len(from) || !isContainedSubschema(from[subschema.Index], to, oneOf) {
+			result = append(result, subschema)
+		}
+	}
+	return result
+}
+
+func isContainedSubschema(schemaRef *openapi3.SchemaRef, to openapi3.SchemaRefs, oneOf bool) bool {
+	for i, container := range to {
+		if !diff.IsSubschema(schemaRef, container) {
+			continue
+		}
+		if !oneOf || isDisjointFromOthers(schemaRef, to, i) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDisjointFromOthers(schemaRef *openapi3.SchemaRef, schemaRefs openapi3.SchemaRefs, index int) bool {
+	for i, other := range schemaRefs {
+		if i != index && !diff.IsDisjoint(schemaRef, other) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,159 @@
+package checker
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/oasdiff/oasdiff/diff"
+)
+
+// TrafficRecord is the number of calls observed to an endpoint. Path is either
+// templated, like /users/{id}, or a request path, like /users/42.
+type TrafficRecord struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Count  int64  `json:"count"`
+}
+
+// Traffic is the observed usage of an API, e.g. exported from access logs or metrics
+type Traffic []TrafficRecord
+
+// TrafficThresholds adjust the level of breaking changes by the traffic of their
+// endpoint: changes to endpoints called fewer than DemoteBelow times are demoted
+// one level, and those to endpoints called at least EscalateAt times are
+// escalated to ERR. A zero threshold is disabled.
+type TrafficThresholds struct {
+	DemoteBelow int64
+	EscalateAt  int64
+}
+
+// LoadTraffic reads traffic from a JSON file, a list of records, or from a CSV
+// file with a method, a path and a count column, in this order unless the first
+// row names them.
+func LoadTraffic(file string) (Traffic, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var traffic Traffic
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &traffic); err != nil {
+			return nil, err
+		}
+	} else if traffic, err = parseTrafficCSV(data); err != nil {
+		return nil, err
+	}
+
+	for i, record := range traffic {
+		if record.Method == "" || record.Path == "" {
+			return nil, fmt.Errorf("record %d: a record must have a method and a path", i+1)
+		}
+		if record.Count < 0 {
+			return nil, fmt.Errorf("record %d: invalid count %d", i+1, record.Count)
+		}
+	}
+	return traffic, nil
+}
+
+func parseTrafficCSV(data []byte) (Traffic, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return Traffic{}, nil
+	}
+
+	method, path, count := 0, 1, 2
+	if header := rows[0]; columnIndex(header, "method") >= 0 {
+		method, path, count = columnIndex(header, "method"), columnIndex(header, "path"), columnIndex(header, "count")
+		if path < 0 || count < 0 {
+			return nil, errors.New("the header must name the method, path and count columns")
+		}
+		rows = rows[1:]
+	}
+
+	traffic := make(Traffic, len(rows))
+	for i, row := range rows {
+		if len(row) <= max(method, path, count) {
+			return nil, fmt.Errorf("row %d: expected a method, a path and a count", i+1)
+		}
+		n, err := strconv.ParseInt(row[count], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid count %q", i+1, row[count])
+		}
+		traffic[i] = TrafficRecord{Method: row[method], Path: row[path], Count: n}
+	}
+	return traffic, nil
+}
+
+func columnIndex(header []string, name string) int {
+	for i, column := range header {
+		if strings.EqualFold(strings.TrimSpace(column), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Count returns the number of calls to the endpoint. A templated record matches
+// the endpoint's path ignoring the names of the path params, and a request
+// path matches it with any value in place of a path param.
+func (traffic Traffic) Count(method, path string) int64 {
+	var count int64
+	for _, record := range traffic {
+		if !strings.EqualFold(record.Method, method) {
+			continue
+		}
+		if _, ok := diff.MatchPathParams(record.Path, path); ok || matchPathTemplate(path, record.Path, false) {
+			count += record.Count
+		}
+	}
+	return count
+}
+
+// ApplyTraffic sets the usage count of the changes to endpoints (see
+// Change.GetUsageCount) and adjusts the level of the breaking ones by the
+// thresholds. Other changes are returned as is. The result is sorted again,
+// since levels may have changed.
+func ApplyTraffic(changes Changes, traffic Traffic, thresholds TrafficThresholds) Changes {
+	result := make(Changes, len(changes))
+	for i, change := range changes {
+		apiChange, ok := change.(ApiChange)
+		if !ok {
+			result[i] = change
+			continue
+		}
+
+		count := traffic.Count(apiChange.Operation, apiChange.Path)
+		apiChange.UsageCount = &count
+		apiChange.Level = thresholds.adjust(apiChange.Level, count)
+		result[i] = apiChange
+	}
+	slices.SortFunc(result, CompareChanges)
+	return result
+}
+
+func (thresholds TrafficThresholds) adjust(level Level, count int64) Level {
+	if !level.IsBreaking() {
+		return level
+	}
+	if thresholds.EscalateAt > 0 && count >= thresholds.EscalateAt {
+		return ERR
+	}
+	if thresholds.DemoteBelow > 0 && count < thresholds.DemoteBelow {
+		return level - 1
+	}
+	return level
+}
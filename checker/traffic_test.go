@@ -0,0 +1,94 @@
+package checker_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/stretchr/testify/require"
+)
+
+var testTraffic = checker.Traffic{
+	{Method: "GET", Path: "/users", Count: 1200},
+	{Method: "POST", Path: "/users", Count: 35},
+	{Method: "GET", Path: "/users/42", Count: 3},
+	{Method: "GET", Path: "/users/{id}", Count: 4},
+}
+
+func TestLoadTraffic_CSV(t *testing.T) {
+	traffic, err := checker.LoadTraffic("../data/traffic/traffic.csv")
+	require.NoError(t, err)
+	require.Equal(t, testTraffic, traffic)
+}
+
+func TestLoadTraffic_JSON(t *testing.T) {
+	traffic, err := checker.LoadTraffic("../data/traffic/traffic.json")
+	require.NoError(t, err)
+	require.Equal(t, testTraffic, traffic)
+}
+
+func TestLoadTraffic_CSVColumns(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traffic.csv")
+	require.NoError(t, os.WriteFile(file, []byte("count,path,method\n7,/users,GET\n"), 0644))
+
+	traffic, err := checker.LoadTraffic(file)
+	require.NoError(t, err)
+	require.Equal(t, checker.Traffic{{Method: "GET", Path: "/users", Count: 7}}, traffic)
+}
+
+func TestLoadTraffic_CSVNoHeader(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traffic.csv")
+	require.NoError(t, os.WriteFile(file, []byte("GET,/users,7\n"), 0644))
+
+	traffic, err := checker.LoadTraffic(file)
+	require.NoError(t, err)
+	require.Equal(t, checker.Traffic{{Method: "GET", Path: "/users", Count: 7}}, traffic)
+}
+
+func TestLoadTraffic_InvalidCount(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traffic.csv")
+	require.NoError(t, os.WriteFile(file, []byte("GET,/users,many\n"), 0644))
+
+	_, err := checker.LoadTraffic(file)
+	require.EqualError(t, err, `row 1: invalid count "many"`)
+}
+
+func TestTrafficCount(t *testing.T) {
+	require.Equal(t, int64(1200), testTraffic.Count("GET", "/users"))
+	require.Equal(t, int64(7), testTraffic.Count("get", "/users/{userId}"))
+	require.Equal(t, int64(0), testTraffic.Count("DELETE", "/users/{userId}"))
+	require.Equal(t, int64(0), testTraffic.Count("GET", "/users/{userId}/roles"))
+}
+
+func TestApplyTraffic(t *testing.T) {
+	hot := checker.ApiChange{Id: checker.ResponseOptionalPropertyRemovedId, Level: checker.WARN, Operation: "GET", Path: "/users"}
+	cold := checker.ApiChange{Id: checker.APIRemovedWithoutDeprecationId, Level: checker.ERR, Operation: "DELETE", Path: "/users/{userId}"}
+	info := checker.ApiChange{Id: checker.EndpointAddedId, Level: checker.INFO, Operation: "PUT", Path: "/users/{userId}"}
+	security := checker.SecurityChange{Id: checker.APIGlobalSecurityAddedCheckId, Level: checker.INFO}
+
+	result := checker.ApplyTraffic(checker.Changes{cold, info, security, hot}, testTraffic, checker.TrafficThresholds{DemoteBelow: 1, EscalateAt: 1000})
+	require.Len(t, result, 4)
+
+	require.Equal(t, checker.ResponseOptionalPropertyRemovedId, result[0].GetId())
+	require.Equal(t, checker.ERR, result[0].GetLevel())
+	require.Equal(t, int64(1200), *checker.GetUsageCount(result[0]))
+
+	require.Equal(t, checker.APIRemovedWithoutDeprecationId, result[1].GetId())
+	require.Equal(t, checker.WARN, result[1].GetLevel())
+	require.Equal(t, int64(0), *checker.GetUsageCount(result[1]))
+
+	require.Equal(t, checker.APIGlobalSecurityAddedCheckId, result[2].GetId())
+	require.Nil(t, checker.GetUsageCount(result[2]))
+
+	require.Equal(t, checker.EndpointAddedId, result[3].GetId())
+	require.Equal(t, checker.INFO, result[3].GetLevel())
+}
+
+func TestApplyTraffic_Disabled(t *testing.T) {
+	cold := checker.ApiChange{Id: checker.APIRemovedWithoutDeprecationId, Level: checker.ERR, Operation: "DELETE", Path: "/users/{userId}"}
+
+	result := checker.ApplyTraffic(checker.Changes{cold}, testTraffic, checker.TrafficThresholds{})
+	require.Equal(t, checker.ERR, result[0].GetLevel())
+	require.Equal(t, int64(0), *checker.GetUsageCount(result[0]))
+}
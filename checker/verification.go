@@ -0,0 +1,26 @@
+package checker
+
+// Verification is the outcome of looking for a counterexample to a breaking
+// change: a payload that the base spec accepts and the revision rejects, for a
+// request, or that the revision allows and the base rejects, for a response.
+// A breaking change without a counterexample is possibly a false positive.
+type Verification struct {
+	// Confirmed is true when a counterexample was found
+	Confirmed bool `json:"confirmed" yaml:"confirmed"`
+	// In describes the payload, e.g. request body application/json or query parameter limit
+	In string `json:"in,omitempty" yaml:"in,omitempty"`
+	// Counterexample is the JSON encoding of the payload
+	Counterexample string `json:"counterexample,omitempty" yaml:"counterexample,omitempty"`
+	// Reason is why the counterexample is rejected
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// WithVerification returns a copy of the change with its verification set.
+// Only changes to operations can be verified; others are returned as is.
+func WithVerification(change Change, verification *Verification) Change {
+	if c, ok := change.(ApiChange); ok {
+		c.Verification = verification
+		return c
+	}
+	return change
+}
@@ -0,0 +1,109 @@
+package checker
+
+import (
+	"github.com/oasdiff/oasdiff/diff"
+)
+
+// MediaTypeInfo is a modified request or response body media type, as delivered by WalkModifiedRequestBodySchemas
+// and WalkModifiedResponseSchemas to checks written outside this package.
+// It wraps the plumbing that the built-in checks use, see media_type_walker.go.
+type MediaTypeInfo struct {
+	info mediaTypeInfo
+}
+
+// Path returns the path of the operation
+func (info MediaTypeInfo) Path() string {
+	return info.info.path
+}
+
+// Method returns the method of the operation
+func (info MediaTypeInfo) Method() string {
+	return info.info.method
+}
+
+// ResponseStatus returns the status code of the response, or an empty string for a request body
+func (info MediaTypeInfo) ResponseStatus() string {
+	return info.info.responseStatus
+}
+
+// OperationDiff returns the diff of the operation
+func (info MediaTypeInfo) OperationDiff() *diff.MethodDiff {
+	return info.info.operationItem
+}
+
+// MediaType returns the media type
+func (info MediaTypeInfo) MediaType() string {
+	return info.info.mediaType
+}
+
+// SchemaDiff returns the diff of the media type's schema, which exists in both the base and the revision
+func (info MediaTypeInfo) SchemaDiff() *diff.SchemaDiff {
+	return info.info.schemaDiff
+}
+
+// NewChange returns a change of the operation, with the media type in its details.
+// Set the change's sources with WithSources, typically from SchemaSources or SchemaFieldSources.
+func (info MediaTypeInfo) NewChange(id string, args []any, comment string) ApiChange {
+	return info.info.newChange(id, args, comment)
+}
+
+// WalkProperties calls processor for every modified property of the media type's schema, at any depth
+func (info MediaTypeInfo) WalkProperties(processor func(p PropertyInfo)) {
+	info.info.walkProperties(func(p propertyInfo) {
+		processor(PropertyInfo{p: p})
+	})
+}
+
+// PropertyInfo is a modified property, as delivered by MediaTypeInfo.WalkProperties
+type PropertyInfo struct {
+	p propertyInfo
+}
+
+// MediaTypeInfo returns the media type that the property belongs to
+func (p PropertyInfo) MediaTypeInfo() MediaTypeInfo {
+	return MediaTypeInfo{info: p.p.mediaTypeInfo}
+}
+
+// PropertyPath returns the path of the property's parent, like "items/address", or an empty string for a top-level property
+func (p PropertyInfo) PropertyPath() string {
+	return p.p.propertyPath
+}
+
+// PropertyName returns the name of the property
+func (p PropertyInfo) PropertyName() string {
+	return p.p.propertyName
+}
+
+// FullName returns the path and the name of the property, as the built-in checks report it
+func (p PropertyInfo) FullName() string {
+	return propertyFullName(p.p.propertyPath, p.p.propertyName)
+}
+
+// SchemaDiff returns the diff of the property's schema, which exists in both the base and the revision
+func (p PropertyInfo) SchemaDiff() *diff.SchemaDiff {
+	return p.p.propertyDiff
+}
+
+// ParentDiff returns the diff of the schema that contains the property
+func (p PropertyInfo) ParentDiff() *diff.SchemaDiff {
+	return p.p.parent
+}
+
+// NewChange returns a change of the operation, with the media type in its details
+func (p PropertyInfo) NewChange(id string, args []any, comment string) ApiChange {
+	return p.p.newChange(id, args, comment)
+}
+
+// WalkModifiedRequestBodySchemas calls processor for every request body media type whose schema was modified
+func WalkModifiedRequestBodySchemas(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config, processor func(info MediaTypeInfo)) {
+	walkModifiedRequestBodySchemas(diffReport, operationsSources, config, func(info mediaTypeInfo) {
+		processor(MediaTypeInfo{info: info})
+	})
+}
+
+// WalkModifiedResponseSchemas calls processor for every response media type whose schema was modified
+func WalkModifiedResponseSchemas(diffReport *diff.Diff, operationsSources *diff.OperationsSourcesMap, config *Config, processor func(info MediaTypeInfo)) {
+	walkModifiedResponseSchemas(diffReport, operationsSources, config, func(info mediaTypeInfo) {
+		processor(MediaTypeInfo{info: info})
+	})
+}
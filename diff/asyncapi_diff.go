@@ -0,0 +1,392 @@
+package diff
+
+import (
+	"errors"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/oasdiff/oasdiff/asyncapi"
+	"github.com/oasdiff/oasdiff/load"
+)
+
+// AsyncAPIDiff describes the changes between a pair of AsyncAPI documents
+type AsyncAPIDiff struct {
+	AsyncAPIDiff *ValueDiff         `json:"asyncAPI,omitempty" yaml:"asyncAPI,omitempty"`
+	ChannelsDiff *ChannelsDiff      `json:"channels,omitempty" yaml:"channels,omitempty"`
+	Base         *asyncapi.Document `json:"-" yaml:"-"`
+	Revision     *asyncapi.Document `json:"-" yaml:"-"`
+}
+
+// Empty indicates whether a change was found in this element
+func (asyncAPIDiff *AsyncAPIDiff) Empty() bool {
+	if asyncAPIDiff == nil {
+		return true
+	}
+
+	return asyncAPIDiff.AsyncAPIDiff.Empty() &&
+		asyncAPIDiff.ChannelsDiff.Empty()
+}
+
+// ChannelsDiff describes the changes between a pair of sets of AsyncAPI channels, keyed by address
+type ChannelsDiff struct {
+	Added    []string          `json:"added,omitempty" yaml:"added,omitempty"`
+	Deleted  []string          `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+	Modified ModifiedChannels  `json:"modified,omitempty" yaml:"modified,omitempty"`
+	Base     asyncapi.Channels `json:"-" yaml:"-"`
+	Revision asyncapi.Channels `json:"-" yaml:"-"`
+}
+
+// ModifiedChannels is a map of channel addresses to their respective diffs
+type ModifiedChannels map[string]*ChannelDiff
+
+// Empty indicates whether a change was found in this element
+func (channelsDiff *ChannelsDiff) Empty() bool {
+	if channelsDiff == nil {
+		return true
+	}
+
+	return len(channelsDiff.Added) == 0 &&
+		len(channelsDiff.Deleted) == 0 &&
+		len(channelsDiff.Modified) == 0
+}
+
+// ChannelDiff describes the changes between a pair of AsyncAPI channels
+type ChannelDiff struct {
+	OperationsDiff *ChannelOperationsDiff `json:"operations,omitempty" yaml:"operations,omitempty"`
+	Base           *asyncapi.Channel      `json:"-" yaml:"-"`
+	Revision       *asyncapi.Channel      `json:"-" yaml:"-"`
+}
+
+// Empty indicates whether a change was found in this element
+func (channelDiff *ChannelDiff) Empty() bool {
+	return channelDiff == nil || channelDiff.OperationsDiff.Empty()
+}
+
+// ChannelOperationsDiff describes the changes between a pair of sets of operations on a channel, keyed by action
+type ChannelOperationsDiff struct {
+	Added    []string                  `json:"added,omitempty" yaml:"added,omitempty"`
+	Deleted  []string                  `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+	Modified ModifiedChannelOperations `json:"modified,omitempty" yaml:"modified,omitempty"`
+}
+
+// ModifiedChannelOperations is a map of the actions of the revision to the diffs of their operations
+type ModifiedChannelOperations map[string]*ChannelOperationDiff
+
+// Empty indicates whether a change was found in this element
+func (operationsDiff *ChannelOperationsDiff) Empty() bool {
+	if operationsDiff == nil {
+		return true
+	}
+
+	return len(operationsDiff.Added) == 0 &&
+		len(operationsDiff.Deleted) == 0 &&
+		len(operationsDiff.Modified) == 0
+}
+
+// ChannelOperationDiff describes the changes between a pair of operations on a channel
+type ChannelOperationDiff struct {
+	OperationIDDiff *ValueDiff          `json:"operationID,omitempty" yaml:"operationID,omitempty"`
+	MessagesDiff    *MessagesDiff       `json:"messages,omitempty" yaml:"messages,omitempty"`
+	Base            *asyncapi.Operation `json:"-" yaml:"-"`
+	Revision        *asyncapi.Operation `json:"-" yaml:"-"`
+}
+
+// Empty indicates whether a change was found in this element
+func (operationDiff *ChannelOperationDiff) Empty() bool {
+	if operationDiff == nil {
+		return true
+	}
+
+	return operationDiff.OperationIDDiff.Empty() &&
+		operationDiff.MessagesDiff.Empty()
+}
+
+// MessagesDiff describes the changes between a pair of sets of messages, keyed by name
+type MessagesDiff struct {
+	Added    []string         `json:"added,omitempty" yaml:"added,omitempty"`
+	Deleted  []string         `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+	Modified ModifiedMessages `json:"modified,omitempty" yaml:"modified,omitempty"`
+}
+
+// ModifiedMessages is a map of message names to their respective diffs
+type ModifiedMessages map[string]*MessageDiff
+
+// Empty indicates whether a change was found in this element
+func (messagesDiff *MessagesDiff) Empty() bool {
+	if messagesDiff == nil {
+		return true
+	}
+
+	return len(messagesDiff.Added) == 0 &&
+		len(messagesDiff.Deleted) == 0 &&
+		len(messagesDiff.Modified) == 0
+}
+
+// MessageDiff describes the changes between a pair of messages
+type MessageDiff struct {
+	ContentTypeDiff *ValueDiff        `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	PayloadDiff     *SchemaDiff       `json:"payload,omitempty" yaml:"payload,omitempty"`
+	HeadersDiff     *SchemaDiff       `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Base            *asyncapi.Message `json:"-" yaml:"-"`
+	Revision        *asyncapi.Message `json:"-" yaml:"-"`
+}
+
+// Empty indicates whether a change was found in this element
+func (messageDiff *MessageDiff) Empty() bool {
+	if messageDiff == nil {
+		return true
+	}
+
+	return messageDiff.ContentTypeDiff.Empty() &&
+		messageDiff.PayloadDiff.Empty() &&
+		messageDiff.HeadersDiff.Empty()
+}
+
+/*
+GetAsyncAPIDiff calculates the diff between a pair of AsyncAPI documents.
+
+Payload and header schemas are compared by SchemaDiff, as the schemas of a
+request for the messages an operation receives and of a response for those it
+sends (see asyncapi.Operation.IsRequest). MatchPath and UnmatchPath filter the
+channels by address.
+*/
+func GetAsyncAPIDiff(config *Config, d1, d2 *asyncapi.Document) (*AsyncAPIDiff, error) {
+	if d1 == nil || d2 == nil {
+		return nil, errors.New("document is nil")
+	}
+
+	result := &AsyncAPIDiff{
+		AsyncAPIDiff: getValueDiff(d1.AsyncAPI, d2.AsyncAPI),
+		Base:         d1,
+		Revision:     d2,
+	}
+
+	channels1, err := filterChannels(config, d1.Channels)
+	if err != nil {
+		return nil, err
+	}
+	channels2, err := filterChannels(config, d2.Channels)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.ChannelsDiff, err = getChannelsDiff(config, newState(), channels1, channels2); err != nil {
+		return nil, err
+	}
+
+	if result.Empty() {
+		return nil, nil
+	}
+
+	return result, nil
+}
+
+// filterChannels returns the channels whose address matches MatchPath and not UnmatchPath
+func filterChannels(config *Config, channels asyncapi.Channels) (asyncapi.Channels, error) {
+	result := maps.Clone(channels)
+
+	for _, filter := range []struct {
+		pattern        string
+		deleteMatching bool
+	}{{config.MatchPath, false}, {config.UnmatchPath, true}} {
+		if filter.pattern == "" {
+			continue
+		}
+		r, err := regexp.Compile(filter.pattern)
+		if err != nil {
+			return nil, err
+		}
+		maps.DeleteFunc(result, func(address string, _ *asyncapi.Channel) bool {
+			return r.MatchString(address) == filter.deleteMatching
+		})
+	}
+	return result, nil
+}
+
+func getChannelsDiff(config *Config, state *state, channels1, channels2 asyncapi.Channels) (*ChannelsDiff, error) {
+	result := &ChannelsDiff{
+		Added:    []string{},
+		Deleted:  []string{},
+		Modified: ModifiedChannels{},
+		Base:     channels1,
+		Revision: channels2,
+	}
+
+	for _, address := range slices.Sorted(maps.Keys(channels1)) {
+		channel2, ok := channels2[address]
+		if !ok {
+			result.Deleted = append(result.Deleted, address)
+			continue
+		}
+		channelDiff, err := getChannelDiff(config, state, channels1[address], channel2)
+		if err != nil {
+			return nil, err
+		}
+		if !channelDiff.Empty() {
+			result.Modified[address] = channelDiff
+		}
+	}
+
+	for _, address := range slices.Sorted(maps.Keys(channels2)) {
+		if _, ok := channels1[address]; !ok {
+			result.Added = append(result.Added, address)
+		}
+	}
+
+	if result.Empty() {
+		return nil, nil
+	}
+	return result, nil
+}
+
+func getChannelDiff(config *Config, state *state, channel1, channel2 *asyncapi.Channel) (*ChannelDiff, error) {
+	result := &ChannelDiff{
+		OperationsDiff: &ChannelOperationsDiff{
+			Added:    []string{},
+			Deleted:  []string{},
+			Modified: ModifiedChannelOperations{},
+		},
+		Base:     channel1,
+		Revision: channel2,
+	}
+
+	for _, action := range slices.Sorted(maps.Keys(channel1.Operations)) {
+		if _, ok := channel2.Operations.Find(action); !ok {
+			result.OperationsDiff.Deleted = append(result.OperationsDiff.Deleted, action)
+		}
+	}
+
+	for _, action := range slices.Sorted(maps.Keys(channel2.Operations)) {
+		operation1, ok := channel1.Operations.Find(action)
+		if !ok {
+			result.OperationsDiff.Added = append(result.OperationsDiff.Added, action)
+			continue
+		}
+		operationDiff, err := getChannelOperationDiff(config, state, operation1, channel2.Operations[action])
+		if err != nil {
+			return nil, err
+		}
+		if !operationDiff.Empty() {
+			result.OperationsDiff.Modified[action] = operationDiff
+		}
+	}
+
+	return result, nil
+}
+
+func getChannelOperationDiff(config *Config, state *state, operation1, operation2 *asyncapi.Operation) (*ChannelOperationDiff, error) {
+	result := &ChannelOperationDiff{
+		OperationIDDiff: getValueDiff(operation1.OpenAPI.OperationID, operation2.OpenAPI.OperationID),
+		Base:            operation1,
+		Revision:        operation2,
+	}
+
+	defer state.setDirection(state.direction)
+	if operation2.IsRequest() {
+		state.setDirection(directionRequest)
+	} else {
+		state.setDirection(directionResponse)
+	}
+
+	var err error
+	if result.MessagesDiff, err = getMessagesDiff(config, state, operation1.Messages, operation2.Messages); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func getMessagesDiff(config *Config, state *state, messages1, messages2 asyncapi.Messages) (*MessagesDiff, error) {
+	result := &MessagesDiff{
+		Added:    []string{},
+		Deleted:  []string{},
+		Modified: ModifiedMessages{},
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(messages1)) {
+		message2, ok := messages2[name]
+		if !ok {
+			result.Deleted = append(result.Deleted, name)
+			continue
+		}
+		messageDiff, err := getMessageDiff(config, state, messages1[name], message2)
+		if err != nil {
+			return nil, err
+		}
+		if !messageDiff.Empty() {
+			result.Modified[name] = messageDiff
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(messages2)) {
+		if _, ok := messages1[name]; !ok {
+			result.Added = append(result.Added, name)
+		}
+	}
+
+	if result.Empty() {
+		return nil, nil
+	}
+	return result, nil
+}
+
+func getMessageDiff(config *Config, state *state, message1, message2 *asyncapi.Message) (*MessageDiff, error) {
+	result := &MessageDiff{
+		ContentTypeDiff: getValueDiff(message1.ContentType, message2.ContentType),
+		Base:            message1,
+		Revision:        message2,
+	}
+
+	var err error
+	if result.PayloadDiff, err = getSchemaDiff(config, state, message1.Payload, message2.Payload); err != nil {
+		return nil, err
+	}
+	if result.HeadersDiff, err = getSchemaDiff(config, state, message1.Headers, message2.Headers); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// withAsyncAPIDiff adds the diff of a pair of AsyncAPI documents to diff, whose
+// specs then only hold the documents' info. Comparing an AsyncAPI document
+// with an OpenAPI spec is an error.
+func withAsyncAPIDiff(config *Config, diff *Diff, s1, s2 *load.SpecInfo) (*Diff, error) {
+	if s1.AsyncAPI == nil && s2.AsyncAPI == nil {
+		return diff, nil
+	}
+	if s1.AsyncAPI == nil || s2.AsyncAPI == nil {
+		return nil, errors.New("can't compare an AsyncAPI document with an OpenAPI spec")
+	}
+
+	asyncAPIDiff, err := GetAsyncAPIDiff(config, s1.AsyncAPI, s2.AsyncAPI)
+	if err != nil || asyncAPIDiff == nil {
+		return diff, err
+	}
+
+	if diff == nil {
+		diff = newDiff()
+		diff.BaseInfo, diff.RevisionInfo = s1.Spec.Info, s2.Spec.Info
+	}
+	diff.AsyncAPIDiff = asyncAPIDiff
+	return diff, nil
+}
+
+// addAsyncAPISources adds the operations of an AsyncAPI document to the sources map
+func addAsyncAPISources(operationsSources OperationsSourcesMap, specInfo *load.SpecInfo) {
+	if specInfo.AsyncAPI == nil {
+		return
+	}
+	for _, channel := range specInfo.AsyncAPI.Channels {
+		for _, operation := range channel.Operations {
+			operationsSources[operation.OpenAPI] = specInfo.Url
+		}
+	}
+}
+
+func (channelsDiff *ChannelsDiff) getSummary() *SummaryDetails {
+	return &SummaryDetails{
+		Added:    len(channelsDiff.Added),
+		Deleted:  len(channelsDiff.Deleted),
+		Modified: len(channelsDiff.Modified),
+	}
+}
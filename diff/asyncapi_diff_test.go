@@ -0,0 +1,77 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/stretchr/testify/require"
+)
+
+func loadAsyncAPI(t *testing.T, file string) *load.SpecInfo {
+	t.Helper()
+	specInfo, err := load.NewSpecInfo(openapi3.NewLoader(), load.NewSource(file))
+	require.NoError(t, err)
+	require.NotNil(t, specInfo.AsyncAPI)
+	return specInfo
+}
+
+func TestAsyncAPIDiff_V2(t *testing.T) {
+	s1 := loadAsyncAPI(t, "../data/asyncapi/base-v2.yaml")
+	s2 := loadAsyncAPI(t, "../data/asyncapi/revision-v2.yaml")
+
+	d, operationsSources, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+
+	channelsDiff := d.AsyncAPIDiff.ChannelsDiff
+	require.Equal(t, []string{"user/deleted"}, channelsDiff.Deleted)
+	require.Empty(t, channelsDiff.Added)
+
+	signUp := channelsDiff.Modified["user/signup"].OperationsDiff.Modified["publish"].MessagesDiff.Modified["SignUp"]
+	require.Equal(t, []string{"age"}, signUp.PayloadDiff.RequiredDiff.Added)
+
+	messagesDiff := channelsDiff.Modified["user/signedup"].OperationsDiff.Modified["subscribe"].MessagesDiff
+	require.Equal(t, []string{"UserInvited"}, messagesDiff.Added)
+	require.Equal(t, []string{"email"}, messagesDiff.Modified["UserSignedUp"].PayloadDiff.PropertiesDiff.Deleted)
+
+	require.Equal(t, "../data/asyncapi/base-v2.yaml", (*operationsSources)[s1.AsyncAPI.Channels["user/deleted"].Operations["subscribe"].OpenAPI])
+
+	summary := d.GetSummary()
+	require.Equal(t, diff.SummaryDetails{Deleted: 1, Modified: 2}, summary.GetSummaryDetails(diff.ChannelsDetail))
+}
+
+func TestAsyncAPIDiff_V2ToV3(t *testing.T) {
+	s1 := loadAsyncAPI(t, "../data/asyncapi/base-v2.yaml")
+	s2 := loadAsyncAPI(t, "../data/asyncapi/base-v3.yaml")
+
+	d, _, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+
+	// publish matches receive and subscribe matches send
+	require.Equal(t, &diff.ValueDiff{From: "2.6.0", To: "3.0.0"}, d.AsyncAPIDiff.AsyncAPIDiff)
+	require.Nil(t, d.AsyncAPIDiff.ChannelsDiff)
+}
+
+func TestAsyncAPIDiff_MatchPath(t *testing.T) {
+	s1 := loadAsyncAPI(t, "../data/asyncapi/base-v3.yaml")
+	s2 := loadAsyncAPI(t, "../data/asyncapi/revision-v3.yaml")
+
+	d, _, err := diff.GetWithOperationsSourcesMap(&diff.Config{MatchPath: "signup$"}, s1, s2)
+	require.NoError(t, err)
+	require.Len(t, d.AsyncAPIDiff.ChannelsDiff.Modified, 1)
+	require.Contains(t, d.AsyncAPIDiff.ChannelsDiff.Modified, "user/signup")
+
+	d, _, err = diff.GetWithOperationsSourcesMap(&diff.Config{UnmatchPath: "user/"}, s1, s2)
+	require.NoError(t, err)
+	require.Nil(t, d.AsyncAPIDiff)
+}
+
+func TestAsyncAPIDiff_OpenAPI(t *testing.T) {
+	s1 := loadAsyncAPI(t, "../data/asyncapi/base-v3.yaml")
+	s2, err := load.NewSpecInfo(openapi3.NewLoader(), load.NewSource("../data/simple.yaml"))
+	require.NoError(t, err)
+
+	_, _, err = diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.EqualError(t, err, "can't compare an AsyncAPI document with an OpenAPI spec")
+}
@@ -119,4 +119,8 @@ const (
 	SunsetExtension          = "x-sunset"
 	XStabilityLevelExtension = "x-stability-level"
 	XExtensibleEnumExtension = "x-extensible-enum"
+	// XDeprecatedExtension deprecates objects that have no deprecated field, like responses and media types
+	XDeprecatedExtension = "x-deprecated"
+	// XDeprecatedScopesExtension maps the deprecated scopes of an OAuth flow to their sunset dates
+	XDeprecatedScopesExtension = "x-deprecated-scopes"
 )
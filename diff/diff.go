@@ -31,6 +31,7 @@ type Diff struct {
 	ExternalDocsDiff      *ExternalDocsDiff         `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
 	ComponentsDiff        *ComponentsDiff           `json:"components,omitempty" yaml:"components,omitempty"`
 	JSONSchemaDialectDiff *ValueDiff                `json:"jsonSchemaDialect,omitempty" yaml:"jsonSchemaDialect,omitempty"`
+	AsyncAPIDiff          *AsyncAPIDiff             `json:"asyncAPI,omitempty" yaml:"asyncAPI,omitempty"`
 
 	// BaseInfo and RevisionInfo are the info object from each spec, carried as
 	// context for checkers that judge it against the changes (see the
@@ -47,6 +48,15 @@ type Diff struct {
 	// either. Excluded from output because they are context, not a change.
 	BaseInfo     *openapi3.Info `json:"-" yaml:"-"`
 	RevisionInfo *openapi3.Info `json:"-" yaml:"-"`
+
+	// RevisionPaths is the paths of the revision, filtered and with their
+	// prefixes rewritten like those of PathsDiff, carried as context for
+	// checkers that judge the revision as a whole, like the sunset-date-passed
+	// checks: an endpoint whose sunset has passed is reported whether or not it
+	// changed. PathsDiff.Revision holds the same paths, but only when some path
+	// changed. Set only on a non-empty diff, like BaseInfo and RevisionInfo;
+	// GetRevisionContext returns them for identical specs.
+	RevisionPaths *openapi3.Paths `json:"-" yaml:"-"`
 }
 
 // OperationsSourcesMap maps OpenAPI operations to their source file paths
@@ -89,6 +99,10 @@ func GetWithOperationsSourcesMap(config *Config, s1, s2 *load.SpecInfo) (*Diff,
 		return nil, nil, err
 	}
 
+	if diff, err = withAsyncAPIDiff(config, diff, s1, s2); err != nil {
+		return nil, nil, err
+	}
+
 	_, operationsSources1, err := mergedPaths([]*load.SpecInfo{s1}, config.IncludePathParams)
 	if err != nil {
 		return nil, nil, err
@@ -100,6 +114,8 @@ func GetWithOperationsSourcesMap(config *Config, s1, s2 *load.SpecInfo) (*Diff,
 
 	operationsSources := *operationsSources1
 	maps.Copy(operationsSources, *operationsSources2)
+	addAsyncAPISources(operationsSources, s1)
+	addAsyncAPISources(operationsSources, s2)
 
 	return diff, &operationsSources, nil
 }
@@ -274,10 +290,50 @@ func getDiff(config *Config, state *state, s1, s2 *openapi3.T) (*Diff, error) {
 	}
 
 	diff.BaseInfo, diff.RevisionInfo = s1.Info, s2.Info
+	if diff.RevisionPaths, err = getRevisionPaths(config, diff.PathsDiff, s2.Paths); err != nil {
+		return nil, err
+	}
 
 	return diff, nil
 }
 
+/*
+GetRevisionContext returns a diff that carries only the context of a pair of specs: their info and the revision's paths.
+Get returns nil for identical specs, but the checkers that judge the revision as a whole, like the sunset-date-passed
+checks, report on it whether or not it changed, so they are run on the context instead.
+The revision's paths are filtered and have their prefixes rewritten according to config, like those of a diff.
+*/
+func GetRevisionContext(config *Config, s1, s2 *openapi3.T) (*Diff, error) {
+	revisionPaths, err := getRevisionPaths(config, nil, s2.Paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Diff{
+		BaseInfo:      s1.Info,
+		RevisionInfo:  s2.Info,
+		RevisionPaths: revisionPaths,
+	}, nil
+}
+
+// getRevisionPaths returns the revision's paths as PathsDiff has them: filtered, and with their prefixes rewritten.
+// Without a PathsDiff, the paths are filtered and rewritten here, leaving the spec's paths as they are.
+func getRevisionPaths(config *Config, pathsDiff *PathsDiff, paths *openapi3.Paths) (*openapi3.Paths, error) {
+	if pathsDiff != nil {
+		return pathsDiff.Revision, nil
+	}
+
+	filtered := openapi3.NewPathsWithCapacity(paths.Len())
+	for path, pathItem := range paths.Map() {
+		filtered.Set(path, pathItem)
+	}
+	if err := filterPaths(config.MatchPath, config.UnmatchPath, config.FilterExtension, filtered, openapi3.NewPaths()); err != nil {
+		return nil, err
+	}
+
+	return rewritePrefix(filtered.Map(), config.PathStripPrefixRevision, config.PathPrefixRevision), nil
+}
+
 func getDiffInternal(config *Config, state *state, s1, s2 *openapi3.T) (*Diff, error) {
 
 	result := newDiff()
@@ -363,5 +419,10 @@ func (diff *Diff) GetSummary() *Summary {
 
 	// special
 	summary.add(diff.EndpointsDiff, EndpointsDetail)
+
+	// asyncapi
+	if diff.AsyncAPIDiff != nil {
+		summary.add(diff.AsyncAPIDiff.ChannelsDiff, ChannelsDetail)
+	}
 	return summary
 }
@@ -34,6 +34,7 @@ The Diff type contains nested diff objects for each OpenAPI component:
   - WebhooksDiff: changes to webhooks (OpenAPI 3.1)
   - ComponentsDiff: changes to reusable components (schemas, parameters, responses, etc.)
   - InfoDiff, SecurityDiff, ServersDiff, TagsDiff: other top-level changes
+  - AsyncAPIDiff: changes to the channels, operations and messages of an AsyncAPI document
 
 Each diff type follows a consistent pattern with Added, Deleted, and Modified fields.
 Modified entries contain detailed nested diffs showing exactly what changed.
@@ -49,6 +50,14 @@ SchemaDiff handles JSON Schema comparison including:
   - Composition (allOf, oneOf, anyOf, not)
   - JSON Schema 2020-12: $defs, if/then/else, dependentSchemas, prefixItems, contains, etc.
 
+# AsyncAPI
+
+When both specs are AsyncAPI documents (see load.SpecInfo.AsyncAPI),
+GetWithOperationsSourcesMap also compares them with GetAsyncAPIDiff. Message
+payloads and headers are compared by SchemaDiff, in the request direction for
+the messages an application receives and in the response direction for those
+it sends.
+
 # References
 
 OpenAPI $ref references should be resolved before diffing. The load package resolves
@@ -22,6 +22,18 @@ func NewPathParamsMap(pathParams1, pathParams2 []string) (PathParamsMap, bool) {
 	return result, true
 }
 
+// MatchPathParams reports whether two templated paths are the same endpoint,
+// ignoring the names of their path params, and returns the param mapping
+// for example: /person/{personName} and /person/{name}
+func MatchPathParams(path1, path2 string) (PathParamsMap, bool) {
+	normalizedPath1, count1, pathParams1 := normalizeTemplatedPath(path1)
+	normalizedPath2, count2, pathParams2 := normalizeTemplatedPath(path2)
+	if count1 != count2 || normalizedPath1 != normalizedPath2 {
+		return nil, false
+	}
+	return NewPathParamsMap(pathParams1, pathParams2)
+}
+
 func (pathParamsMap PathParamsMap) find(pathParam1, pathParam2 string) bool {
 	if len(pathParamsMap) == 0 {
 		return pathParam1 == pathParam2
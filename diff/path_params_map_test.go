@@ -0,0 +1,27 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchPathParams(t *testing.T) {
+	pathParamsMap, ok := diff.MatchPathParams("/person/{personName}/pets/{petId}", "/person/{name}/pets/{id}")
+	require.True(t, ok)
+	require.Equal(t, diff.PathParamsMap{"personName": "name", "petId": "id"}, pathParamsMap)
+}
+
+func TestMatchPathParams_NoParams(t *testing.T) {
+	_, ok := diff.MatchPathParams("/person", "/person")
+	require.True(t, ok)
+}
+
+func TestMatchPathParams_Different(t *testing.T) {
+	_, ok := diff.MatchPathParams("/person/{name}", "/person/{name}/pets")
+	require.False(t, ok)
+
+	_, ok = diff.MatchPathParams("/person/{name}", "/person/me")
+	require.False(t, ok)
+}
@@ -0,0 +1,315 @@
+package diff
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxPatternInsts limits the size of the compiled patterns that are compared
+	maxPatternInsts = 1000
+	// maxPatternSteps limits the work done to compare two patterns
+	maxPatternSteps = 1_000_000
+)
+
+// patternCodepoint matches ECMA-262 \uXXXX escapes which Go writes as \x{XXXX}
+var patternCodepoint = regexp.MustCompile(`\\u([0-9A-Fa-f]{4})`)
+
+const (
+	// ecmaSpace is the ECMA-262 \s class which, unlike Perl's, includes \v, the no-break space and the Unicode spaces
+	ecmaSpace = `\t\n\v\f\r \x{a0}\x{1680}\x{2000}-\x{200a}\x{2028}\x{2029}\x{202f}\x{205f}\x{3000}\x{feff}`
+	// ecmaNotSpace is the complement of ecmaSpace, for \S inside a character class
+	ecmaNotSpace = `\x00-\x08\x0e-\x1f\x21-\x{9f}\x{a1}-\x{167f}\x{1681}-\x{1fff}\x{200b}-\x{2027}\x{202a}-\x{202e}\x{2030}-\x{205e}\x{2060}-\x{2fff}\x{3001}-\x{fefe}\x{ff00}-\x{10ffff}`
+	// ecmaDot is the ECMA-262 . which, unlike Perl's, doesn't match the line terminators \r, U+2028 and U+2029 either
+	ecmaDot = `[^\n\r\x{2028}\x{2029}]`
+)
+
+// IsPatternSubset reports whether every string that matches pattern a also matches pattern b.
+// Patterns are ECMA-262 regular expressions which, as in JSON Schema, match anywhere in a string unless anchored.
+// An empty pattern matches every string.
+// The comparison covers the common regex subset: literals, character classes, groups, alternation, repetition and the ^ and $ anchors.
+// decided is false when a pattern uses other features, like lookarounds, backreferences or word boundaries, or when the patterns are too large to compare.
+func IsPatternSubset(a, b string) (result bool, decided bool) {
+	if a == b {
+		return true, true
+	}
+
+	progB, ok := compilePattern(b)
+	if !ok {
+		return false, false
+	}
+
+	progA, ok := compilePattern(a)
+	if !ok {
+		// whatever a matches, it is contained in a pattern that matches every string
+		if result, decided := IsPatternSubset("", b); decided && result {
+			return true, true
+		}
+		return false, false
+	}
+
+	return newPatternComparison(progA, progB).isSubset()
+}
+
+// isPatternSubset reports whether pattern a is provably contained in pattern b
+func isPatternSubset(a, b string) bool {
+	result, decided := IsPatternSubset(a, b)
+	return decided && result
+}
+
+// compilePattern compiles a pattern into an automaton, if it only uses the supported subset of the regex syntax
+func compilePattern(pattern string) (*syntax.Prog, bool) {
+	re, err := syntax.Parse(translatePattern(patternCodepoint.ReplaceAllString(pattern, `\x{$1}`)), syntax.Perl)
+	if err != nil {
+		return nil, false
+	}
+
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil || len(prog.Inst) > maxPatternInsts {
+		return nil, false
+	}
+
+	for _, inst := range prog.Inst {
+		switch inst.Op {
+		case syntax.InstRune:
+			if syntax.Flags(inst.Arg)&syntax.FoldCase != 0 {
+				return nil, false
+			}
+		case syntax.InstEmptyWidth:
+			if syntax.EmptyOp(inst.Arg)&^(syntax.EmptyBeginText|syntax.EmptyEndText) != 0 {
+				return nil, false
+			}
+		}
+	}
+	return prog, true
+}
+
+// translatePattern rewrites the parts of an ECMA-262 pattern that Go parses with a different meaning: \s, \S and .
+// \d and \w are ASCII classes in both.
+func translatePattern(pattern string) string {
+	var b strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			switch next := pattern[i]; {
+			case next == 's' && inClass:
+				b.WriteString(ecmaSpace)
+			case next == 's':
+				b.WriteString("[" + ecmaSpace + "]")
+			case next == 'S' && inClass:
+				b.WriteString(ecmaNotSpace)
+			case next == 'S':
+				b.WriteString("[^" + ecmaSpace + "]")
+			default:
+				b.WriteByte(c)
+				b.WriteByte(next)
+			}
+		case c == '[' && !inClass:
+			inClass = true
+			b.WriteByte(c)
+		case c == ']' && inClass:
+			inClass = false
+			b.WriteByte(c)
+		case c == '.' && !inClass:
+			b.WriteString(ecmaDot)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// patternState is the state of a pattern automaton after reading a prefix of a string
+type patternState struct {
+	pcs     []uint32 // the instructions that wait for the next rune, or for the end of the string
+	matched bool     // the prefix matches the pattern, and so does every string that starts with it
+}
+
+func (state patternState) key() string {
+	var b strings.Builder
+	if state.matched {
+		b.WriteString("*")
+	}
+	for _, pc := range state.pcs {
+		b.WriteString(strconv.FormatUint(uint64(pc), 10))
+		b.WriteString(",")
+	}
+	return b.String()
+}
+
+// patternComparison runs the automata of two patterns side by side over all strings
+type patternComparison struct {
+	a, b *syntax.Prog
+	// runes holds the first rune of each range of runes that neither pattern tells apart
+	runes []rune
+}
+
+func newPatternComparison(a, b *syntax.Prog) *patternComparison {
+	return &patternComparison{
+		a:     a,
+		b:     b,
+		runes: getPatternRunes(a, b),
+	}
+}
+
+// isSubset searches for a string that a matches and b doesn't
+func (c *patternComparison) isSubset() (bool, bool) {
+	type pair struct {
+		a, b patternState
+	}
+
+	start := pair{
+		a: getPatternState(c.a, []uint32{uint32(c.a.Start)}, true, false),
+		b: getPatternState(c.b, []uint32{uint32(c.b.Start)}, true, false),
+	}
+	if accepts(c.a, start.a, true) && !accepts(c.b, start.b, true) {
+		return false, true
+	}
+
+	visited := map[string]bool{start.a.key() + "|" + start.b.key(): true}
+	queue := []pair{start}
+	steps := 0
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current.b.matched {
+			// b matches every string from here on
+			continue
+		}
+
+		for _, r := range c.runes {
+			if steps++; steps > maxPatternSteps {
+				return false, false
+			}
+
+			next := pair{
+				a: getNextPatternState(c.a, current.a, r),
+				b: getNextPatternState(c.b, current.b, r),
+			}
+			if accepts(c.a, next.a, false) && !accepts(c.b, next.b, false) {
+				return false, true
+			}
+
+			key := next.a.key() + "|" + next.b.key()
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+			queue = append(queue, next)
+		}
+	}
+	return true, true
+}
+
+// getPatternState returns the state reached from the given instructions without reading a rune
+func getPatternState(prog *syntax.Prog, pcs []uint32, atBegin, atEnd bool) patternState {
+	result := patternState{}
+	visited := map[uint32]bool{}
+	stack := slices.Clone(pcs)
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[pc] {
+			continue
+		}
+		visited[pc] = true
+
+		inst := &prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstMatch:
+			result.matched = true
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, inst.Out, inst.Arg)
+		case syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, inst.Out)
+		case syntax.InstEmptyWidth:
+			empty := syntax.EmptyOp(inst.Arg)
+			if empty&syntax.EmptyBeginText != 0 && !atBegin {
+				continue
+			}
+			if empty&syntax.EmptyEndText != 0 && !atEnd {
+				result.pcs = append(result.pcs, pc)
+				continue
+			}
+			stack = append(stack, inst.Out)
+		case syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+			if !atEnd {
+				result.pcs = append(result.pcs, pc)
+			}
+		}
+	}
+
+	if result.matched {
+		result.pcs = nil
+	} else {
+		slices.Sort(result.pcs)
+	}
+	return result
+}
+
+// getNextPatternState returns the state reached by reading r
+func getNextPatternState(prog *syntax.Prog, state patternState, r rune) patternState {
+	if state.matched {
+		return state
+	}
+
+	// patterns aren't anchored, so a match can also start after r
+	pcs := []uint32{uint32(prog.Start)}
+	for _, pc := range state.pcs {
+		if inst := &prog.Inst[pc]; matchesRune(inst, r) {
+			pcs = append(pcs, inst.Out)
+		}
+	}
+	return getPatternState(prog, pcs, false, false)
+}
+
+// accepts reports whether the string read so far matches the pattern
+func accepts(prog *syntax.Prog, state patternState, atBegin bool) bool {
+	return state.matched || getPatternState(prog, state.pcs, atBegin, true).matched
+}
+
+func matchesRune(inst *syntax.Inst, r rune) bool {
+	switch inst.Op {
+	case syntax.InstRuneAny:
+		return true
+	case syntax.InstRuneAnyNotNL:
+		return r != '\n'
+	case syntax.InstRune, syntax.InstRune1:
+		return inst.MatchRune(r)
+	}
+	return false
+}
+
+// getPatternRunes splits the runes into ranges that every instruction of the given automata either matches entirely or not at all,
+// and returns the first rune of each range
+func getPatternRunes(progs ...*syntax.Prog) []rune {
+	bounds := []rune{0, '\n', '\n' + 1}
+	for _, prog := range progs {
+		for _, inst := range prog.Inst {
+			if inst.Op != syntax.InstRune && inst.Op != syntax.InstRune1 {
+				continue
+			}
+			if len(inst.Rune) == 1 {
+				bounds = append(bounds, inst.Rune[0], inst.Rune[0]+1)
+				continue
+			}
+			for i := 0; i+1 < len(inst.Rune); i += 2 {
+				bounds = append(bounds, inst.Rune[i], inst.Rune[i+1]+1)
+			}
+		}
+	}
+
+	slices.Sort(bounds)
+	bounds = slices.Compact(bounds)
+	return slices.DeleteFunc(bounds, func(r rune) bool {
+		return r > unicode.MaxRune
+	})
+}
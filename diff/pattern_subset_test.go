@@ -0,0 +1,79 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPatternSubset(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{"same pattern", `^[a-z]+$`, `^[a-z]+$`, true},
+		{"broader class", `^[a-z]+$`, `^[a-z0-9]+$`, true},
+		{"narrower class", `^[a-z0-9]+$`, `^[a-z]+$`, false},
+		{"plus in star", `^[0-9]+$`, `^[0-9]*$`, true},
+		{"star in plus", `^[0-9]*$`, `^[0-9]+$`, false},
+		{"equivalent", `^[a-z]+$`, `^[a-z][a-z]*$`, true},
+		{"shorter repeat", `^[a-z]{2,4}$`, `^[a-z]{1,8}$`, true},
+		{"longer repeat", `^[a-z]{1,8}$`, `^[a-z]{2,4}$`, false},
+		{"digit class", `^[0-9]{3}$`, `^\d+$`, true},
+		{"alternation", `^(cat|dog)$`, `^[a-z]{3}$`, true},
+		{"added alternative", `^(cat|dog|bird)$`, `^(cat|dog)$`, false},
+		{"any pattern", `^[a-z]+$`, `.*`, true},
+		{"empty pattern", `^[a-z]+$`, ``, true},
+		{"from empty pattern", ``, `^[a-z]+$`, false},
+		{"unanchored", `abc`, `b`, true},
+		{"unanchored not contained", `b`, `abc`, false},
+		{"anchored in unanchored", `^abc$`, `b`, true},
+		{"unanchored in anchored", `b`, `^b$`, false},
+		{"start anchor", `^ab`, `^a`, true},
+		{"end anchor", `ab$`, `^a`, false},
+		{"dot", `^a.c$`, `^a[^\n]c$`, true},
+		{"dot includes newline", `^a[\s\S]c$`, `^a.c$`, false},
+		{"dot excludes carriage return", `^a\r$`, `^a.$`, false},
+		{"dot excludes line separator", `^a\u2028$`, `^a.$`, false},
+		{"space class includes vertical tab", `^\s+$`, `^[ \t\n\r\f]+$`, false},
+		{"space class includes no-break space", `^\u00a0$`, `^\s$`, true},
+		{"space class in class", `^[\sa]+$`, `^[ \t\n\v\f\r\u00a0\u1680\u2000-\u200a\u2028\u2029\u202f\u205f\u3000\ufeffa]+$`, true},
+		{"non-space class", `^\S+$`, `^[^\s]+$`, true},
+		{"non-space class in class", `^\u00a0$`, `^[\Sa]$`, false},
+		{"escaped dot", `^a\.$`, `^a[.]$`, true},
+		{"codepoint", `^\u0041$`, `^[A-Z]$`, true},
+		{"unicode class", `^[a-z]+$`, `^\p{L}+$`, true},
+		{"email", `^[a-z]+@[a-z]+\.com$`, `^[^@]+@[^@]+$`, true},
+		{"unparsable in any pattern", `(?=a)`, `.*`, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, decided := diff.IsPatternSubset(test.a, test.b)
+			require.True(t, decided)
+			require.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestIsPatternSubset_Undecided(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"lookahead", `^(?=a)[a-z]+$`, `^[a-z]+$`},
+		{"backreference", `^([a-z])\1$`, `^[a-z]+$`},
+		{"word boundary", `\bcat\b`, `cat`},
+		{"case insensitive", `^(?i)cat$`, `^[a-z]+$`},
+		{"invalid", `^[a-z+$`, `^[a-z]+$`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, decided := diff.IsPatternSubset(test.a, test.b)
+			require.False(t, decided)
+		})
+	}
+}
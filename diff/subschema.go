@@ -0,0 +1,475 @@
+package diff
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// IsSubschema reports whether every value that is valid against schema a is also valid against schema b.
+// The answer is conservative: false means that containment couldn't be proved, not that it doesn't hold.
+// A nil schema accepts every value.
+//
+// The test covers types and nullability, enums and consts, numeric and length ranges, patterns,
+// required properties, properties and additionalProperties, items, and allOf, anyOf, oneOf and not.
+// Other keywords, like patternProperties or if/then/else, are only contained in the same keywords.
+func IsSubschema(a, b *openapi3.SchemaRef) bool {
+	return newContainment().isSubschema(getValue(a), getValue(b))
+}
+
+// IsDisjoint reports whether no value is valid against both schema a and schema b.
+// Like IsSubschema, the answer is conservative: false means that disjointness couldn't be proved.
+func IsDisjoint(a, b *openapi3.SchemaRef) bool {
+	return isDisjoint(getValue(a), getValue(b))
+}
+
+func getValue(schemaRef *openapi3.SchemaRef) *openapi3.Schema {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return &openapi3.Schema{}
+	}
+	return schemaRef.Value
+}
+
+type subschemaPair struct {
+	a, b *openapi3.Schema
+}
+
+// containment tracks the pairs of schemas under test, so that recursive schemas are assumed to be contained when they recur
+type containment struct {
+	visited map[subschemaPair]struct{}
+}
+
+func newContainment() *containment {
+	return &containment{visited: map[subschemaPair]struct{}{}}
+}
+
+func (c *containment) isSubschemaRef(a, b *openapi3.SchemaRef) bool {
+	return c.isSubschema(getValue(a), getValue(b))
+}
+
+func (c *containment) isSubschema(a, b *openapi3.Schema) bool {
+	if a == b || isUnconstrained(b) {
+		return true
+	}
+
+	pair := subschemaPair{a: a, b: b}
+	if _, ok := c.visited[pair]; ok {
+		return true
+	}
+	c.visited[pair] = struct{}{}
+	defer delete(c.visited, pair)
+
+	// the values of a are the values of one of its subschemas, and the values of each of its allOf subschemas
+	if len(a.OneOf) > 0 && c.allSubschemas(a.OneOf, b) ||
+		len(a.AnyOf) > 0 && c.allSubschemas(a.AnyOf, b) ||
+		slices.ContainsFunc(a.AllOf, func(schemaRef *openapi3.SchemaRef) bool { return c.isSubschema(getValue(schemaRef), b) }) {
+		return true
+	}
+
+	// enums are tested value by value
+	if values := getEnum(a); values != nil {
+		return c.allValid(values, b)
+	}
+
+	for _, schemaRef := range b.AllOf {
+		if !c.isSubschema(a, getValue(schemaRef)) {
+			return false
+		}
+	}
+
+	if len(b.AnyOf) > 0 && !slices.ContainsFunc(b.AnyOf, func(schemaRef *openapi3.SchemaRef) bool { return c.isSubschema(a, getValue(schemaRef)) }) {
+		return false
+	}
+
+	if len(b.OneOf) > 0 && !c.isSubschemaOfOne(a, b.OneOf) {
+		return false
+	}
+
+	if b.Not != nil && !isDisjoint(a, getValue(b.Not)) {
+		return false
+	}
+
+	return c.isSubschemaByKeywords(a, b)
+}
+
+func (c *containment) allSubschemas(schemaRefs openapi3.SchemaRefs, b *openapi3.Schema) bool {
+	for _, schemaRef := range schemaRefs {
+		if !c.isSubschema(getValue(schemaRef), b) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSubschemaOfOne reports whether a is contained in one of the subschemas and disjoint from the others
+func (c *containment) isSubschemaOfOne(a *openapi3.Schema, schemaRefs openapi3.SchemaRefs) bool {
+	for i, schemaRef := range schemaRefs {
+		if !c.isSubschema(a, getValue(schemaRef)) {
+			continue
+		}
+		disjoint := true
+		for j, other := range schemaRefs {
+			if i != j && !isDisjoint(a, getValue(other)) {
+				disjoint = false
+				break
+			}
+		}
+		if disjoint {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *containment) allValid(values []any, b *openapi3.Schema) bool {
+	for _, value := range values {
+		if !isValid(value, b) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSubschemaByKeywords compares the keywords of a and b, other than the enum of a and the composition of b
+func (c *containment) isSubschemaByKeywords(a, b *openapi3.Schema) bool {
+	if len(b.Enum) > 0 || b.Const != nil {
+		return false
+	}
+
+	if acceptsNull(a) && !acceptsNull(b) {
+		return false
+	}
+
+	typesA := getNonNullTypes(a)
+	typesB := getNonNullTypes(b)
+	for _, t := range typesA {
+		if typesB != nil && !permitsType(typesB, t) {
+			return false
+		}
+	}
+	if typesA == nil && typesB != nil {
+		return false
+	}
+
+	if b.Format != "" && a.Format != b.Format {
+		return false
+	}
+
+	// the keywords of b for each type of a, or for every type when a has no type
+	checkType := func(t string) bool {
+		return typesA == nil || slices.Contains(typesA, t) || t == openapi3.TypeNumber && slices.Contains(typesA, openapi3.TypeInteger)
+	}
+
+	if checkType(openapi3.TypeString) && !isSubschemaString(a, b) {
+		return false
+	}
+	if (checkType(openapi3.TypeNumber) || checkType(openapi3.TypeInteger)) && !isSubschemaNumber(a, b) {
+		return false
+	}
+	if checkType(openapi3.TypeArray) && !c.isSubschemaArray(a, b) {
+		return false
+	}
+	if checkType(openapi3.TypeObject) && !c.isSubschemaObject(a, b) {
+		return false
+	}
+
+	return equalKeywords(a, b)
+}
+
+func isSubschemaString(a, b *openapi3.Schema) bool {
+	if a.MinLength < b.MinLength {
+		return false
+	}
+	if !isMaxContained(a.MaxLength, b.MaxLength) {
+		return false
+	}
+	if b.Pattern != "" && !isPatternSubset(a.Pattern, b.Pattern) {
+		return false
+	}
+	return true
+}
+
+func isSubschemaNumber(a, b *openapi3.Schema) bool {
+	if !isBoundContained(getLowerBound(a), getLowerBound(b), 1) {
+		return false
+	}
+	if !isBoundContained(getUpperBound(a), getUpperBound(b), -1) {
+		return false
+	}
+	if b.MultipleOf != nil {
+		if a.MultipleOf == nil {
+			return *b.MultipleOf == 1 && slices.Equal(getNonNullTypes(a), []string{openapi3.TypeInteger})
+		}
+		if quotient := *a.MultipleOf / *b.MultipleOf; quotient != math.Trunc(quotient) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *containment) isSubschemaArray(a, b *openapi3.Schema) bool {
+	if a.MinItems < b.MinItems {
+		return false
+	}
+	if !isMaxContained(a.MaxItems, b.MaxItems) {
+		return false
+	}
+	if b.UniqueItems && !a.UniqueItems {
+		return false
+	}
+	if len(b.PrefixItems) > 0 || len(a.PrefixItems) > 0 {
+		if len(a.PrefixItems) != len(b.PrefixItems) {
+			return false
+		}
+		for i := range a.PrefixItems {
+			if !c.isSubschemaRef(a.PrefixItems[i], b.PrefixItems[i]) {
+				return false
+			}
+		}
+	}
+	return b.Items == nil || c.isSubschemaRef(a.Items, b.Items)
+}
+
+func (c *containment) isSubschemaObject(a, b *openapi3.Schema) bool {
+	if a.MinProps < b.MinProps {
+		return false
+	}
+	if !isMaxContained(a.MaxProps, b.MaxProps) {
+		return false
+	}
+
+	for _, name := range b.Required {
+		if !slices.Contains(a.Required, name) {
+			return false
+		}
+	}
+
+	for name, property := range b.Properties {
+		if schemaRef, ok := a.Properties[name]; ok {
+			if !c.isSubschemaRef(schemaRef, property) {
+				return false
+			}
+			continue
+		}
+		if isForbidden(a.AdditionalProperties) {
+			continue
+		}
+		if !c.isSubschemaRef(a.AdditionalProperties.Schema, property) {
+			return false
+		}
+	}
+
+	if isForbidden(b.AdditionalProperties) {
+		if !isForbidden(a.AdditionalProperties) || len(a.PatternProperties) > 0 {
+			return false
+		}
+		for name := range a.Properties {
+			if _, ok := b.Properties[name]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+
+	if additional := b.AdditionalProperties.Schema; additional != nil {
+		for name, property := range a.Properties {
+			if _, ok := b.Properties[name]; !ok && !c.isSubschemaRef(property, additional) {
+				return false
+			}
+		}
+		if !isForbidden(a.AdditionalProperties) && !c.isSubschemaRef(a.AdditionalProperties.Schema, additional) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalKeywords reports whether b has no other keywords than those compared, or the same ones as a
+func equalKeywords(a, b *openapi3.Schema) bool {
+	other := func(schema *openapi3.Schema) openapi3.Schema {
+		return openapi3.Schema{
+			PatternProperties:     schema.PatternProperties,
+			DependentRequired:     schema.DependentRequired,
+			DependentSchemas:      schema.DependentSchemas,
+			PropertyNames:         schema.PropertyNames,
+			UnevaluatedItems:      schema.UnevaluatedItems,
+			UnevaluatedProperties: schema.UnevaluatedProperties,
+			Contains:              schema.Contains,
+			MinContains:           schema.MinContains,
+			MaxContains:           schema.MaxContains,
+			If:                    schema.If,
+			Then:                  schema.Then,
+			Else:                  schema.Else,
+		}
+	}
+
+	otherB := other(b)
+	dataB, err := json.Marshal(&otherB)
+	if err != nil {
+		return false
+	}
+	if string(dataB) == "{}" {
+		return true
+	}
+
+	otherA := other(a)
+	dataA, err := json.Marshal(&otherA)
+	return err == nil && string(dataA) == string(dataB)
+}
+
+// isDisjoint reports whether a and b provably accept no common value
+func isDisjoint(a, b *openapi3.Schema) bool {
+	if values := getEnum(a); values != nil {
+		return noneValid(values, b)
+	}
+	if values := getEnum(b); values != nil {
+		return noneValid(values, a)
+	}
+
+	if acceptsNull(a) && acceptsNull(b) {
+		return false
+	}
+
+	typesA, typesB := getNonNullTypes(a), getNonNullTypes(b)
+	if typesA == nil || typesB == nil {
+		return false
+	}
+	for _, t := range typesA {
+		if permitsType(typesB, t) || t == openapi3.TypeNumber && slices.Contains(typesB, openapi3.TypeInteger) {
+			return false
+		}
+	}
+	return true
+}
+
+func noneValid(values []any, schema *openapi3.Schema) bool {
+	for _, value := range values {
+		if isValid(value, schema) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValid(value any, schema *openapi3.Schema) bool {
+	return schema.VisitJSON(value, openapi3.MultiErrors()) == nil
+}
+
+// getEnum returns the values of a schema that accepts a finite set of values, or nil
+func getEnum(schema *openapi3.Schema) []any {
+	if schema.Const != nil {
+		return []any{schema.Const}
+	}
+	if len(schema.Enum) > 0 {
+		return schema.Enum
+	}
+	return nil
+}
+
+// isUnconstrained reports whether a schema accepts every value
+func isUnconstrained(schema *openapi3.Schema) bool {
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || len(schema.AllOf) > 0 || schema.Not != nil ||
+		schema.Items != nil || len(schema.PrefixItems) > 0 || schema.Contains != nil ||
+		len(schema.Properties) > 0 || schema.AdditionalProperties.Schema != nil || len(schema.PatternProperties) > 0 ||
+		len(schema.DependentSchemas) > 0 || schema.PropertyNames != nil ||
+		schema.UnevaluatedItems.Schema != nil || schema.UnevaluatedProperties.Schema != nil ||
+		schema.If != nil || schema.Then != nil || schema.Else != nil {
+		return false
+	}
+
+	// the remaining keywords are marshaled without subschemas, which may be recursive
+	constraints := *schema
+	constraints.Defs = nil
+	constraints.ContentSchema = nil
+	constraints.Extensions = nil
+	constraints.Origin = nil
+	constraints.Title = ""
+	constraints.Description = ""
+	constraints.Default = nil
+	constraints.Example = nil
+	constraints.Examples = nil
+	constraints.ExternalDocs = nil
+	constraints.Deprecated = false
+	constraints.ReadOnly = false
+	constraints.WriteOnly = false
+
+	data, err := json.Marshal(&constraints)
+	return err == nil && string(data) == "{}"
+}
+
+// acceptsNull reports whether the schema may accept null, by its type
+func acceptsNull(schema *openapi3.Schema) bool {
+	return schema.Nullable || schema.Type == nil || len(*schema.Type) == 0 || schema.Type.IncludesNull()
+}
+
+// getNonNullTypes returns the types of the schema other than null, or nil if the type is unrestricted
+func getNonNullTypes(schema *openapi3.Schema) []string {
+	if schema.Type == nil || len(*schema.Type) == 0 {
+		return nil
+	}
+	result := []string{}
+	for _, t := range *schema.Type {
+		if t != openapi3.TypeNull {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func permitsType(types []string, t string) bool {
+	return slices.Contains(types, t) || t == openapi3.TypeInteger && slices.Contains(types, openapi3.TypeNumber)
+}
+
+func isMaxContained(maxA, maxB *uint64) bool {
+	return maxB == nil || maxA != nil && *maxA <= *maxB
+}
+
+func isForbidden(additionalProperties openapi3.AdditionalProperties) bool {
+	return additionalProperties.Has != nil && !*additionalProperties.Has
+}
+
+type numericBound struct {
+	value     float64
+	exclusive bool
+}
+
+func getLowerBound(schema *openapi3.Schema) *numericBound {
+	return getBound(schema.Min, schema.ExclusiveMin, 1)
+}
+
+func getUpperBound(schema *openapi3.Schema) *numericBound {
+	return getBound(schema.Max, schema.ExclusiveMax, -1)
+}
+
+// getBound returns the tightest bound of a schema, in the style of OpenAPI 3.0 or 3.1
+// direction is 1 for lower bounds and -1 for upper bounds
+func getBound(bound *float64, exclusive openapi3.ExclusiveBound, direction float64) *numericBound {
+	var result *numericBound
+	if bound != nil {
+		result = &numericBound{value: *bound, exclusive: exclusive.IsTrue()}
+	}
+	if exclusive.Value != nil {
+		if result == nil || *exclusive.Value*direction >= result.value*direction {
+			result = &numericBound{value: *exclusive.Value, exclusive: true}
+		}
+	}
+	return result
+}
+
+// isBoundContained reports whether bound a is at least as tight as bound b
+// direction is 1 for lower bounds and -1 for upper bounds
+func isBoundContained(a, b *numericBound, direction float64) bool {
+	if b == nil {
+		return true
+	}
+	if a == nil {
+		return false
+	}
+	if a.value*direction > b.value*direction {
+		return true
+	}
+	return a.value == b.value && (a.exclusive || !b.exclusive)
+}
@@ -0,0 +1,130 @@
+package diff_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/stretchr/testify/require"
+)
+
+func newSchemaRef(t *testing.T, data string) *openapi3.SchemaRef {
+	t.Helper()
+	schema := openapi3.NewSchema()
+	require.NoError(t, json.Unmarshal([]byte(data), schema))
+	return &openapi3.SchemaRef{Value: schema}
+}
+
+func TestIsSubschema(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{"same type", `{"type":"string"}`, `{"type":"string"}`, true},
+		{"any schema", `{"type":"string"}`, `{}`, true},
+		{"any value", `{}`, `{"type":"string"}`, false},
+		{"different types", `{"type":"string"}`, `{"type":"integer"}`, false},
+		{"integer in number", `{"type":"integer"}`, `{"type":"number"}`, true},
+		{"number in integer", `{"type":"number"}`, `{"type":"integer"}`, false},
+		{"type in types", `{"type":"string"}`, `{"type":["string","integer"]}`, true},
+		{"types in type", `{"type":["string","integer"]}`, `{"type":"string"}`, false},
+		{"nullable in not nullable", `{"type":"string","nullable":true}`, `{"type":"string"}`, false},
+		{"not nullable in nullable", `{"type":"string"}`, `{"type":"string","nullable":true}`, true},
+		{"null type in nullable", `{"type":["string","null"]}`, `{"type":"string","nullable":true}`, true},
+		{"enum in type", `{"type":"string","enum":["a","b"]}`, `{"type":"string"}`, true},
+		{"enum in enum", `{"enum":["a"]}`, `{"enum":["a","b"]}`, true},
+		{"enum not in enum", `{"enum":["a","c"]}`, `{"enum":["a","b"]}`, false},
+		{"const in enum", `{"const":"a"}`, `{"enum":["a","b"]}`, true},
+		{"enum in pattern", `{"enum":["ab","ac"]}`, `{"type":"string","pattern":"^a"}`, true},
+		{"type in enum", `{"type":"string"}`, `{"enum":["a"]}`, false},
+		{"narrower range", `{"type":"number","minimum":1,"maximum":5}`, `{"type":"number","minimum":0,"maximum":10}`, true},
+		{"wider range", `{"type":"number","minimum":0,"maximum":10}`, `{"type":"number","minimum":1,"maximum":5}`, false},
+		{"unbounded range", `{"type":"number"}`, `{"type":"number","maximum":10}`, false},
+		{"exclusive in inclusive", `{"type":"number","exclusiveMinimum":true,"minimum":0}`, `{"type":"number","minimum":0}`, true},
+		{"inclusive in exclusive", `{"type":"number","minimum":0}`, `{"type":"number","exclusiveMinimum":true,"minimum":0}`, false},
+		{"exclusive 3.1", `{"type":"number","exclusiveMinimum":1}`, `{"type":"number","minimum":1}`, true},
+		{"multiple of multiple", `{"type":"integer","multipleOf":4}`, `{"type":"integer","multipleOf":2}`, true},
+		{"multiple of other", `{"type":"integer","multipleOf":3}`, `{"type":"integer","multipleOf":2}`, false},
+		{"integer multiple of one", `{"type":"integer"}`, `{"type":"number","multipleOf":1}`, true},
+		{"shorter length", `{"type":"string","minLength":2,"maxLength":5}`, `{"type":"string","minLength":1,"maxLength":10}`, true},
+		{"longer length", `{"type":"string","maxLength":20}`, `{"type":"string","maxLength":10}`, false},
+		{"same format", `{"type":"string","format":"date"}`, `{"type":"string","format":"date"}`, true},
+		{"added format", `{"type":"string"}`, `{"type":"string","format":"date"}`, false},
+		{"same pattern", `{"type":"string","pattern":"^[a-z]+$"}`, `{"type":"string","pattern":"^[a-z]+$"}`, true},
+		{"finite pattern", `{"type":"string","pattern":"^(dog|cat)s?$"}`, `{"type":"string","pattern":"^[a-z]+$"}`, true},
+		{"finite pattern not in pattern", `{"type":"string","pattern":"^(dog|Cat)$"}`, `{"type":"string","pattern":"^[a-z]+$"}`, false},
+		{"infinite pattern", `{"type":"string","pattern":"^[a-z]+$"}`, `{"type":"string","pattern":"^[a-z]*$"}`, true},
+		{"infinite pattern not in pattern", `{"type":"string","pattern":"^[a-z0-9]+$"}`, `{"type":"string","pattern":"^[a-z]+$"}`, false},
+		{"pattern in any pattern", `{"type":"string","pattern":"^[a-z]+$"}`, `{"type":"string","pattern":".*"}`, true},
+		{"required superset", `{"type":"object","required":["a","b"]}`, `{"type":"object","required":["a"]}`, true},
+		{"required subset", `{"type":"object","required":["a"]}`, `{"type":"object","required":["a","b"]}`, false},
+		{"narrower property", `{"type":"object","properties":{"a":{"type":"integer"}}}`, `{"type":"object","properties":{"a":{"type":"number"}}}`, true},
+		{"wider property", `{"type":"object","properties":{"a":{"type":"number"}}}`, `{"type":"object","properties":{"a":{"type":"integer"}}}`, false},
+		{"property of additional properties", `{"type":"object"}`, `{"type":"object","properties":{"a":{"type":"integer"}}}`, false},
+		{"property of no additional properties", `{"type":"object","additionalProperties":false}`, `{"type":"object","properties":{"a":{"type":"integer"}}}`, true},
+		{"closed in closed", `{"type":"object","properties":{"a":{}},"additionalProperties":false}`, `{"type":"object","properties":{"a":{},"b":{}},"additionalProperties":false}`, true},
+		{"open in closed", `{"type":"object","properties":{"a":{}}}`, `{"type":"object","properties":{"a":{}},"additionalProperties":false}`, false},
+		{"additional properties schema", `{"type":"object","additionalProperties":{"type":"integer"}}`, `{"type":"object","additionalProperties":{"type":"number"}}`, true},
+		{"property in additional properties schema", `{"type":"object","properties":{"a":{"type":"string"}},"additionalProperties":false}`, `{"type":"object","additionalProperties":{"type":"number"}}`, false},
+		{"items", `{"type":"array","items":{"type":"integer"},"minItems":1}`, `{"type":"array","items":{"type":"number"}}`, true},
+		{"wider items", `{"type":"array","items":{"type":"number"}}`, `{"type":"array","items":{"type":"integer"}}`, false},
+		{"unique items", `{"type":"array","items":{}}`, `{"type":"array","items":{},"uniqueItems":true}`, false},
+		{"one of in any of", `{"oneOf":[{"type":"string"},{"type":"integer"}]}`, `{"anyOf":[{"type":"integer"},{"type":"string"},{"type":"boolean"}]}`, true},
+		{"any of in one of", `{"anyOf":[{"type":"string"},{"type":"integer"}]}`, `{"oneOf":[{"type":"integer"},{"type":"string"}]}`, true},
+		{"one of not disjoint", `{"type":"integer"}`, `{"oneOf":[{"type":"number"},{"type":"integer"}]}`, false},
+		{"one of removed", `{"oneOf":[{"type":"string"},{"type":"integer"}]}`, `{"oneOf":[{"type":"string"}]}`, false},
+		{"all of member", `{"allOf":[{"type":"string","maxLength":5},{"minLength":1}]}`, `{"type":"string","maxLength":10}`, true},
+		{"all of in all of", `{"type":"string","minLength":2,"maxLength":5}`, `{"allOf":[{"type":"string","maxLength":10},{"minLength":1}]}`, true},
+		{"not disjoint", `{"type":"string"}`, `{"not":{"type":"integer"}}`, true},
+		{"not overlapping", `{"type":"number"}`, `{"not":{"type":"integer"}}`, false},
+		{"other keywords", `{"type":"object"}`, `{"type":"object","propertyNames":{"pattern":"^a"}}`, false},
+		{"same other keywords", `{"type":"object","propertyNames":{"pattern":"^a"}}`, `{"type":"object","propertyNames":{"pattern":"^a"}}`, true},
+		{"annotations", `{"type":"string","maxLength":5}`, `{"type":"string","description":"a string","title":"String"}`, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, diff.IsSubschema(newSchemaRef(t, test.a), newSchemaRef(t, test.b)))
+		})
+	}
+}
+
+func TestIsSubschema_Nil(t *testing.T) {
+	require.True(t, diff.IsSubschema(newSchemaRef(t, `{"type":"string"}`), nil))
+	require.False(t, diff.IsSubschema(nil, newSchemaRef(t, `{"type":"string"}`)))
+}
+
+func TestIsSubschema_Recursive(t *testing.T) {
+	node := func(valueType string) *openapi3.SchemaRef {
+		schemaRef := newSchemaRef(t, `{"type":"object","properties":{"value":{"type":"`+valueType+`"}}}`)
+		schemaRef.Value.Properties["next"] = &openapi3.SchemaRef{Ref: "#/components/schemas/Node", Value: schemaRef.Value}
+		return schemaRef
+	}
+
+	require.True(t, diff.IsSubschema(node("integer"), node("number")))
+	require.False(t, diff.IsSubschema(node("number"), node("integer")))
+}
+
+func TestIsDisjoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{"different types", `{"type":"string"}`, `{"type":"integer"}`, true},
+		{"integer and number", `{"type":"number"}`, `{"type":"integer"}`, false},
+		{"both nullable", `{"type":"string","nullable":true}`, `{"type":"integer","nullable":true}`, false},
+		{"different enums", `{"enum":["a"]}`, `{"enum":["b"]}`, true},
+		{"enum in type", `{"enum":["a"]}`, `{"type":"string"}`, false},
+		{"no type", `{}`, `{"type":"string"}`, false},
+		{"same type", `{"type":"object","required":["a"]}`, `{"type":"object","required":["b"]}`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, diff.IsDisjoint(newSchemaRef(t, test.a), newSchemaRef(t, test.b)))
+		})
+	}
+}
@@ -51,6 +51,9 @@ const (
 
 	// Special
 	EndpointsDetail DetailName = "endpoints"
+
+	// AsyncAPI
+	ChannelsDetail DetailName = "channels"
 )
 
 // GetSummaryDetails returns the summary for a specific part
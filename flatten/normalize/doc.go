@@ -0,0 +1,54 @@
+/*
+Package normalize rewrites schemas into a canonical form, so that equivalent
+schemas written in different ways compare as equal.
+
+# Overview
+
+Refactoring a spec often changes how a schema is written without changing what
+it accepts: a single-value enum becomes a const, a nullable type is written the
+OpenAPI 3.1 way, or a schema is wrapped in an allOf to add a description to a
+$ref. Without normalization, oasdiff reports each of these as a change, and
+some of them as breaking changes.
+
+# Usage
+
+Normalize a spec:
+
+	normalize.Spec(spec)
+
+Or use via the load package option:
+
+	specInfo, err := load.NewSpecInfo(loader, source, load.WithNormalize())
+
+# Rules
+
+Every schema in the spec is rewritten in place:
+  - A const without an enum becomes an enum with a single value
+  - A type array with "null" and other types becomes the other types with nullable: true
+  - An allOf with a single subschema and no other keywords, except annotations, is replaced by the subschema, keeping the annotations
+  - The subschemas of oneOf and anyOf are sorted by their content
+
+# Example
+
+Before:
+
+	name:
+	  description: the name of the pet
+	  allOf:
+	    - $ref: '#/components/schemas/Name'
+	kind:
+	  type: [string, "null"]
+	  const: dog
+
+After:
+
+	name:
+	  description: the name of the pet
+	  type: string
+	  maxLength: 20
+	kind:
+	  type: string
+	  nullable: true
+	  enum: [dog]
+*/
+package normalize
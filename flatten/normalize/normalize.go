@@ -0,0 +1,207 @@
+package normalize
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Spec normalizes every schema in the spec in place.
+// Subschemas are sorted in a second pass, after their content was normalized.
+func Spec(spec *openapi3.T) {
+	_ = spec.WalkSchemas(func(_ string, schemaRef *openapi3.SchemaRef) error {
+		Schema(schemaRef.Value)
+		return nil
+	})
+
+	_ = spec.WalkSchemas(func(_ string, schemaRef *openapi3.SchemaRef) error {
+		sortSubschemas(schemaRef.Value.OneOf)
+		sortSubschemas(schemaRef.Value.AnyOf)
+		return nil
+	})
+}
+
+// Schema normalizes a single schema in place, without its subschemas
+func Schema(schema *openapi3.Schema) {
+	collapseAllOf(schema, map[*openapi3.Schema]struct{}{})
+	constToEnum(schema)
+	nullableType(schema)
+}
+
+// collapseAllOf replaces an allOf with a single subschema by the subschema.
+// Nested single-subschema allOfs are collapsed first, guarding against cycles.
+func collapseAllOf(schema *openapi3.Schema, visited map[*openapi3.Schema]struct{}) {
+	if len(schema.AllOf) != 1 || schema.AllOf[0] == nil || schema.AllOf[0].Value == nil {
+		return
+	}
+
+	if _, ok := visited[schema]; ok {
+		return
+	}
+	visited[schema] = struct{}{}
+
+	if !hasOnlyAnnotations(schema) {
+		return
+	}
+
+	subschema := schema.AllOf[0].Value
+	collapseAllOf(subschema, visited)
+
+	result := *subschema
+	result.Origin = schema.Origin
+	annotate(&result, schema)
+	*schema = result
+}
+
+// hasOnlyAnnotations returns true if the schema has no keywords other than allOf and annotations
+func hasOnlyAnnotations(schema *openapi3.Schema) bool {
+	other := *schema
+	other.AllOf = nil
+	other.Extensions = nil
+	other.Origin = nil
+	other.Title = ""
+	other.Description = ""
+	other.Default = nil
+	other.Example = nil
+	other.Examples = nil
+	other.ExternalDocs = nil
+	other.Deprecated = false
+	other.ReadOnly = false
+	other.WriteOnly = false
+
+	data, err := json.Marshal(&other)
+	return err == nil && string(data) == "{}"
+}
+
+// annotate copies the annotations of the schema to the result, overriding those of the result
+func annotate(result, schema *openapi3.Schema) {
+	if schema.Title != "" {
+		result.Title = schema.Title
+	}
+	if schema.Description != "" {
+		result.Description = schema.Description
+	}
+	if schema.Default != nil {
+		result.Default = schema.Default
+	}
+	if schema.Example != nil {
+		result.Example = schema.Example
+	}
+	if schema.Examples != nil {
+		result.Examples = schema.Examples
+	}
+	if schema.ExternalDocs != nil {
+		result.ExternalDocs = schema.ExternalDocs
+	}
+	result.Deprecated = result.Deprecated || schema.Deprecated
+	result.ReadOnly = result.ReadOnly || schema.ReadOnly
+	result.WriteOnly = result.WriteOnly || schema.WriteOnly
+
+	if len(schema.Extensions) > 0 {
+		extensions := maps.Clone(result.Extensions)
+		if extensions == nil {
+			extensions = map[string]any{}
+		}
+		maps.Copy(extensions, schema.Extensions)
+		result.Extensions = extensions
+	}
+}
+
+// constToEnum replaces a const by an enum with a single value
+func constToEnum(schema *openapi3.Schema) {
+	if schema.Const == nil || len(schema.Enum) > 0 {
+		return
+	}
+
+	schema.Enum = []any{schema.Const}
+	schema.Const = nil
+}
+
+// nullableType replaces "null" in a type array with other types by nullable: true
+func nullableType(schema *openapi3.Schema) {
+	if schema.Type == nil || len(*schema.Type) < 2 || !schema.Type.IncludesNull() {
+		return
+	}
+
+	types := openapi3.Types{}
+	for _, t := range *schema.Type {
+		if t != openapi3.TypeNull {
+			types = append(types, t)
+		}
+	}
+	schema.Type = &types
+	schema.Nullable = true
+}
+
+// sortSubschemas sorts subschemas by their content, regardless of whether they are inline or referenced
+func sortSubschemas(schemaRefs openapi3.SchemaRefs) {
+	if len(schemaRefs) < 2 {
+		return
+	}
+
+	keys := make(map[*openapi3.SchemaRef]string, len(schemaRefs))
+	for _, schemaRef := range schemaRefs {
+		keys[schemaRef] = getKey(schemaRef)
+	}
+
+	slices.SortStableFunc(schemaRefs, func(a, b *openapi3.SchemaRef) int {
+		return strings.Compare(keys[a], keys[b])
+	})
+}
+
+// getKey returns the content of the schema as JSON, with nested subschemas of oneOf and anyOf in sorted order
+func getKey(schemaRef *openapi3.SchemaRef) string {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(schemaRef.Value)
+	if err != nil {
+		return ""
+	}
+
+	var content any
+	if err := json.Unmarshal(data, &content); err != nil {
+		return ""
+	}
+
+	data, err = json.Marshal(sortContent(content))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// sortContent sorts the oneOf and anyOf lists in generic JSON content
+func sortContent(content any) any {
+	switch content := content.(type) {
+	case map[string]any:
+		for key, value := range content {
+			content[key] = sortContent(value)
+			if key != "oneOf" && key != "anyOf" {
+				continue
+			}
+			if list, ok := content[key].([]any); ok {
+				slices.SortStableFunc(list, func(a, b any) int {
+					return strings.Compare(marshal(a), marshal(b))
+				})
+			}
+		}
+	case []any:
+		for i, value := range content {
+			content[i] = sortContent(value)
+		}
+	}
+	return content
+}
+
+func marshal(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,128 @@
+package normalize_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/oasdiff/oasdiff/flatten/normalize"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize_ConstToEnum(t *testing.T) {
+	schema := &openapi3.Schema{Const: "dog"}
+	normalize.Schema(schema)
+	require.Nil(t, schema.Const)
+	require.Equal(t, []any{"dog"}, schema.Enum)
+}
+
+func TestNormalize_ConstWithEnum(t *testing.T) {
+	schema := &openapi3.Schema{Const: "dog", Enum: []any{"dog", "cat"}}
+	normalize.Schema(schema)
+	require.Equal(t, "dog", schema.Const)
+	require.Equal(t, []any{"dog", "cat"}, schema.Enum)
+}
+
+func TestNormalize_NullableType(t *testing.T) {
+	schema := &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeNull, openapi3.TypeString}}
+	normalize.Schema(schema)
+	require.True(t, schema.Nullable)
+	require.Equal(t, &openapi3.Types{openapi3.TypeString}, schema.Type)
+}
+
+func TestNormalize_NullType(t *testing.T) {
+	schema := &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeNull}}
+	normalize.Schema(schema)
+	require.False(t, schema.Nullable)
+	require.Equal(t, &openapi3.Types{openapi3.TypeNull}, schema.Type)
+}
+
+func TestNormalize_SingleAllOf(t *testing.T) {
+	name := &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}, Description: "a name", Title: "Name"}
+	schema := &openapi3.Schema{
+		Description: "the name of the pet",
+		AllOf:       openapi3.SchemaRefs{{Ref: "#/components/schemas/Name", Value: name}},
+	}
+	normalize.Schema(schema)
+	require.Empty(t, schema.AllOf)
+	require.Equal(t, &openapi3.Types{openapi3.TypeString}, schema.Type)
+	require.Equal(t, "the name of the pet", schema.Description)
+	require.Equal(t, "Name", schema.Title)
+	require.Equal(t, "a name", name.Description)
+}
+
+func TestNormalize_SingleAllOfWithKeywords(t *testing.T) {
+	schema := &openapi3.Schema{
+		Nullable: true,
+		AllOf:    openapi3.SchemaRefs{{Value: &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}}}},
+	}
+	normalize.Schema(schema)
+	require.Len(t, schema.AllOf, 1)
+	require.Nil(t, schema.Type)
+}
+
+func TestNormalize_SingleAllOfCycle(t *testing.T) {
+	schema := &openapi3.Schema{}
+	schema.AllOf = openapi3.SchemaRefs{{Ref: "#/components/schemas/Self", Value: schema}}
+	normalize.Schema(schema)
+	require.Len(t, schema.AllOf, 1)
+}
+
+func TestNormalize_SortSubschemas(t *testing.T) {
+	integer := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeInteger}}}
+	str := &openapi3.SchemaRef{Ref: "#/components/schemas/Str", Value: &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}}}
+
+	spec := &openapi3.T{
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{
+				"Value": {Value: &openapi3.Schema{OneOf: openapi3.SchemaRefs{str, integer}}},
+			},
+		},
+	}
+	normalize.Spec(spec)
+	require.Equal(t, openapi3.SchemaRefs{integer, str}, spec.Components.Schemas["Value"].Value.OneOf)
+}
+
+func loadSpec(t *testing.T, path string, normalized bool) *load.SpecInfo {
+	t.Helper()
+	specInfo, err := load.NewSpecInfo(openapi3.NewLoader(), load.NewSource(path), load.GetOption(load.WithNormalize(), normalized))
+	require.NoError(t, err)
+	return specInfo
+}
+
+// TestNormalize_Equivalence checks that the pairs of specs in the corpus have no differences in their paths once normalized
+func TestNormalize_Equivalence(t *testing.T) {
+	bases, err := filepath.Glob("../../data/normalize/*_base.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, bases)
+
+	for _, base := range bases {
+		revision := strings.TrimSuffix(base, "_base.yaml") + "_revision.yaml"
+		t.Run(filepath.Base(base), func(t *testing.T) {
+			s1, s2 := loadSpec(t, base, true), loadSpec(t, revision, true)
+			d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+			require.NoError(t, err)
+			if d != nil {
+				require.Nil(t, d.PathsDiff)
+			}
+			require.Empty(t, checker.CheckBackwardCompatibility(checker.NewConfig(checker.GetAllChecks()), d, osm))
+		})
+	}
+}
+
+// TestNormalize_NotEquivalentWithoutNormalize checks that the corpus needs normalization
+func TestNormalize_NotEquivalentWithoutNormalize(t *testing.T) {
+	for _, name := range []string{"const_enum", "single_all_of", "nullable_type_array"} {
+		t.Run(name, func(t *testing.T) {
+			s1 := loadSpec(t, "../../data/normalize/"+name+"_base.yaml", false)
+			s2 := loadSpec(t, "../../data/normalize/"+name+"_revision.yaml", false)
+			d, err := diff.Get(diff.NewConfig(), s1.Spec, s2.Spec)
+			require.NoError(t, err)
+			require.NotNil(t, d.PathsDiff)
+		})
+	}
+}
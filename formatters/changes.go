@@ -1,23 +1,29 @@
 package formatters
 
 import (
+	"strings"
+
 	"github.com/oasdiff/oasdiff/checker"
 )
 
 type Change struct {
-	Id             string          `json:"id,omitempty" yaml:"id,omitempty"`
-	Text           string          `json:"text,omitempty" yaml:"text,omitempty"`
-	Comment        string          `json:"comment,omitempty" yaml:"comment,omitempty"`
-	Level          checker.Level   `json:"level" yaml:"level"`
-	Operation      string          `json:"operation,omitempty" yaml:"operation,omitempty"`
-	OperationId    string          `json:"operationId,omitempty" yaml:"operationId,omitempty"`
-	Path           string          `json:"path,omitempty" yaml:"path,omitempty"`
-	Section        string          `json:"section,omitempty" yaml:"section,omitempty"`
-	IsBreaking     bool            `json:"-" yaml:"-"`
-	Attributes     map[string]any  `json:"attributes,omitempty" yaml:"attributes,omitempty"`
-	BaseSource     *checker.Source `json:"baseSource,omitempty" yaml:"baseSource,omitempty"`
-	RevisionSource *checker.Source `json:"revisionSource,omitempty" yaml:"revisionSource,omitempty"`
-	Fingerprint    string          `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+	Id             string                `json:"id,omitempty" yaml:"id,omitempty"`
+	Text           string                `json:"text,omitempty" yaml:"text,omitempty"`
+	Comment        string                `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Level          checker.Level         `json:"level" yaml:"level"`
+	Operation      string                `json:"operation,omitempty" yaml:"operation,omitempty"`
+	OperationId    string                `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Path           string                `json:"path,omitempty" yaml:"path,omitempty"`
+	Section        string                `json:"section,omitempty" yaml:"section,omitempty"`
+	IsBreaking     bool                  `json:"-" yaml:"-"`
+	Note           string                `json:"-" yaml:"-"` // the change's history in a history report, or its consumers and usage
+	Attributes     map[string]any        `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	BaseSource     *checker.Source       `json:"baseSource,omitempty" yaml:"baseSource,omitempty"`
+	RevisionSource *checker.Source       `json:"revisionSource,omitempty" yaml:"revisionSource,omitempty"`
+	Fingerprint    string                `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+	Consumers      []string              `json:"consumers,omitempty" yaml:"consumers,omitempty"`
+	UsageCount     *int64                `json:"usageCount,omitempty" yaml:"usageCount,omitempty"`
+	Verification   *checker.Verification `json:"verification,omitempty" yaml:"verification,omitempty"`
 }
 
 type Changes []Change
@@ -41,7 +47,31 @@ func NewChanges(originalChanges checker.Changes, l checker.Localizer) Changes {
 			BaseSource:     change.GetBaseSource(),
 			RevisionSource: change.GetRevisionSource(),
 			Fingerprint:    checker.Fingerprint(change),
+			Consumers:      checker.GetConsumers(change),
+			UsageCount:     checker.GetUsageCount(change),
+			Verification:   checker.GetVerification(change),
 		}
 	}
 	return changes
 }
+
+// changeNote names the consumers that the change affects, the number of calls
+// to its endpoint and its counterexample, or returns "" when neither consumer
+// usage nor traffic were given, and the change wasn't verified
+func changeNote(change checker.Change, l checker.Localizer) string {
+	notes := []string{}
+	if consumers := checker.GetConsumers(change); len(consumers) > 0 {
+		notes = append(notes, l("affected-consumers", strings.Join(consumers, ", ")))
+	}
+	if count := checker.GetUsageCount(change); count != nil {
+		notes = append(notes, l("usage-count", *count))
+	}
+	if verification := checker.GetVerification(change); verification != nil {
+		if verification.Confirmed {
+			notes = append(notes, l("verification-confirmed", verification.In, verification.Counterexample, verification.Reason))
+		} else {
+			notes = append(notes, l("verification-unconfirmed", verification.In))
+		}
+	}
+	return strings.Join(notes, "; ")
+}
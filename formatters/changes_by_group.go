@@ -15,27 +15,33 @@ func GroupChanges(changes checker.Changes, l checker.Localizer) ChangesByGroup {
 	result := ChangesByGroup{}
 
 	for _, change := range changes {
-		var group ChangeGroup
-
-		switch change.(type) {
-		case checker.ApiChange:
-			group = ChangeGroup{Section: change.GetSection(), Path: change.GetPath(), Operation: change.GetOperation()}
-		default:
-			group = ChangeGroup{Section: change.GetSection()}
-		}
-
-		changeEntry := Change{
+		result.add(change, Change{
 			IsBreaking: change.IsBreaking(),
 			Text:       change.GetUncolorizedText(l),
 			Comment:    change.GetComment(l),
-		}
-
-		if c, ok := result[group]; ok {
-			*c = append(*c, changeEntry)
-		} else {
-			result[group] = &Changes{changeEntry}
-		}
+			Note:       changeNote(change, l),
+		})
 	}
 
 	return result
 }
+
+// add appends the entry to the group of the change it was made from.
+func (result ChangesByGroup) add(change checker.Change, changeEntry Change) {
+	var group ChangeGroup
+
+	switch change.(type) {
+	case checker.ApiChange:
+		group = ChangeGroup{Section: change.GetSection(), Path: change.GetPath(), Operation: change.GetOperation()}
+	case checker.SchemaChange:
+		group = ChangeGroup{Section: change.GetSection(), Path: change.GetPath()}
+	default:
+		group = ChangeGroup{Section: change.GetSection()}
+	}
+
+	if c, ok := result[group]; ok {
+		*c = append(*c, changeEntry)
+	} else {
+		result[group] = &Changes{changeEntry}
+	}
+}
@@ -29,6 +29,11 @@ var changes = checker.Changes{
 		Id:    "security-added",
 		Level: checker.INFO,
 	},
+	checker.SchemaChange{
+		Id:      "request-property-removed",
+		Level:   checker.WARN,
+		Pointer: "#/properties/note",
+	},
 }
 
 func TestChanges_Group(t *testing.T) {
@@ -36,4 +41,5 @@ func TestChanges_Group(t *testing.T) {
 	require.Contains(t, grouped, formatters.ChangeGroup{Section: "paths", Path: "/test", Operation: "GET"})
 	require.Contains(t, grouped, formatters.ChangeGroup{Section: "components"})
 	require.Contains(t, grouped, formatters.ChangeGroup{Section: "security"})
+	require.Contains(t, grouped, formatters.ChangeGroup{Section: "schema", Path: "#/properties/note"})
 }
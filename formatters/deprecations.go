@@ -0,0 +1,134 @@
+package formatters
+
+import (
+	"fmt"
+
+	"github.com/oasdiff/oasdiff/checker"
+)
+
+// Deprecation is a deprecated endpoint, parameter or property (see checker.Deprecation)
+type Deprecation struct {
+	Kind          string          `json:"kind" yaml:"kind"`
+	Operation     string          `json:"operation" yaml:"operation"`
+	Path          string          `json:"path" yaml:"path"`
+	OperationId   string          `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	In            string          `json:"in,omitempty" yaml:"in,omitempty"`
+	Status        string          `json:"status,omitempty" yaml:"status,omitempty"`
+	MediaType     string          `json:"mediaType,omitempty" yaml:"mediaType,omitempty"`
+	Name          string          `json:"name,omitempty" yaml:"name,omitempty"`
+	Stability     string          `json:"stability,omitempty" yaml:"stability,omitempty"`
+	Sunset        string          `json:"sunset,omitempty" yaml:"sunset,omitempty"`
+	SunsetError   string          `json:"sunsetError,omitempty" yaml:"sunsetError,omitempty"`
+	DaysRemaining *int            `json:"daysRemaining,omitempty" yaml:"daysRemaining,omitempty"`
+	Overdue       bool            `json:"overdue,omitempty" yaml:"overdue,omitempty"`
+	Source        *checker.Source `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+type Deprecations []Deprecation
+
+func NewDeprecations(deprecations checker.Deprecations) Deprecations {
+	result := make(Deprecations, len(deprecations))
+	for i, d := range deprecations {
+		result[i] = Deprecation{
+			Kind:        string(d.Kind),
+			Operation:   d.Operation,
+			Path:        d.Path,
+			OperationId: d.OperationId,
+			In:          d.In,
+			Status:      d.Status,
+			MediaType:   d.MediaType,
+			Name:        d.Name,
+			Stability:   d.Stability,
+			SunsetError: d.SunsetError,
+			Overdue:     d.Overdue,
+			Source:      d.Source,
+		}
+		if d.Sunset != nil {
+			daysRemaining := d.DaysRemaining
+			result[i].Sunset = d.Sunset.String()
+			result[i].DaysRemaining = &daysRemaining
+		}
+	}
+	return result
+}
+
+// deprecatedElement describes what is deprecated in an operation, like "query parameter page"
+func deprecatedElement(d checker.Deprecation) string {
+	switch d.Kind {
+	case checker.DeprecatedParameter:
+		return fmt.Sprintf("%s parameter %s", d.In, d.Name)
+	case checker.DeprecatedProperty:
+		if d.Status != "" {
+			return fmt.Sprintf("%s property %s (status %s)", d.In, d.Name, d.Status)
+		}
+		return fmt.Sprintf("%s property %s", d.In, d.Name)
+	default:
+		return string(d.Kind)
+	}
+}
+
+// deprecationStatus is "overdue", "invalid sunset: <error>", "no sunset" or "" for a deprecation with a sunset to come
+func deprecationStatus(d checker.Deprecation) string {
+	switch {
+	case d.Overdue:
+		return "overdue"
+	case d.SunsetError != "":
+		return "invalid sunset: " + d.SunsetError
+	case d.Sunset == nil:
+		return "no sunset"
+	default:
+		return ""
+	}
+}
+
+func countOverdue(deprecations checker.Deprecations) int {
+	count := 0
+	for _, d := range deprecations {
+		if d.Overdue {
+			count++
+		}
+	}
+	return count
+}
+
+// DeprecationsTemplateData is the data of the markdown deprecations template
+type DeprecationsTemplateData struct {
+	AsOf         string
+	Deprecations []DeprecationData
+	Overdue      int
+}
+
+// DeprecationData is a deprecation in DeprecationsTemplateData
+type DeprecationData struct {
+	Operation     string
+	Path          string
+	Element       string
+	Sunset        string
+	DaysRemaining string
+	Stability     string
+	Status        string
+	Overdue       bool
+}
+
+func newDeprecationsTemplateData(deprecations checker.Deprecations, opts RenderOpts) DeprecationsTemplateData {
+	data := DeprecationsTemplateData{
+		AsOf:         opts.AsOf.String(),
+		Deprecations: make([]DeprecationData, len(deprecations)),
+		Overdue:      countOverdue(deprecations),
+	}
+	for i, d := range deprecations {
+		data.Deprecations[i] = DeprecationData{
+			Operation: d.Operation,
+			Path:      d.Path,
+			Element:   deprecatedElement(d),
+			Stability: d.Stability,
+			Status:    deprecationStatus(d),
+			Overdue:   d.Overdue,
+		}
+		if d.Sunset != nil {
+			data.Deprecations[i].Sunset = d.Sunset.String()
+			data.Deprecations[i].DaysRemaining = fmt.Sprint(d.DaysRemaining)
+		}
+	}
+	return data
+}
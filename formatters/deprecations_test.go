@@ -0,0 +1,134 @@
+package formatters_test
+
+import (
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/civil"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/formatters"
+	"github.com/stretchr/testify/require"
+)
+
+var testDeprecations = checker.Deprecations{
+	{
+		Kind:          checker.DeprecatedParameter,
+		Operation:     "GET",
+		Path:          "/pets",
+		OperationId:   "listPets",
+		In:            "query",
+		Name:          "page",
+		Stability:     checker.StabilityStable,
+		Sunset:        &civil.Date{Year: 2026, Month: 11, Day: 1},
+		DaysRemaining: 14,
+	},
+	{
+		Kind:          checker.DeprecatedEndpoint,
+		Operation:     "DELETE",
+		Path:          "/pets/{id}",
+		Sunset:        &civil.Date{Year: 2026, Month: 9, Day: 30},
+		DaysRemaining: -18,
+		Overdue:       true,
+	},
+	{
+		Kind:        checker.DeprecatedProperty,
+		Operation:   "POST",
+		Path:        "/pets",
+		In:          "request",
+		MediaType:   "application/json",
+		Name:        "nickname",
+		SunsetError: "sunset date doesn't conform with RFC3339: next month",
+	},
+}
+
+func getDeprecationsRenderOpts() formatters.RenderOpts {
+	opts := formatters.NewRenderOpts()
+	opts.AsOf = civil.Date{Year: 2026, Month: 10, Day: 18}
+	return opts
+}
+
+func TestJsonFormatter_RenderDeprecations(t *testing.T) {
+	out, err := jsonFormatter.RenderDeprecations(testDeprecations[:2], getDeprecationsRenderOpts())
+	require.NoError(t, err)
+	require.Equal(t, `[{"kind":"parameter","operation":"GET","path":"/pets","operationId":"listPets","in":"query","name":"page","stability":"stable","sunset":"2026-11-01","daysRemaining":14},{"kind":"endpoint","operation":"DELETE","path":"/pets/{id}","sunset":"2026-09-30","daysRemaining":-18,"overdue":true}]`, string(out))
+}
+
+func TestYamlFormatter_RenderDeprecations(t *testing.T) {
+	out, err := yamlFormatter.RenderDeprecations(testDeprecations[2:], getDeprecationsRenderOpts())
+	require.NoError(t, err)
+	require.Equal(t, "- kind: property\n  operation: POST\n  path: /pets\n  in: request\n  mediaType: application/json\n  name: nickname\n  sunsetError: 'sunset date doesn''t conform with RFC3339: next month'\n", string(out))
+}
+
+func TestTextFormatter_RenderDeprecations(t *testing.T) {
+	out, err := textFormatter.RenderDeprecations(testDeprecations, getDeprecationsRenderOpts())
+	require.NoError(t, err)
+	require.Equal(t, `3 deprecations as of 2026-10-18, 1 overdue
+
+OPERATION          DEPRECATED                 SUNSET      DAYS  STABILITY  STATUS
+GET /pets          query parameter page       2026-11-01  14    stable
+DELETE /pets/{id}  endpoint                   2026-09-30  -18              OVERDUE
+POST /pets         request property nickname                               invalid sunset: sunset date doesn't conform with RFC3339: next month
+`, string(out))
+}
+
+func TestTextFormatter_RenderDeprecationsEmpty(t *testing.T) {
+	out, err := textFormatter.RenderDeprecations(nil, getDeprecationsRenderOpts())
+	require.NoError(t, err)
+	require.Equal(t, "No deprecations as of 2026-10-18", string(out))
+}
+
+func TestMarkupFormatter_RenderDeprecations(t *testing.T) {
+	out, err := markupFormatter.RenderDeprecations(testDeprecations, getDeprecationsRenderOpts())
+	require.NoError(t, err)
+	require.Contains(t, string(out), "# Deprecations as of 2026-10-18\n")
+	require.Contains(t, string(out), "3 deprecations, 1 overdue")
+	require.Contains(t, string(out), "| GET /pets | query parameter page | 2026-11-01 | 14 | stable |  |\n")
+	require.Contains(t, string(out), "| DELETE /pets/{id} | endpoint | 2026-09-30 | -18 |  | :warning: overdue |\n")
+}
+
+func TestICSFormatter_RenderDeprecations(t *testing.T) {
+	f, err := formatters.Lookup(string(formatters.FormatICS), formatters.FormatterOpts{Title: "Pet Store"})
+	require.NoError(t, err)
+
+	out, err := f.RenderDeprecations(testDeprecations, getDeprecationsRenderOpts())
+	require.NoError(t, err)
+
+	lines := strings.Split(string(out), "\r\n")
+	require.Equal(t, "BEGIN:VCALENDAR", lines[0])
+	require.Contains(t, lines, "X-WR-CALNAME:Pet Store sunsets")
+	require.Contains(t, lines, "DTSTAMP:20261018T000000Z")
+	require.Contains(t, lines, "DTSTART;VALUE=DATE:20261101")
+	require.Contains(t, lines, "DTEND;VALUE=DATE:20261102")
+	require.Contains(t, lines, "SUMMARY:Sunset of the query parameter page of GET /pets")
+	require.Contains(t, lines, `DESCRIPTION:Operation ID: listPets\nStability: stable`)
+	require.Contains(t, lines, "SUMMARY:Sunset of DELETE /pets/{id}")
+	require.Contains(t, lines, "DESCRIPTION:Overdue by 18 days as of 2026-10-18")
+
+	// the property without a sunset date has no event
+	require.Equal(t, 2, strings.Count(string(out), "BEGIN:VEVENT"))
+	require.Equal(t, "END:VCALENDAR", lines[len(lines)-2])
+}
+
+func TestICSFormatter_Fold(t *testing.T) {
+	deprecation := testDeprecations[0]
+	deprecation.Name = strings.Repeat("é", 50)
+
+	f, err := formatters.Lookup(string(formatters.FormatICS), formatters.DefaultFormatterOpts())
+	require.NoError(t, err)
+
+	out, err := f.RenderDeprecations(checker.Deprecations{deprecation}, getDeprecationsRenderOpts())
+	require.NoError(t, err)
+
+	for _, line := range strings.Split(string(out), "\r\n") {
+		require.LessOrEqual(t, len(line), 75)
+	}
+	require.Contains(t, strings.ReplaceAll(string(out), "\r\n ", ""), "SUMMARY:Sunset of the query parameter "+deprecation.Name+" of GET /pets\r\n")
+}
+
+func TestICSFormatter_NotImplemented(t *testing.T) {
+	f, err := formatters.Lookup(string(formatters.FormatICS), formatters.DefaultFormatterOpts())
+	require.NoError(t, err)
+
+	_, err = f.RenderChangelog(nil, formatters.NewRenderOpts())
+	require.Error(t, err)
+}
@@ -23,6 +23,8 @@ Get a formatter and render output:
   - html: HTML format for web display
   - githubactions: GitHub Actions workflow command format (::error, ::warning)
   - junit: JUnit XML format for CI/CD test reporting
+  - sarif: SARIF 2.1.0 for code-scanning tools
+  - ics: iCalendar feed of deprecation sunset dates
 
 # Formatter Interface
 
@@ -32,6 +34,9 @@ All formatters implement the Formatter interface with methods:
   - RenderChangelog: render breaking changes and changelog
   - RenderChecks: render available check rules
   - RenderFlatten: render a flattened spec
+  - RenderValidate: render spec validation findings
+  - RenderHistory: render the changelog of a sequence of releases
+  - RenderDeprecations: render the deprecated elements of a spec and their sunset dates
 
 # Localization
 
@@ -41,6 +46,6 @@ Change messages are translated using the checker/localizations package.
 # Output Types
 
 Use SupportedOutputs() to check which output types a formatter supports:
-  - OutputDiff, OutputSummary, OutputChangelog, OutputChecks, OutputFlatten
+  - OutputDiff, OutputSummary, OutputChangelog, OutputChecks, OutputFlatten, OutputValidate, OutputHistory, OutputDeprecations
 */
 package formatters
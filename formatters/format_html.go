@@ -133,8 +133,21 @@ func ExecuteHtmlTemplate(tmpl *template.Template, changes ChangesByGroup, baseVe
 	return out.Bytes(), nil
 }
 
+//go:embed templates/history.html
+var historyHtml string
+
+func (f HTMLFormatter) RenderHistory(history checker.History, opts RenderOpts) ([]byte, error) {
+	tmpl := template.Must(template.New("history").Funcs(HtmlTemplateFuncs()).Parse(historyHtml))
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, newHistoryTemplateData(history, f.Localizer, f.BaseVersion, f.RevisionVersion)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
 func (f HTMLFormatter) SupportedOutputs() []Output {
-	return []Output{OutputDiff, OutputChangelog}
+	return []Output{OutputDiff, OutputChangelog, OutputHistory}
 }
 
 func (f HTMLFormatter) SupportsTemplate() bool {
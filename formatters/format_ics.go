@@ -0,0 +1,121 @@
+package formatters
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/oasdiff/oasdiff/checker"
+)
+
+// ICSFormatter renders deprecations as an iCalendar feed (RFC 5545) with an all-day event on each sunset date,
+// which calendar apps can subscribe to
+type ICSFormatter struct {
+	notImplementedFormatter
+	Title string
+}
+
+func newICSFormatter(title string) ICSFormatter {
+	return ICSFormatter{
+		Title: title,
+	}
+}
+
+// RenderDeprecations emits an event for each deprecation with a sunset date; those without one can't be scheduled.
+// The event's UID is derived from the deprecated element, so that a subscribed calendar updates the event when its
+// sunset date changes, rather than adding another one.
+func (f ICSFormatter) RenderDeprecations(deprecations checker.Deprecations, opts RenderOpts) ([]byte, error) {
+	result := bytes.NewBuffer(nil)
+
+	calendarName := "API sunsets"
+	if f.Title != "" {
+		calendarName = f.Title + " sunsets"
+	}
+
+	// DTSTAMP is the report date rather than the current time, so that the same report renders the same feed
+	stamp := icsDate(opts.AsOf.String()) + "T000000Z"
+
+	writeICSLine(result, "BEGIN:VCALENDAR")
+	writeICSLine(result, "VERSION:2.0")
+	writeICSLine(result, "PRODID:-//oasdiff//deprecations//EN")
+	writeICSLine(result, "CALSCALE:GREGORIAN")
+	writeICSLine(result, "X-WR-CALNAME:"+escapeICSText(calendarName))
+
+	for _, d := range deprecations {
+		if d.Sunset == nil {
+			continue
+		}
+
+		summary := "Sunset of " + d.Operation + " " + d.Path
+		if d.Kind != checker.DeprecatedEndpoint {
+			summary = "Sunset of the " + deprecatedElement(d) + " of " + d.Operation + " " + d.Path
+		}
+
+		description := []string{}
+		if d.OperationId != "" {
+			description = append(description, "Operation ID: "+d.OperationId)
+		}
+		if d.Stability != "" {
+			description = append(description, "Stability: "+d.Stability)
+		}
+		if d.Overdue {
+			description = append(description, fmt.Sprintf("Overdue by %d days as of %s", -d.DaysRemaining, opts.AsOf))
+		}
+
+		writeICSLine(result, "BEGIN:VEVENT")
+		writeICSLine(result, "UID:"+deprecationUID(d)+"@oasdiff")
+		writeICSLine(result, "DTSTAMP:"+stamp)
+		writeICSLine(result, "DTSTART;VALUE=DATE:"+icsDate(d.Sunset.String()))
+		writeICSLine(result, "DTEND;VALUE=DATE:"+icsDate(d.Sunset.AddDays(1).String()))
+		writeICSLine(result, "SUMMARY:"+escapeICSText(summary))
+		if len(description) > 0 {
+			writeICSLine(result, "DESCRIPTION:"+escapeICSText(strings.Join(description, "\n")))
+		}
+		writeICSLine(result, "TRANSP:TRANSPARENT")
+		writeICSLine(result, "END:VEVENT")
+	}
+
+	writeICSLine(result, "END:VCALENDAR")
+
+	return result.Bytes(), nil
+}
+
+func (f ICSFormatter) SupportedOutputs() []Output {
+	return []Output{OutputDeprecations}
+}
+
+// deprecationUID identifies a deprecated element, whatever its sunset date
+func deprecationUID(d checker.Deprecation) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{string(d.Kind), d.Operation, d.Path, d.In, d.Status, d.MediaType, d.Name}, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// icsDate converts a date like 2026-12-31 to the iCalendar form 20261231
+func icsDate(date string) string {
+	return strings.ReplaceAll(date, "-", "")
+}
+
+// escapeICSText escapes a TEXT value (RFC 5545, section 3.3.11)
+func escapeICSText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(text)
+}
+
+// writeICSLine writes a content line ending with CRLF, folded into lines of at most 75 octets
+// (RFC 5545, section 3.1) without splitting a UTF-8 character
+func writeICSLine(result *bytes.Buffer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		result.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// the continuation lines start with a space, which counts towards their length
+		limit = 74
+	}
+	result.WriteString(line + "\r\n")
+}
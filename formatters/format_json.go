@@ -45,8 +45,16 @@ func (f JSONFormatter) RenderValidate(findings Findings, opts RenderOpts) ([]byt
 	return printJSON(findings)
 }
 
+func (f JSONFormatter) RenderHistory(history checker.History, opts RenderOpts) ([]byte, error) {
+	return printJSON(NewHistory(history, f.Localizer))
+}
+
+func (f JSONFormatter) RenderDeprecations(deprecations checker.Deprecations, opts RenderOpts) ([]byte, error) {
+	return printJSON(NewDeprecations(deprecations))
+}
+
 func (f JSONFormatter) SupportedOutputs() []Output {
-	return []Output{OutputDiff, OutputSummary, OutputChangelog, OutputChecks, OutputFlatten, OutputValidate}
+	return []Output{OutputDiff, OutputSummary, OutputChangelog, OutputChecks, OutputFlatten, OutputValidate, OutputHistory, OutputDeprecations}
 }
 
 func printJSON(output any) ([]byte, error) {
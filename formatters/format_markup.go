@@ -78,8 +78,34 @@ func ExecuteTextTemplate(tmpl *template.Template, changes ChangesByGroup, baseVe
 	return out.Bytes(), nil
 }
 
+//go:embed templates/history.md
+var historyMarkdown string
+
+func (f MarkupFormatter) RenderHistory(history checker.History, opts RenderOpts) ([]byte, error) {
+	tmpl := template.Must(template.New("history").Funcs(MarkupTemplateFuncs()).Parse(historyMarkdown))
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, newHistoryTemplateData(history, f.Localizer, f.BaseVersion, f.RevisionVersion)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+//go:embed templates/deprecations.md
+var deprecationsMarkdown string
+
+func (f MarkupFormatter) RenderDeprecations(deprecations checker.Deprecations, opts RenderOpts) ([]byte, error) {
+	tmpl := template.Must(template.New("deprecations").Parse(deprecationsMarkdown))
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, newDeprecationsTemplateData(deprecations, opts)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
 func (f MarkupFormatter) SupportedOutputs() []Output {
-	return []Output{OutputDiff, OutputChangelog}
+	return []Output{OutputDiff, OutputChangelog, OutputHistory, OutputDeprecations}
 }
 
 func (f MarkupFormatter) SupportsTemplate() bool {
@@ -0,0 +1,358 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/oasdiff/oasdiff/checker"
+)
+
+// SARIF 2.1.0: https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// sarifFingerprintKey names the change fingerprint in partialFingerprints;
+	// the version suffix lets the computation change without clashing with stored values.
+	sarifFingerprintKey = "oasdiffFingerprint/v1"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id                   string               `json:"id"`
+	ShortDescription     *sarifMessage        `json:"shortDescription,omitempty"`
+	Help                 *sarifMessage        `json:"help,omitempty"`
+	DefaultConfiguration sarifConfiguration   `json:"defaultConfiguration"`
+	Properties           *sarifRuleProperties `json:"properties,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProperties struct {
+	Tags []string `json:"tags,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	RelatedLocations    []sarifLocation   `json:"relatedLocations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// ValidateRuleDescription describes a validate rule in the SARIF rules table.
+// The validate package sets it: it depends on formatters, so formatters can't
+// call it directly.
+var ValidateRuleDescription = func(id string) string { return "" }
+
+type SarifFormatter struct {
+	notImplementedFormatter
+	Localizer checker.Localizer
+}
+
+func newSarifFormatter(l checker.Localizer) SarifFormatter {
+	return SarifFormatter{
+		Localizer: l,
+	}
+}
+
+// RenderChangelog reports each change as a result located in the revision
+// spec, or in the base spec for a change that removed something. The rules
+// table lists the rules the results refer to.
+func (f SarifFormatter) RenderChangelog(changes checker.Changes, opts RenderOpts) ([]byte, error) {
+	allRules := map[string]checker.BackwardCompatibilityRule{}
+	for _, rule := range checker.GetAllRules() {
+		allRules[rule.Id] = rule
+	}
+
+	rules := newSarifRules()
+	results := make([]sarifResult, 0, len(changes))
+	for _, change := range changes {
+		id := change.GetId()
+		index := rules.add(id, func() sarifRule {
+			rule, ok := allRules[id]
+			if !ok {
+				return sarifRule{Id: id, DefaultConfiguration: sarifConfiguration{Level: sarifLevel(change.GetLevel())}}
+			}
+			return f.newChangelogRule(rule)
+		})
+
+		result := sarifResult{
+			RuleId:              id,
+			RuleIndex:           index,
+			Level:               sarifLevel(change.GetLevel()),
+			Message:             sarifMessage{Text: change.GetUncolorizedText(f.Localizer)},
+			PartialFingerprints: map[string]string{sarifFingerprintKey: checker.Fingerprint(change)},
+			Properties:          sarifProperties(change.GetOperation(), change.GetPath()),
+		}
+
+		base, revision := change.GetBaseSource(), change.GetRevisionSource()
+		switch {
+		case revision != nil:
+			result.Locations = []sarifLocation{newSarifLocation(revision.File, revision.Line, revision.Column, revision.EndLine, revision.EndColumn)}
+			if base != nil {
+				related := newSarifLocation(base.File, base.Line, base.Column, base.EndLine, base.EndColumn)
+				related.Message = &sarifMessage{Text: "base"}
+				result.RelatedLocations = []sarifLocation{related}
+			}
+		case base != nil:
+			result.Locations = []sarifLocation{newSarifLocation(base.File, base.Line, base.Column, base.EndLine, base.EndColumn)}
+		case change.GetSourceFile() != "":
+			result.Locations = []sarifLocation{newSarifLocation(change.GetSourceFile(), 0, 0, 0, 0)}
+		}
+
+		results = append(results, result)
+	}
+
+	return printSarif(rules.list, results)
+}
+
+func (f SarifFormatter) newChangelogRule(rule checker.BackwardCompatibilityRule) sarifRule {
+	result := sarifRule{
+		Id:                   rule.Id,
+		ShortDescription:     &sarifMessage{Text: f.Localizer(rule.Description)},
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Level)},
+		Properties: &sarifRuleProperties{
+			Tags: []string{rule.Direction.String(), rule.Area.String(), rule.Kind.String(), rule.Action.String()},
+		},
+	}
+
+	commentKey := rule.Id + "-comment"
+	if mitigation := f.Localizer(commentKey); mitigation != commentKey {
+		result.Help = &sarifMessage{Text: mitigation}
+	}
+
+	return result
+}
+
+// RenderChecks lists the rules with no results, so code-scanning tools can
+// import the rule descriptions.
+func (f SarifFormatter) RenderChecks(checks Checks, opts RenderOpts) ([]byte, error) {
+	rules := make([]sarifRule, 0, len(checks))
+	for _, check := range checks {
+		rule := sarifRule{
+			Id:                   check.Id,
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevelName(check.Level)},
+		}
+		if check.Description != "" {
+			rule.ShortDescription = &sarifMessage{Text: check.Description}
+		}
+		if check.Mitigation != "" {
+			rule.Help = &sarifMessage{Text: check.Mitigation}
+		}
+		if tags := nonEmpty(check.Direction, check.Area, check.Kind, check.Action); len(tags) > 0 {
+			rule.Properties = &sarifRuleProperties{Tags: tags}
+		}
+		rules = append(rules, rule)
+	}
+
+	return printSarif(rules, []sarifResult{})
+}
+
+// RenderValidate reports each finding as a result located in the spec.
+func (f SarifFormatter) RenderValidate(findings Findings, opts RenderOpts) ([]byte, error) {
+	rules := newSarifRules()
+	results := make([]sarifResult, 0, len(findings))
+	for _, finding := range findings {
+		index := rules.add(finding.Id, func() sarifRule {
+			rule := sarifRule{
+				Id:                   finding.Id,
+				DefaultConfiguration: sarifConfiguration{Level: sarifLevel(finding.Level)},
+			}
+			if description := ValidateRuleDescription(finding.Id); description != "" {
+				rule.ShortDescription = &sarifMessage{Text: description}
+			}
+			return rule
+		})
+
+		result := sarifResult{
+			RuleId:              finding.Id,
+			RuleIndex:           index,
+			Level:               sarifLevel(finding.Level),
+			Message:             sarifMessage{Text: finding.Text},
+			PartialFingerprints: map[string]string{sarifFingerprintKey: finding.Fingerprint},
+			Properties:          sarifProperties(finding.Operation, finding.Path),
+		}
+		if finding.Source.File != "" {
+			result.Locations = []sarifLocation{newSarifLocation(finding.Source.File, finding.Source.Line, finding.Source.Column, 0, 0)}
+		}
+
+		results = append(results, result)
+	}
+
+	return printSarif(rules.list, results)
+}
+
+func (f SarifFormatter) SupportedOutputs() []Output {
+	return []Output{OutputChangelog, OutputChecks, OutputValidate}
+}
+
+// sarifRules is the rules table of a run, in order of first use, so that a
+// result can refer to its rule by index.
+type sarifRules struct {
+	list    []sarifRule
+	indexes map[string]int
+}
+
+func newSarifRules() *sarifRules {
+	return &sarifRules{list: []sarifRule{}, indexes: map[string]int{}}
+}
+
+func (rules *sarifRules) add(id string, newRule func() sarifRule) int {
+	if index, ok := rules.indexes[id]; ok {
+		return index
+	}
+	rules.list = append(rules.list, newRule())
+	rules.indexes[id] = len(rules.list) - 1
+	return len(rules.list) - 1
+}
+
+func printSarif(rules []sarifRule, results []sarifResult) ([]byte, error) {
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "oasdiff",
+				InformationURI: "https://github.com/oasdiff/oasdiff",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	bytes, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sarif: %w", err)
+	}
+	return bytes, nil
+}
+
+func newSarifLocation(file string, line, column, endLine, endColumn int) sarifLocation {
+	location := sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: sarifURI(file)},
+		},
+	}
+	// SARIF regions are 1-based; a source without a line has no region
+	if line > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{
+			StartLine:   line,
+			StartColumn: column,
+			EndLine:     endLine,
+			EndColumn:   endColumn,
+		}
+	}
+	return location
+}
+
+// sarifURI turns a spec location into a URI reference: URLs are kept, paths
+// are made forward-slashed, and absolute paths become file URIs.
+func sarifURI(file string) string {
+	if strings.HasPrefix(file, "http://") || strings.HasPrefix(file, "https://") {
+		return file
+	}
+	path := filepath.ToSlash(file)
+	if filepath.IsAbs(file) {
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		return (&url.URL{Scheme: "file", Path: path}).String()
+	}
+	return (&url.URL{Path: path}).String()
+}
+
+func sarifLevel(level checker.Level) string {
+	switch level {
+	case checker.ERR:
+		return "error"
+	case checker.WARN:
+		return "warning"
+	case checker.INFO:
+		return "note"
+	default:
+		return "none"
+	}
+}
+
+// sarifLevelName maps the level names of a checks listing (see Level.String).
+func sarifLevelName(level string) string {
+	for _, l := range []checker.Level{checker.ERR, checker.WARN, checker.INFO} {
+		if level == l.String() {
+			return sarifLevel(l)
+		}
+	}
+	return "none"
+}
+
+func sarifProperties(operation, path string) map[string]string {
+	properties := map[string]string{}
+	if operation != "" {
+		properties["operation"] = operation
+	}
+	if path != "" {
+		properties["path"] = path
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return properties
+}
+
+func nonEmpty(values ...string) []string {
+	return slices.DeleteFunc(values, func(value string) bool { return value == "" })
+}
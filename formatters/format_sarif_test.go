@@ -0,0 +1,163 @@
+package formatters_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/formatters"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/stretchr/testify/require"
+)
+
+var sarifFormatter = formatters.SarifFormatter{
+	Localizer: MockLocalizer,
+}
+
+func parseSarif(t *testing.T, output []byte) map[string]any {
+	t.Helper()
+
+	var log map[string]any
+	require.NoError(t, json.Unmarshal(output, &log))
+	require.Equal(t, "2.1.0", log["version"])
+	runs := log["runs"].([]any)
+	require.Len(t, runs, 1)
+	return runs[0].(map[string]any)
+}
+
+func getSarifRules(run map[string]any) []any {
+	return run["tool"].(map[string]any)["driver"].(map[string]any)["rules"].([]any)
+}
+
+func TestSarifLookup(t *testing.T) {
+	f, err := formatters.Lookup(string(formatters.FormatSarif), formatters.DefaultFormatterOpts())
+	require.NoError(t, err)
+	require.IsType(t, formatters.SarifFormatter{}, f)
+}
+
+func TestSarifFormatter_RenderChangelog(t *testing.T) {
+	testChanges := checker.Changes{
+		checker.ApiChange{
+			Id:        "api-path-removed-without-deprecation",
+			Level:     checker.ERR,
+			Operation: http.MethodGet,
+			Path:      "/api/test",
+			Source:    load.NewSource("openapi.yaml"),
+			CommonChange: checker.CommonChange{
+				BaseSource: checker.NewSource("base.yaml", 10, 3),
+			},
+		},
+		checker.ApiChange{
+			Id:        "request-parameter-removed",
+			Level:     checker.WARN,
+			Args:      []any{"query", "a"},
+			Operation: http.MethodPost,
+			Path:      "/api/test",
+			Source:    load.NewSource("openapi.yaml"),
+			CommonChange: checker.CommonChange{
+				BaseSource:     checker.NewSource("base.yaml", 20, 5),
+				RevisionSource: checker.NewSource("specs/revision.yaml", 21, 5).WithEnd(22, 9),
+			},
+		},
+		checker.ApiChange{
+			Id:        "request-parameter-removed",
+			Level:     checker.WARN,
+			Args:      []any{"query", "b"},
+			Operation: http.MethodPost,
+			Path:      "/api/test",
+			Source:    load.NewSource("openapi.yaml"),
+		},
+	}
+
+	output, err := sarifFormatter.RenderChangelog(testChanges, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	run := parseSarif(t, output)
+
+	rules := getSarifRules(run)
+	require.Len(t, rules, 2)
+	require.Equal(t, "api-path-removed-without-deprecation", rules[0].(map[string]any)["id"])
+	require.Equal(t, "error", rules[0].(map[string]any)["defaultConfiguration"].(map[string]any)["level"])
+	require.Equal(t, "request-parameter-removed", rules[1].(map[string]any)["id"])
+
+	results := run["results"].([]any)
+	require.Len(t, results, 3)
+
+	removed := results[0].(map[string]any)
+	require.Equal(t, "error", removed["level"])
+	require.Equal(t, float64(0), removed["ruleIndex"])
+	require.Equal(t, checker.Fingerprint(testChanges[0]), removed["partialFingerprints"].(map[string]any)["oasdiffFingerprint/v1"])
+	location := removed["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)
+	require.Equal(t, "base.yaml", location["artifactLocation"].(map[string]any)["uri"])
+	require.Equal(t, float64(10), location["region"].(map[string]any)["startLine"])
+
+	// a change with both sources is located in the revision, with the base as a related location
+	parameter := results[1].(map[string]any)
+	require.Equal(t, "warning", parameter["level"])
+	require.Equal(t, float64(1), parameter["ruleIndex"])
+	location = parameter["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)
+	require.Equal(t, "specs/revision.yaml", location["artifactLocation"].(map[string]any)["uri"])
+	require.Equal(t, map[string]any{"startLine": float64(21), "startColumn": float64(5), "endLine": float64(22), "endColumn": float64(9)}, location["region"])
+	require.Len(t, parameter["relatedLocations"], 1)
+	require.Equal(t, map[string]any{"operation": "POST", "path": "/api/test"}, parameter["properties"])
+
+	// a change without a source location falls back to its spec file
+	location = results[2].(map[string]any)["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)
+	require.Equal(t, "openapi.yaml", location["artifactLocation"].(map[string]any)["uri"])
+	require.NotContains(t, location, "region")
+	require.Equal(t, float64(1), results[2].(map[string]any)["ruleIndex"])
+}
+
+func TestSarifFormatter_RenderChecks(t *testing.T) {
+	checks := formatters.Checks{
+		{Id: "change_id", Level: "info", Direction: "request", Area: "parameters", Description: "This is a breaking change."},
+		{Id: "validate_id", Level: "error"},
+	}
+
+	output, err := sarifFormatter.RenderChecks(checks, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	run := parseSarif(t, output)
+
+	require.Empty(t, run["results"])
+	rules := getSarifRules(run)
+	require.Len(t, rules, 2)
+	require.Equal(t, "note", rules[0].(map[string]any)["defaultConfiguration"].(map[string]any)["level"])
+	require.Equal(t, []any{"request", "parameters"}, rules[0].(map[string]any)["properties"].(map[string]any)["tags"])
+	require.Equal(t, "error", rules[1].(map[string]any)["defaultConfiguration"].(map[string]any)["level"])
+	require.NotContains(t, rules[1].(map[string]any), "properties")
+}
+
+func TestSarifFormatter_RenderValidate(t *testing.T) {
+	findings := formatters.Findings{
+		{Id: "info-version-required", Text: "info version is missing", Level: checker.ERR, Source: formatters.Source{File: "/tmp/openapi.yaml"}, Fingerprint: "0123456789ab"},
+		{Id: "example-violates-schema", Text: "invalid example", Level: checker.INFO, Operation: "GET", Path: "/a", Source: formatters.Source{File: "openapi.yaml", Line: 4, Column: 7}, Fingerprint: "ba9876543210"},
+	}
+
+	output, err := sarifFormatter.RenderValidate(findings, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	run := parseSarif(t, output)
+
+	require.Len(t, getSarifRules(run), 2)
+	results := run["results"].([]any)
+	require.Len(t, results, 2)
+
+	// a finding without a line has no region, and absolute paths become file URIs
+	location := results[0].(map[string]any)["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)
+	require.Equal(t, "file:///tmp/openapi.yaml", location["artifactLocation"].(map[string]any)["uri"])
+	require.NotContains(t, location, "region")
+
+	require.Equal(t, "note", results[1].(map[string]any)["level"])
+	require.Equal(t, "ba9876543210", results[1].(map[string]any)["partialFingerprints"].(map[string]any)["oasdiffFingerprint/v1"])
+}
+
+func TestSarifFormatter_NotImplemented(t *testing.T) {
+	var err error
+	_, err = sarifFormatter.RenderDiff(nil, formatters.NewRenderOpts())
+	require.Error(t, err)
+
+	_, err = sarifFormatter.RenderSummary(nil, formatters.NewRenderOpts())
+	require.Error(t, err)
+
+	_, err = sarifFormatter.RenderFlatten(nil, formatters.NewRenderOpts())
+	require.Error(t, err)
+}
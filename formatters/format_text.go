@@ -3,6 +3,8 @@ package formatters
 import (
 	"bytes"
 	"fmt"
+	"regexp"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/TwiN/go-color"
@@ -43,7 +45,11 @@ func (f TEXTFormatter) RenderChangelog(changes checker.Changes, opts RenderOpts)
 	_, _ = fmt.Fprint(result, getChangelogTitle(changes, f.Localizer, opts.ColorMode))
 
 	for _, c := range changes {
-		_, _ = fmt.Fprintf(result, "%s\n\n", c.MultiLineError(f.Localizer, opts.ColorMode))
+		_, _ = fmt.Fprint(result, c.MultiLineError(f.Localizer, opts.ColorMode))
+		if note := changeNote(c, f.Localizer); note != "" {
+			_, _ = fmt.Fprintf(result, "\n\t\t%s", note)
+		}
+		_, _ = fmt.Fprint(result, "\n\n")
 	}
 
 	return result.Bytes(), nil
@@ -119,6 +125,44 @@ func (f TEXTFormatter) RenderValidate(findings Findings, opts RenderOpts) ([]byt
 	return result.Bytes(), nil
 }
 
+var trailingSpaces = regexp.MustCompile(` +\n`)
+
+// RenderDeprecations emits a summary line followed by a table of the
+// deprecations, one row each, with their sunset date, the days remaining until
+// it, and a status column that flags overdue deprecations and missing or
+// invalid sunset dates.
+func (f TEXTFormatter) RenderDeprecations(deprecations checker.Deprecations, opts RenderOpts) ([]byte, error) {
+	result := bytes.NewBuffer(nil)
+
+	if len(deprecations) == 0 {
+		_, _ = fmt.Fprintf(result, "No deprecations as of %s", opts.AsOf)
+		return result.Bytes(), nil
+	}
+
+	_, _ = fmt.Fprintf(result, "%d deprecations as of %s, %d overdue\n\n", len(deprecations), opts.AsOf, countOverdue(deprecations))
+
+	w := tabwriter.NewWriter(result, 1, 1, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "OPERATION\tDEPRECATED\tSUNSET\tDAYS\tSTABILITY\tSTATUS")
+	for _, d := range deprecations {
+		sunset, days := "", ""
+		if d.Sunset != nil {
+			sunset, days = d.Sunset.String(), fmt.Sprint(d.DaysRemaining)
+		}
+		status := deprecationStatus(d)
+		if d.Overdue {
+			status = strings.ToUpper(status)
+			if checker.IsColorEnabled(opts.ColorMode) {
+				status = color.InRed(status)
+			}
+		}
+		_, _ = fmt.Fprintln(w, d.Operation+" "+d.Path+"\t"+deprecatedElement(d)+"\t"+sunset+"\t"+days+"\t"+d.Stability+"\t"+status)
+	}
+	_ = w.Flush()
+
+	// rows with an empty status end with the padding of the previous column
+	return trailingSpaces.ReplaceAll(result.Bytes(), []byte("\n")), nil
+}
+
 func (f TEXTFormatter) SupportedOutputs() []Output {
-	return []Output{OutputDiff, OutputChangelog, OutputChecks, OutputValidate}
+	return []Output{OutputDiff, OutputChangelog, OutputChecks, OutputValidate, OutputDeprecations}
 }
@@ -33,6 +33,21 @@ func TestTextFormatter_RenderChangelog(t *testing.T) {
 	require.Equal(t, "1 changes: 1 error, 0 warning, 0 info\nerror\t[change_id]\n\tin components/test\n\t\tThis is a breaking change.\n\n", string(out))
 }
 
+func TestTextFormatter_RenderChangelog_Consumers(t *testing.T) {
+	testChanges := checker.Changes{
+		checker.ComponentChange{
+			CommonChange: checker.CommonChange{Consumers: []string{"mobile", "web"}},
+			Id:           "change_id",
+			Level:        checker.ERR,
+			Component:    "test",
+		},
+	}
+
+	out, err := formatters.TEXTFormatter{Localizer: checker.NewDefaultLocalizer()}.RenderChangelog(testChanges, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Contains(t, string(out), "\n\t\taffects mobile, web\n\n")
+}
+
 func TestTextFormatter_RenderChecks(t *testing.T) {
 	checks := formatters.Checks{
 		{
@@ -45,8 +45,16 @@ func (f YAMLFormatter) RenderValidate(findings Findings, opts RenderOpts) ([]byt
 	return printYAML(findings)
 }
 
+func (f YAMLFormatter) RenderHistory(history checker.History, opts RenderOpts) ([]byte, error) {
+	return printYAML(NewHistory(history, f.Localizer))
+}
+
+func (f YAMLFormatter) RenderDeprecations(deprecations checker.Deprecations, opts RenderOpts) ([]byte, error) {
+	return printYAML(NewDeprecations(deprecations))
+}
+
 func (f YAMLFormatter) SupportedOutputs() []Output {
-	return []Output{OutputDiff, OutputSummary, OutputChangelog, OutputChecks, OutputFlatten, OutputValidate}
+	return []Output{OutputDiff, OutputSummary, OutputChangelog, OutputChecks, OutputFlatten, OutputValidate, OutputHistory, OutputDeprecations}
 }
 
 func printYAML(output any) ([]byte, error) {
@@ -0,0 +1,125 @@
+package formatters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oasdiff/oasdiff/checker"
+)
+
+// HistoryChange is a change of a release, with the releases it relates to (see checker.HistoryChange)
+type HistoryChange struct {
+	Change     `yaml:",inline"`
+	Introduced string `json:"introduced,omitempty" yaml:"introduced,omitempty"`
+	Reverts    string `json:"reverts,omitempty" yaml:"reverts,omitempty"`
+	RevertedIn string `json:"revertedIn,omitempty" yaml:"revertedIn,omitempty"`
+}
+
+// HistoryRelease is a release and the changes from the release before it
+type HistoryRelease struct {
+	Version string          `json:"version,omitempty" yaml:"version,omitempty"`
+	Source  string          `json:"source,omitempty" yaml:"source,omitempty"`
+	Changes []HistoryChange `json:"changes" yaml:"changes"`
+}
+
+type HistoryReleases []HistoryRelease
+
+func NewHistory(history checker.History, l checker.Localizer) HistoryReleases {
+	releases := make(HistoryReleases, len(history))
+	for i, release := range history {
+		changes := make([]HistoryChange, len(release.Changes))
+		for j, change := range newChanges(release.Changes, l) {
+			changes[j] = HistoryChange{
+				Change:     change,
+				Introduced: release.Changes[j].Introduced,
+				Reverts:    release.Changes[j].Reverts,
+				RevertedIn: release.Changes[j].RevertedIn,
+			}
+		}
+		releases[i] = HistoryRelease{
+			Version: release.Version,
+			Source:  release.Source,
+			Changes: changes,
+		}
+	}
+	return releases
+}
+
+func newChanges(historyChanges []checker.HistoryChange, l checker.Localizer) Changes {
+	changes := make(checker.Changes, len(historyChanges))
+	for i, change := range historyChanges {
+		changes[i] = change.Change
+	}
+	return NewChanges(changes, l)
+}
+
+// HistoryTemplateData is the data of the markdown and html history templates
+type HistoryTemplateData struct {
+	Releases        []HistoryReleaseData
+	BaseVersion     string
+	RevisionVersion string
+}
+
+// HistoryReleaseData is a release in HistoryTemplateData; the changes carry their history in Note
+type HistoryReleaseData struct {
+	Version        string
+	Source         string
+	GroupedChanges ChangesByGroup
+}
+
+// Title is the release's version or, if the spec has none, its source
+func (r HistoryReleaseData) Title() string {
+	if r.Version == "" {
+		return r.Source
+	}
+	return r.Version
+}
+
+func (t HistoryTemplateData) GetVersionTitle() string {
+	if t.BaseVersion == "" || t.RevisionVersion == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s to %s", t.BaseVersion, t.RevisionVersion)
+}
+
+func newHistoryTemplateData(history checker.History, l checker.Localizer, baseVersion, revisionVersion string) HistoryTemplateData {
+	releases := make([]HistoryReleaseData, len(history))
+	for i, release := range history {
+		grouped := ChangesByGroup{}
+		for _, change := range release.Changes {
+			grouped.add(change.Change, Change{
+				IsBreaking: change.IsBreaking(),
+				Text:       change.GetUncolorizedText(l),
+				Comment:    change.GetComment(l),
+				Note:       historyNote(change, release.Version, l),
+			})
+		}
+		releases[i] = HistoryReleaseData{
+			Version:        release.Version,
+			Source:         release.Source,
+			GroupedChanges: grouped,
+		}
+	}
+
+	return HistoryTemplateData{
+		Releases:        releases,
+		BaseVersion:     baseVersion,
+		RevisionVersion: revisionVersion,
+	}
+}
+
+// historyNote describes how a change relates to other releases, or returns "" for a change that is new and stays
+func historyNote(change checker.HistoryChange, version string, l checker.Localizer) string {
+	notes := []string{}
+	if change.Introduced != version {
+		notes = append(notes, l("history-reintroduced", change.Introduced))
+	}
+	if change.Reverts != "" {
+		notes = append(notes, l("history-reverts", change.Reverts))
+	}
+	if change.RevertedIn != "" {
+		notes = append(notes, l("history-reverted-in", change.RevertedIn))
+	}
+	return strings.Join(notes, "; ")
+}
@@ -0,0 +1,66 @@
+package formatters_test
+
+import (
+	"testing"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/formatters"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/stretchr/testify/require"
+)
+
+var testHistory = checker.History{
+	{
+		Version: "1.1.0",
+		Source:  "openapi-1.1.0.yaml",
+		Changes: []checker.HistoryChange{
+			{
+				Change: checker.ApiChange{
+					Id:        "change_id",
+					Level:     checker.ERR,
+					Operation: "GET",
+					Path:      "/test",
+					Source:    load.NewSource("openapi-1.1.0.yaml"),
+				},
+				Introduced: "1.0.0",
+				Reverts:    "1.0.1",
+			},
+		},
+	},
+	{
+		Version: "",
+		Source:  "openapi-1.2.0.yaml",
+	},
+}
+
+func TestJsonFormatter_RenderHistory(t *testing.T) {
+	out, err := jsonFormatter.RenderHistory(testHistory, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Equal(t, `[{"version":"1.1.0","source":"openapi-1.1.0.yaml","changes":[{"id":"change_id","text":"This is a breaking change.","level":3,"operation":"GET","path":"/test","section":"paths","fingerprint":"80a8c624dc40","introduced":"1.0.0","reverts":"1.0.1"}]},{"source":"openapi-1.2.0.yaml","changes":[]}]`, string(out))
+}
+
+func TestYamlFormatter_RenderHistory(t *testing.T) {
+	out, err := yamlFormatter.RenderHistory(testHistory, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Contains(t, string(out), "      introduced: 1.0.0\n      reverts: 1.0.1\n")
+	require.Contains(t, string(out), "    - id: change_id\n")
+}
+
+func TestMarkupFormatter_RenderHistory(t *testing.T) {
+	f := formatters.MarkupFormatter{Localizer: MockLocalizer, BaseVersion: "1.0.0", RevisionVersion: "1.2.0"}
+	out, err := f.RenderHistory(testHistory, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Contains(t, string(out), "# API History 1.0.0 to 1.2.0")
+	require.Contains(t, string(out), "## 1.1.0")
+	require.Contains(t, string(out), "### GET /test")
+	require.Contains(t, string(out), "- :warning: This is a breaking change. _(history-reintroduced; history-reverts)_")
+	require.Contains(t, string(out), "## openapi-1.2.0.yaml\n\nNo changes to report")
+}
+
+func TestHtmlFormatter_RenderHistory(t *testing.T) {
+	out, err := htmlFormatter.RenderHistory(testHistory, formatters.NewRenderOpts())
+	require.NoError(t, err)
+	require.Contains(t, string(out), `<div class="release-title">1.1.0</div>`)
+	require.Contains(t, string(out), `<span class="note">(history-reintroduced; history-reverts)</span>`)
+	require.Contains(t, string(out), "<p>No changes to report</p>")
+}
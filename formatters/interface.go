@@ -18,6 +18,8 @@ type Formatter interface {
 	RenderChecks(checks Checks, opts RenderOpts) ([]byte, error)
 	RenderFlatten(spec *openapi3.T, opts RenderOpts) ([]byte, error)
 	RenderValidate(findings Findings, opts RenderOpts) ([]byte, error)
+	RenderHistory(history checker.History, opts RenderOpts) ([]byte, error)
+	RenderDeprecations(deprecations checker.Deprecations, opts RenderOpts) ([]byte, error)
 	SupportedOutputs() []Output
 	SupportsTemplate() bool
 }
@@ -32,6 +34,8 @@ var formatters = map[Format]Formatter{
 	FormatHTML:          HTMLFormatter{},
 	FormatGithubActions: GitHubActionsFormatter{},
 	FormatJUnit:         JUnitFormatter{},
+	FormatSarif:         SarifFormatter{},
+	FormatICS:           ICSFormatter{},
 }
 
 // Lookup returns a formatter by its name
@@ -56,6 +60,10 @@ func Lookup(format string, opts FormatterOpts) (Formatter, error) {
 		return newGitHubActionsFormatter(l), nil
 	case FormatJUnit:
 		return newJUnitFormatter(l), nil
+	case FormatSarif:
+		return newSarifFormatter(l), nil
+	case FormatICS:
+		return newICSFormatter(opts.Title), nil
 	default:
 		return nil, fmt.Errorf("unsupported format: %s", f)
 	}
@@ -34,6 +34,14 @@ func (f notImplementedFormatter) RenderValidate(Findings, RenderOpts) ([]byte, e
 	return notImplemented()
 }
 
+func (f notImplementedFormatter) RenderHistory(checker.History, RenderOpts) ([]byte, error) {
+	return notImplemented()
+}
+
+func (f notImplementedFormatter) RenderDeprecations(checker.Deprecations, RenderOpts) ([]byte, error) {
+	return notImplemented()
+}
+
 func (f notImplementedFormatter) SupportsTemplate() bool {
 	return false
 }
@@ -9,4 +9,6 @@ const (
 	OutputChecks
 	OutputFlatten
 	OutputValidate
+	OutputHistory
+	OutputDeprecations
 )
@@ -22,6 +22,7 @@ func TestSupportsTemplateMethod(t *testing.T) {
 		{"singleline", false},
 		{"githubactions", false},
 		{"junit", false},
+		{"sarif", false},
 	}
 
 	for _, tc := range testCases {
@@ -2,6 +2,8 @@ package formatters
 
 import (
 	"fmt"
+
+	"cloud.google.com/go/civil"
 	"github.com/oasdiff/oasdiff/checker"
 )
 
@@ -18,6 +20,7 @@ const (
 	FormatGithubActions Format = "githubactions"
 	FormatJUnit         Format = "junit"
 	FormatSarif         Format = "sarif"
+	FormatICS           Format = "ics"
 )
 
 func GetSupportedFormats() []string {
@@ -32,6 +35,7 @@ func GetSupportedFormats() []string {
 		string(FormatGithubActions),
 		string(FormatJUnit),
 		string(FormatSarif),
+		string(FormatICS),
 	}
 }
 
@@ -40,15 +44,17 @@ type FormatterOpts struct {
 	Language        string
 	BaseVersion     string
 	RevisionVersion string
+	Title           string // the title of the spec, used to name an iCalendar feed
 }
 
 // RenderOpts can be used to pass properties to the renderer method
 type RenderOpts struct {
 	ColorMode    checker.ColorMode
-	WrapInObject bool   // wrap the output in a JSON object with the key "changes"
-	TemplatePath string // path to custom template file for changelog generation
-	DiffEmpty    bool   // true when the underlying diff found no changes at all
-	IsBreaking   bool   // true when invoked via `oasdiff breaking` (vs `changelog`); affects empty-result wording
+	WrapInObject bool       // wrap the output in a JSON object with the key "changes"
+	TemplatePath string     // path to custom template file for changelog generation
+	DiffEmpty    bool       // true when the underlying diff found no changes at all
+	IsBreaking   bool       // true when invoked via `oasdiff breaking` (vs `changelog`); affects empty-result wording
+	AsOf         civil.Date // the date that deprecations count the days until their sunset from
 }
 
 func NewRenderOpts() RenderOpts {
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// newFlagsFromArguments builds the Flags for one run of a command that was
+// requested by name rather than typed on the command line, such as an MCP tool
+// call or an HTTP request. The arguments are applied to the command's own flags,
+// so they are validated and defaulted exactly as on the command line, and the
+// config file at configPath (or the default lookup, when empty) is read as it
+// would be for the command itself.
+//
+// Arguments named in skip are left to the caller (e.g. the specs); those in
+// excluded, and hidden flags, are rejected as unknown.
+func newFlagsFromArguments(cmd *cobra.Command, configPath string, arguments map[string]any, skip []string, excluded map[string]bool) (*Flags, *ReturnError) {
+
+	// a command built per call has no root, so it inherits --config explicitly
+	cmd.PersistentFlags().String("config", configPath, "path to config file")
+
+	if err := setFlagsFromArguments(cmd, arguments, skip, excluded); err != nil {
+		return nil, getErrInvalidFlags(err)
+	}
+
+	flags := NewFlags()
+	if err := RunViper(cmd, flags.getViper()); err != nil {
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+func setFlagsFromArguments(cmd *cobra.Command, arguments map[string]any, skip []string, excluded map[string]bool) error {
+	flagSet := cmd.PersistentFlags()
+
+	for name, value := range arguments {
+		if slices.Contains(skip, name) {
+			continue
+		}
+
+		flag := flagSet.Lookup(name)
+		if flag == nil || flag.Hidden || excluded[name] {
+			return fmt.Errorf("unknown argument %q", name)
+		}
+
+		values, err := argumentToStrings(value)
+		if err != nil {
+			return fmt.Errorf("invalid argument %q: %w", name, err)
+		}
+
+		for _, v := range values {
+			if err := flagSet.Set(name, v); err != nil {
+				return fmt.Errorf("invalid argument %q: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// argumentToStrings converts a JSON argument into the string form pflag
+// parses; arrays become one Set call per element, which slice flags append.
+func argumentToStrings(value any) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := argumentToStrings(item)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, s...)
+		}
+		return result, nil
+	case []string:
+		return v, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
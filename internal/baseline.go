@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/formatters"
+	"go.yaml.in/yaml/v3"
+)
+
+// A baseline is a snapshot of accepted changes, stored in the changelog json
+// format, so the json output of breaking or changelog is a valid baseline too.
+// Changes are matched against it by fingerprint.
+
+// writeBaseline writes the changes to path as a baseline.
+func writeBaseline(path string, errs checker.Changes, l checker.Localizer) *ReturnError {
+	data, err := json.MarshalIndent(formatters.NewChanges(errs, l), "", "  ")
+	if err != nil {
+		return getErrCantProcessBaseline(path, err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return getErrCantProcessBaseline(path, err)
+	}
+
+	return nil
+}
+
+// readBaseline reads a baseline written by --baseline-write, or the json or
+// yaml output of breaking and changelog, either as a list of changes or
+// wrapped in an object with a "changes" key.
+func readBaseline(path string) (formatters.Changes, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// yaml is a superset of json
+	var changes formatters.Changes
+	if err := yaml.Unmarshal(data, &changes); err == nil {
+		return changes, nil
+	}
+
+	var wrapped struct {
+		Changes formatters.Changes `yaml:"changes"`
+	}
+	if err := yaml.Unmarshal(data, &wrapped); err != nil {
+		return nil, fmt.Errorf("expected a list of changes: %w", err)
+	}
+	return wrapped.Changes, nil
+}
+
+// applyBaseline removes the changes whose fingerprint is in the baseline and
+// warns about the baseline entries, at level or higher, that no longer occur.
+func applyBaseline(path string, errs checker.Changes, level checker.Level, stderr io.Writer) (checker.Changes, *ReturnError) {
+	baseline, err := readBaseline(path)
+	if err != nil {
+		return nil, getErrCantProcessBaseline(path, err)
+	}
+
+	accepted := make(map[string]bool, len(baseline))
+	for _, change := range baseline {
+		accepted[change.Fingerprint] = true
+	}
+
+	occurring := make(map[string]bool, len(errs))
+	result := make(checker.Changes, 0, len(errs))
+	for _, change := range errs {
+		fingerprint := checker.Fingerprint(change)
+		occurring[fingerprint] = true
+		if !accepted[fingerprint] {
+			result = append(result, change)
+		}
+	}
+
+	for _, change := range baseline {
+		// changes below the reported level weren't looked for, so they can't be said to be gone
+		if change.Level < level || occurring[change.Fingerprint] {
+			continue
+		}
+		_, _ = fmt.Fprintf(stderr, "warning: %s: baseline entry no longer occurs and can be removed: %s %s %s %s (fingerprint %s)\n", path, change.Id, change.Operation, change.Path, change.Text, change.Fingerprint)
+	}
+
+	return result, nil
+}
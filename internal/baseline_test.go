@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BaselineNoLongerOccurs(t *testing.T) {
+	l := checker.NewDefaultLocalizer()
+
+	removed := checker.ApiChange{Id: "api-path-removed-without-deprecation", Level: checker.ERR, Operation: "GET", Path: "/removed"}
+	kept := checker.ApiChange{Id: "request-parameter-removed", Level: checker.WARN, Args: []any{"query", "id"}, Operation: "GET", Path: "/kept"}
+	added := checker.ApiChange{Id: "request-parameter-removed", Level: checker.WARN, Args: []any{"query", "name"}, Operation: "GET", Path: "/kept"}
+
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+	require.Nil(t, writeBaseline(baseline, checker.Changes{removed, kept}, l))
+
+	var stderr bytes.Buffer
+	errs, err := applyBaseline(baseline, checker.Changes{kept, added}, checker.INFO, &stderr)
+	require.Nil(t, err)
+	require.Equal(t, checker.Changes{added}, errs)
+	require.Contains(t, stderr.String(), "baseline entry no longer occurs")
+	require.Contains(t, stderr.String(), "api-path-removed-without-deprecation GET /removed")
+	require.NotContains(t, stderr.String(), "request-parameter-removed")
+
+	// entries below the reported level are not reported as gone
+	stderr.Reset()
+	_, err = applyBaseline(baseline, checker.Changes{removed}, checker.ERR, &stderr)
+	require.Nil(t, err)
+	require.Empty(t, stderr.String())
+}
@@ -12,7 +12,7 @@ func getBreakingChangesCmd() *cobra.Command {
 	cmd := cobra.Command{
 		Use:   "breaking base revision [flags]",
 		Short: "Display breaking changes",
-		Long:  "Display breaking changes between base and revision specs." + specHelp,
+		Long:  "Display breaking changes between base and revision specs." + specHelp + gitBaseHelp,
 		Args:  getParseArgs(),
 		RunE:  getRun(runBreakingChanges),
 	}
@@ -20,6 +20,12 @@ func getBreakingChangesCmd() *cobra.Command {
 	addCommonDiffFlags(&cmd)
 	addCommonBreakingFlags(&cmd)
 	enumWithOptions(&cmd, newEnumValue(GetBreakingLevels(), ""), "fail-on", "o", "exit with return code 1 when output includes errors with this level or higher")
+	addBaselineFlags(&cmd)
+	addConsumerUsageFlags(&cmd)
+	addTrafficFlags(&cmd)
+	addProtoFlags(&cmd)
+	addGitBaseFlags(&cmd)
+	addWatchFlag(&cmd, "revision spec")
 	addOpenFlags(&cmd, "breaking changes")
 
 	return &cmd
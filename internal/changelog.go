@@ -3,11 +3,14 @@ package internal
 import (
 	"fmt"
 	"io"
-	"os"
+	"slices"
+	"time"
 
+	"cloud.google.com/go/civil"
 	"github.com/oasdiff/oasdiff/checker"
 	"github.com/oasdiff/oasdiff/formatters"
 	"github.com/oasdiff/oasdiff/load"
+	"github.com/oasdiff/oasdiff/protobuf"
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +21,7 @@ func getChangelogCmd() *cobra.Command {
 	cmd := cobra.Command{
 		Use:   "changelog base revision [flags]",
 		Short: "Display changelog",
-		Long:  "Display changes between base and revision specs." + specHelp,
+		Long:  "Display changes between base and revision specs." + specHelp + gitBaseHelp,
 		Args:  getParseArgs(),
 		RunE:  getRun(runChangelog),
 	}
@@ -27,6 +30,12 @@ func getChangelogCmd() *cobra.Command {
 	addCommonBreakingFlags(&cmd)
 	enumWithOptions(&cmd, newEnumValue(GetSupportedLevels(), ""), "fail-on", "o", "exit with return code 1 when output includes errors with this level or higher")
 	enumWithOptions(&cmd, newEnumValue(GetSupportedLevels(), LevelInfo), "level", "", "output errors with this level or higher")
+	addBaselineFlags(&cmd)
+	addConsumerUsageFlags(&cmd)
+	addTrafficFlags(&cmd)
+	addProtoFlags(&cmd)
+	addGitBaseFlags(&cmd)
+	addWatchFlag(&cmd, "revision spec")
 	addOpenFlags(&cmd, "changelog")
 
 	return &cmd
@@ -53,44 +62,82 @@ func getChangelog(flags *Flags, stdout io.Writer, level checker.Level, isBreakin
 		return false, returnErr
 	}
 
-	severityLevels, returnErr := getCustomSeverityLevels(flags.getSeverityLevelsFile())
+	return reportChangelog(flags, stdout, diffResult, level, isBreaking)
+}
+
+// reportChangelog runs the checks on a computed diff, prints the changelog and
+// decides the --fail-on result.
+func reportChangelog(flags *Flags, stdout io.Writer, diffResult *diffResult, level checker.Level, isBreaking bool) (bool, *ReturnError) {
+
+	bcConfig, returnErr := getCheckerConfig(flags)
 	if returnErr != nil {
 		return false, returnErr
 	}
 
-	bcConfig := checker.NewConfig(
-		checker.GetAllChecks(),
-		checker.WithSeverityLevels(severityLevels),
-		checker.WithDeprecation(flags.getDeprecationDaysBeta(), flags.getDeprecationDaysStable()),
-		checker.WithAttributes(flags.getAttributes()),
-		checker.WithStabilityLevel(flags.getStabilityLevel()),
-	)
-
-	errs, returnErr := filterIgnored(
+	errs, returnErr := addProtoChanges(
+		flags,
+		bcConfig,
 		checker.CheckBackwardCompatibilityUntilLevel(
 			bcConfig,
-			diffResult.diffReport,
+			diffResult.checkReport(),
 			diffResult.operationsSources,
 			level),
+		level)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	errs, returnErr = filterConsumerUsage(errs, flags.getConsumerUsage())
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	errs, returnErr = applyTraffic(flags, errs, level)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	asOf, returnErr := getAsOf(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	errs, returnErr = filterIgnored(
+		errs,
 		flags.getWarnIgnoreFile(),
 		flags.getErrIgnoreFile(),
-		checker.NewLocalizer(flags.getLang()))
+		checker.NewLocalizer(flags.getLang()),
+		asOf,
+		flags.getStderr())
 
 	if returnErr != nil {
 		return false, returnErr
 	}
 
+	if baselineWrite := flags.getBaselineWrite(); baselineWrite != "" {
+		if returnErr := writeBaseline(baselineWrite, errs, checker.NewLocalizer(flags.getLang())); returnErr != nil {
+			return false, returnErr
+		}
+	}
+
+	if baseline := flags.getBaseline(); baseline != "" {
+		errs, returnErr = applyBaseline(baseline, errs, level, flags.getStderr())
+		if returnErr != nil {
+			return false, returnErr
+		}
+	}
+
 	if returnErr := outputChangelog(flags, stdout, errs, diffResult.specInfoPair, diffResult.diffReport.Empty(), isBreaking); returnErr != nil {
 		return false, returnErr
 	}
 
 	if flags.getOpen() {
-		if err := uploadAndOpen(flags, os.Stderr, isBreaking, errs, diffResult.baseSpecs, diffResult.revSpecs, diffResult.diffReport.Empty()); err != nil {
+		if err := uploadAndOpen(flags, flags.getStderr(), isBreaking, errs, diffResult.baseSpecs, diffResult.revSpecs, diffResult.diffReport.Empty()); err != nil {
 			// --open is additive: an upload error, unsupported source, or
 			// composed mode must not change the exit code or pre-empt --fail-on.
 			// Warn to stderr (not stdout, so it never corrupts piped --format
 			// json/yaml output) and continue.
-			_, _ = fmt.Fprintf(os.Stderr, "warning: could not open the side-by-side review: %v\n", err)
+			_, _ = fmt.Fprintf(flags.getStderr(), "warning: could not open the side-by-side review: %v\n", err)
 		}
 	}
 
@@ -105,27 +152,155 @@ func getChangelog(flags *Flags, stdout io.Writer, level checker.Level, isBreakin
 	return false, nil
 }
 
-func filterIgnored(errs checker.Changes, warnIgnoreFile string, errIgnoreFile string, l checker.Localizer) (checker.Changes, *ReturnError) {
+// getCheckerConfig returns the checks configuration the flags ask for.
+func getCheckerConfig(flags *Flags) (*checker.Config, *ReturnError) {
+
+	customRules, returnErr := getCustomRules(flags.getCustomRulesFile())
+	if returnErr != nil {
+		return nil, returnErr
+	}
+
+	severityLevels, returnErr := getCustomSeverityLevels(flags.getSeverityLevelsFile(), customRules.GetIds()...)
+	if returnErr != nil {
+		return nil, returnErr
+	}
+
+	asOf, returnErr := getAsOf(flags)
+	if returnErr != nil {
+		return nil, returnErr
+	}
+
+	return checker.NewConfig(
+		checker.GetAllChecks(),
+		checker.WithCustomRules(customRules),
+		checker.WithSeverityLevels(severityLevels),
+		checker.WithDeprecation(flags.getDeprecationDaysBeta(), flags.getDeprecationDaysStable()),
+		checker.WithAttributes(flags.getAttributes()),
+		checker.WithStabilityLevel(flags.getStabilityLevel()),
+		checker.WithAsOf(asOf),
+	), nil
+}
+
+// addProtoChanges adds the wire-compatibility changes between the base and
+// revision protobuf descriptor sets, if given, to the changes of the specs.
+func addProtoChanges(flags *Flags, config *checker.Config, errs checker.Changes, level checker.Level) (checker.Changes, *ReturnError) {
+	baseProto, revisionProto := flags.getBaseProto(), flags.getRevisionProto()
+	if baseProto == "" && revisionProto == "" {
+		return errs, nil
+	}
+	if baseProto == "" || revisionProto == "" {
+		return nil, getErrInvalidFlags(fmt.Errorf("base-proto and revision-proto must be specified together"))
+	}
+
+	base, err := protobuf.Load(baseProto)
+	if err != nil {
+		return nil, getErrCantProcessProto(baseProto, err)
+	}
+	revision, err := protobuf.Load(revisionProto)
+	if err != nil {
+		return nil, getErrCantProcessProto(revisionProto, err)
+	}
+
+	for _, change := range checker.CheckProtoCompatibility(config, base, revision, baseProto, revisionProto) {
+		if change.GetLevel() >= level {
+			errs = append(errs, change)
+		}
+	}
+	slices.SortFunc(errs, checker.CompareChanges)
+	return errs, nil
+}
+
+// filterConsumerUsage keeps the changes that affect the consumers described by
+// the usage files, or all of them when there are none.
+func filterConsumerUsage(errs checker.Changes, usageFiles []string) (checker.Changes, *ReturnError) {
+	if len(usageFiles) == 0 {
+		return errs, nil
+	}
+
+	usages := make([]*checker.ConsumerUsage, len(usageFiles))
+	for i, usageFile := range usageFiles {
+		usage, err := checker.LoadConsumerUsage(usageFile)
+		if err != nil {
+			return nil, getErrCantProcessConsumerUsage(usageFile, err)
+		}
+		usages[i] = usage
+	}
+
+	return checker.FilterConsumerUsage(errs, usages), nil
+}
+
+// applyTraffic weighs the changes by the traffic file's usage counts, and drops
+// those demoted below the level to report.
+func applyTraffic(flags *Flags, errs checker.Changes, level checker.Level) (checker.Changes, *ReturnError) {
+	trafficFile := flags.getTraffic()
+	if trafficFile == "" {
+		return errs, nil
+	}
+
+	demoteBelow, escalateAt := flags.getTrafficDemoteBelow(), flags.getTrafficEscalateAt()
+	if escalateAt > 0 && escalateAt < demoteBelow {
+		return nil, getErrInvalidFlags(fmt.Errorf("traffic-escalate-at %d must not be less than traffic-demote-below %d", escalateAt, demoteBelow))
+	}
+
+	traffic, err := checker.LoadTraffic(trafficFile)
+	if err != nil {
+		return nil, getErrCantProcessTraffic(trafficFile, err)
+	}
+
+	result := checker.Changes{}
+	for _, change := range checker.ApplyTraffic(errs, traffic, checker.TrafficThresholds{
+		DemoteBelow: int64(demoteBelow),
+		EscalateAt:  int64(escalateAt),
+	}) {
+		if change.GetLevel() >= level {
+			result = append(result, change)
+		}
+	}
+	return result, nil
+}
+
+// filterIgnored drops the changes that the ignore files match; entries that expired before asOf ignore nothing,
+// and are reported to stderr along with the stale ones
+func filterIgnored(errs checker.Changes, warnIgnoreFile string, errIgnoreFile string, l checker.Localizer, asOf civil.Date, stderr io.Writer) (checker.Changes, *ReturnError) {
 
 	if warnIgnoreFile != "" {
 		var err error
-		errs, err = checker.ProcessIgnoredBackwardCompatibilityErrors(checker.WARN, errs, warnIgnoreFile, l)
+		var report *checker.IgnoreReport
+		errs, report, err = checker.ProcessIgnoreFile(checker.WARN, errs, warnIgnoreFile, l, asOf.In(time.UTC))
 		if err != nil {
 			return nil, getErrCantProcessIgnoreFile("warn", err)
 		}
+		printIgnoreReport(stderr, warnIgnoreFile, report)
 	}
 
 	if errIgnoreFile != "" {
 		var err error
-		errs, err = checker.ProcessIgnoredBackwardCompatibilityErrors(checker.ERR, errs, errIgnoreFile, l)
+		var report *checker.IgnoreReport
+		errs, report, err = checker.ProcessIgnoreFile(checker.ERR, errs, errIgnoreFile, l, asOf.In(time.UTC))
 		if err != nil {
 			return nil, getErrCantProcessIgnoreFile("err", err)
 		}
+		printIgnoreReport(stderr, errIgnoreFile, report)
 	}
 
 	return errs, nil
 }
 
+// printIgnoreReport warns about the expired and stale entries of a structured
+// ignore file. It writes to stderr so it never corrupts piped json/yaml output.
+func printIgnoreReport(w io.Writer, ignoreFile string, report *checker.IgnoreReport) {
+	if report.Empty() {
+		return
+	}
+
+	for _, entry := range report.Expired {
+		_, _ = fmt.Fprintf(w, "warning: %s: entry expired on %s and no longer ignores changes: %s\n", ignoreFile, entry.Expires, entry)
+	}
+	for _, entry := range report.Stale {
+		_, _ = fmt.Fprintf(w, "warning: %s: stale entry matches no change: %s\n", ignoreFile, entry)
+	}
+}
+
 func outputChangelog(flags *Flags, stdout io.Writer, errs checker.Changes, specInfoPair *load.SpecInfoPair, diffEmpty, isBreaking bool) *ReturnError {
 
 	// formatter lookup
@@ -160,15 +335,28 @@ func outputChangelog(flags *Flags, stdout io.Writer, errs checker.Changes, specI
 	return nil
 }
 
-func getCustomSeverityLevels(severityLevelsFile string) (map[string]checker.Level, *ReturnError) {
+func getCustomSeverityLevels(severityLevelsFile string, customRuleIds ...string) (map[string]checker.Level, *ReturnError) {
 	if severityLevelsFile == "" {
 		return nil, nil
 	}
 
-	m, err := checker.ProcessSeverityLevels(severityLevelsFile)
+	m, err := checker.ProcessSeverityLevels(severityLevelsFile, customRuleIds...)
 	if err != nil {
 		return nil, getErrFailedToLoadSeverityLevels(severityLevelsFile, err)
 	}
 
 	return m, nil
 }
+
+func getCustomRules(customRulesFile string) (*checker.CustomRules, *ReturnError) {
+	if customRulesFile == "" {
+		return nil, nil
+	}
+
+	rules, err := checker.LoadCustomRules(customRulesFile)
+	if err != nil {
+		return nil, getErrFailedToLoadCustomRules(customRulesFile, err)
+	}
+
+	return rules, nil
+}
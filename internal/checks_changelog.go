@@ -8,6 +8,7 @@ import (
 	"github.com/oasdiff/oasdiff/checker"
 	"github.com/oasdiff/oasdiff/checker/localizations"
 	"github.com/oasdiff/oasdiff/formatters"
+	"github.com/oasdiff/oasdiff/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -42,10 +43,16 @@ func addChecksChangelogFlags(cmd *cobra.Command) {
 	addChecksSeverityFlag(cmd)
 	enumWithOptions(cmd, newEnumSliceValue(getAllTags(), nil), "tags", "t", "include only checks with all specified tags")
 	enumWithOptions(cmd, newEnumValue(localizations.GetSupportedLanguages(), localizations.LangDefault), "lang", "l", "language for localized output")
+	cmd.PersistentFlags().String("custom-rules", "", "YAML file of custom rules to list in addition to the built-in ones")
 }
 
 func runChecksChangelog(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
-	return false, outputChangelogRules(stdout, flags, checker.GetAllRules())
+	customRules, returnErr := getCustomRules(flags.getCustomRulesFile())
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	return false, outputChangelogRules(stdout, flags, append(checker.GetAllRules(), customRules.GetRules()...))
 }
 
 func outputChangelogRules(stdout io.Writer, flags *Flags, rules []checker.BackwardCompatibilityRule) *ReturnError {
@@ -65,7 +72,14 @@ func outputChangelogRules(stdout io.Writer, flags *Flags, rules []checker.Backwa
 	// filter rules
 	severity := flags.getSeverity()
 	checks := make(formatters.Checks, 0, len(rules))
+	listed := utils.StringSet{}
 	for _, rule := range rules {
+		// a rule reported by several checks is registered with each of them, but listed once
+		if listed.Contains(rule.Id) {
+			continue
+		}
+		listed.Add(rule.Id)
+
 		if !matchSeverity(severity, rule.Level) {
 			continue
 		}
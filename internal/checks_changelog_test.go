@@ -6,6 +6,7 @@ import (
 	"io"
 	"testing"
 
+	"github.com/oasdiff/oasdiff/checker"
 	"github.com/oasdiff/oasdiff/internal"
 	"github.com/stretchr/testify/require"
 )
@@ -21,3 +22,20 @@ func Test_ChecksChangelogAcceptsTheListingFlags(t *testing.T) {
 	require.NoError(t, json.Unmarshal(stdout.Bytes(), &checks))
 	require.NotEmpty(t, checks)
 }
+
+// A rule reported by several checks is listed once.
+func Test_ChecksChangelogListsEachRuleOnce(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff checks changelog --format json"), &stdout, io.Discard))
+
+	var checks []map[string]any
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &checks))
+
+	ids := map[string]bool{}
+	for _, check := range checks {
+		id := check["id"].(string)
+		require.False(t, ids[id], id)
+		ids[id] = true
+	}
+	require.True(t, ids[checker.ResponseStatusRemovedBeforeSunsetId])
+}
@@ -21,6 +21,7 @@ func addCommonDiffFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().Bool("include-path-params", false, "include path parameter names in endpoint matching")
 	cmd.PersistentFlags().Bool("match-inline-refs", true, "match validation-equivalent inline/$ref subschemas as the same anyOf/oneOf branch")
 	cmd.PersistentFlags().Bool("flatten-allof", false, "merge subschemas under allOf before diff")
+	cmd.PersistentFlags().Bool("normalize", false, "rewrite schemas into a canonical form before diff, so that equivalent schemas compare as equal")
 	cmd.PersistentFlags().Bool("flatten-params", false, "merge common parameters at path level with operation parameters")
 	cmd.PersistentFlags().Bool("case-insensitive-headers", true, "case-insensitive header name comparison (HTTP headers are case-insensitive per RFC 7230)")
 	cmd.PersistentFlags().StringSlice("exclude-extensions", nil, "OpenAPI Extension names to exclude from diff (e.g., x-internal)")
@@ -91,14 +92,44 @@ func addCommonBreakingFlags(cmd *cobra.Command) {
 	hideFlag(cmd, "include-checks")
 	cmd.PersistentFlags().Uint("deprecation-days-beta", checker.DefaultBetaDeprecationDays, "min days required between deprecating a beta resource and removing it")
 	cmd.PersistentFlags().Uint("deprecation-days-stable", checker.DefaultStableDeprecationDays, "min days required between deprecating a stable resource and removing it")
+	cmd.PersistentFlags().String("as-of", "", "compare the sunset dates and ignore file expiry dates with this date (YYYY-MM-DD) instead of today")
 	enumWithOptions(cmd, newEnumValue(checker.GetSupportedColorValues(), "auto"), "color", "", "when to colorize textual output")
 	enumWithOptions(cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputChangelog), string(formatters.FormatText)), "format", "f", "output format")
 	cmd.PersistentFlags().String("severity-levels", "", "configuration file for custom severity levels")
+	cmd.PersistentFlags().String("custom-rules", "", "YAML file of custom rules to check in addition to the built-in ones")
 	cmd.PersistentFlags().StringSlice("attributes", nil, "OpenAPI Extensions to include in json or yaml output")
 	cmd.PersistentFlags().String("template", "", "path to custom template file for changelog generation")
 	enumWithOptions(cmd, newEnumValue(checker.GetSupportedStabilityLevels(), ""), "stability-level", "", "minimum stability level to include")
 }
 
+// addBaselineFlags registers the flags that record accepted changes and
+// suppress them on later runs.
+func addBaselineFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("baseline", "", "file of accepted changes, written by --baseline-write; changes in it are not reported")
+	cmd.PersistentFlags().String("baseline-write", "", "write the reported changes to this file, to be used later with --baseline")
+}
+
+// addConsumerUsageFlags registers the flag that limits the report to the
+// changes affecting the API's consumers.
+func addConsumerUsageFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringSlice("consumer-usage", nil, "report only the changes that affect a consumer, described by this file: a list of operations, an OpenAPI subset spec or a HAR file; repeat for each consumer")
+}
+
+// addTrafficFlags registers the flags that weigh breaking changes by the
+// observed traffic of their endpoints.
+func addTrafficFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("traffic", "", "CSV or JSON file of observed calls per endpoint (method, path, count), to weigh breaking changes by usage")
+	cmd.PersistentFlags().Uint("traffic-demote-below", 1, "with --traffic, demote breaking changes to endpoints called fewer times than this by one level (0 to disable)")
+	cmd.PersistentFlags().Uint("traffic-escalate-at", 0, "with --traffic, escalate breaking changes to endpoints called at least this many times to error (0 to disable)")
+}
+
+// addProtoFlags registers the flags that add the wire-compatibility changes
+// of the service's protobuf descriptor sets to the report.
+func addProtoFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("base-proto", "", "protobuf descriptor set (protoc --descriptor_set_out) of the base version of the service, to check gRPC wire compatibility along with the specs")
+	cmd.PersistentFlags().String("revision-proto", "", "protobuf descriptor set of the revision, used with --base-proto")
+}
+
 // addOpenFlags registers --open and its companion review-upload flags. Kept out
 // of addCommonBreakingFlags so the git-diff driver (which shares that helper but
 // has no --open) doesn't inherit them.
@@ -19,6 +19,8 @@ import (
 //     --open, which is itself command-line only, and the token is a credential
 //     that should never be committed to a config file. So they are command-line
 //     only too.
+//   - watch: keeps the command running until it is interrupted. Persisting it in
+//     a config file would make every run, including CI runs, never exit.
 //   - config: the path to the config file itself.
 //
 // Hidden (deprecated) flags are skipped separately via flag.Hidden, so they
@@ -27,6 +29,7 @@ var flagsNotInConfigFile = map[string]bool{
 	"open":         true,
 	"review-token": true,
 	"review-meta":  true,
+	"watch":        true,
 	"config":       true,
 }
 
@@ -57,6 +60,10 @@ func Test_ConfigFileCoversAllFlags(t *testing.T) {
 		getFlattenCmd(),
 		getUpgradeCmd(),
 		getValidateCmd(),
+		getServeCmd(),
+		getLSPCmd(),
+		getHistoryCmd(),
+		getDeprecationsCmd(),
 	}
 
 	for _, cmd := range commands {
@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/formatters"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/spf13/cobra"
+)
+
+const deprecationsCmd = "deprecations"
+
+func getDeprecationsCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:   "deprecations spec [flags]",
+		Short: "List deprecated endpoints, parameters and properties",
+		Long: `List the deprecated endpoints, parameters and request and response properties of a spec,
+with their sunset date (x-sunset), the stability level of their operation (x-stability-level)
+and the days remaining until the sunset. Deprecations whose sunset date has passed are flagged as overdue.
+
+The days are counted from today, or from the date given with --as-of.
+Use '-f ics' to export the sunset dates as an iCalendar feed, or '-f json' for a JSON feed.
+
+Spec can be a path to a file, a URL, a git ref (e.g. main:openapi.yaml), or '-' to read standard input.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: getRun(runDeprecations),
+	}
+
+	enumWithOptions(&cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputDeprecations), string(formatters.FormatText)), "format", "f", "output format")
+	enumWithOptions(&cmd, newEnumValue(checker.GetSupportedColorValues(), "auto"), "color", "", "when to colorize textual output")
+	cmd.PersistentFlags().String("as-of", "", "count the days until the sunset dates from this date (YYYY-MM-DD) instead of today")
+	cmd.PersistentFlags().Bool("allow-external-refs", true, "allow external $refs in specs; disable to prevent SSRF when processing untrusted specs")
+
+	return &cmd
+}
+
+func runDeprecations(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
+
+	asOf, returnErr := getAsOf(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = flags.getAllowExternalRefs()
+	loader.IncludeOrigin = true
+
+	spec, err := load.NewSpecInfo(loader, flags.getBase())
+	if err != nil {
+		return false, getErrFailedToLoadSpec("original", flags.getBase(), err)
+	}
+
+	if returnErr := outputDeprecations(flags, stdout, spec, checker.GetDeprecations(spec, asOf), asOf); returnErr != nil {
+		return false, returnErr
+	}
+
+	return false, nil
+}
+
+// getAsOf returns the date of --as-of, or today
+func getAsOf(flags *Flags) (civil.Date, *ReturnError) {
+	if flags.getAsOf() == "" {
+		return civil.DateOf(time.Now()), nil
+	}
+
+	asOf, err := civil.ParseDate(flags.getAsOf())
+	if err != nil {
+		return civil.Date{}, getErrInvalidFlags(fmt.Errorf("invalid as-of date %q, expected YYYY-MM-DD", flags.getAsOf()))
+	}
+	return asOf, nil
+}
+
+func outputDeprecations(flags *Flags, stdout io.Writer, spec *load.SpecInfo, deprecations checker.Deprecations, asOf civil.Date) *ReturnError {
+
+	// formatter lookup
+	formatterOpts := formatters.DefaultFormatterOpts()
+	formatterOpts.Title = getSpecTitle(spec)
+	formatter, err := formatters.Lookup(flags.getFormat(), formatterOpts)
+	if err != nil {
+		return getErrUnsupportedFormat(flags.getFormat(), deprecationsCmd)
+	}
+
+	colorMode, err := checker.NewColorMode(flags.getColor())
+	if err != nil {
+		return getErrInvalidColorMode(err)
+	}
+
+	// render
+	bytes, err := formatter.RenderDeprecations(deprecations, formatters.RenderOpts{ColorMode: colorMode, AsOf: asOf})
+	if err != nil {
+		return getErrFailedPrint(deprecationsCmd+" "+flags.getFormat(), err)
+	}
+
+	// print output
+	_, _ = fmt.Fprintf(stdout, "%s\n", bytes)
+
+	return nil
+}
+
+func getSpecTitle(spec *load.SpecInfo) string {
+	if spec.Spec.Info == nil {
+		return ""
+	}
+	return spec.Spec.Info.Title
+}
@@ -27,6 +27,7 @@ func getDiffCmd() *cobra.Command {
 	enumWithOptions(&cmd, newEnumSliceValue(diff.GetExcludeDiffOptions(), nil), "exclude-elements", "e", "elements to exclude")
 	enumWithOptions(&cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputDiff), string(formatters.FormatYAML)), "format", "f", "output format")
 	cmd.PersistentFlags().BoolP("fail-on-diff", "o", false, "exit with return code 1 when any change is found")
+	addWatchFlag(&cmd, "revision spec")
 
 	return &cmd
 }
@@ -73,6 +74,7 @@ func calcDiff(flags *Flags) (*diffResult, *ReturnError) {
 	loader := openapi3.NewLoader()
 	loader.IncludeOrigin = true
 	loader.IsExternalRefsAllowed = flags.getAllowExternalRefs()
+	flags.watch.setLoader(loader, flags.getBase(), flags.getRevision())
 
 	if flags.getComposed() {
 		return composedDiff(loader, flags)
@@ -89,6 +91,18 @@ type diffResult struct {
 	// --open path each SpecInfo.Sources carries its captured file texts.
 	baseSpecs []*load.SpecInfo
 	revSpecs  []*load.SpecInfo
+	// The context of identical specs, which the checks that judge the revision
+	// as a whole still need (see diff.GetRevisionContext); nil otherwise.
+	revisionContext *diff.Diff
+}
+
+// checkReport returns the diff to run the checks on: the diff report, or the
+// context of the specs when they are identical.
+func (r *diffResult) checkReport() *diff.Diff {
+	if r.diffReport == nil {
+		return r.revisionContext
+	}
+	return r.diffReport
 }
 
 func newDiffResult(d *diff.Diff, o *diff.OperationsSourcesMap, s *load.SpecInfoPair) *diffResult {
@@ -103,7 +117,8 @@ func newDiffResult(d *diff.Diff, o *diff.OperationsSourcesMap, s *load.SpecInfoP
 // plain one otherwise. --open renders a side-by-side review whose blocks are
 // sliced from source text, so it needs every contributing file (root + $ref'd)
 // recorded; ordinary runs skip the recorder. normalDiff and composedDiff pass
-// their respective loader pairs.
+// their respective loader pairs. Watch mode uses the recorder too, to learn
+// which files the watched spec references.
 func loaderForOpen[F any](open bool, plain, capture F) F {
 	if open {
 		return capture
@@ -113,13 +128,11 @@ func loaderForOpen[F any](open bool, plain, capture F) F {
 
 func normalDiff(loader *openapi3.Loader, flags *Flags) (*diffResult, *ReturnError) {
 
-	flattenAllOf := load.GetOption(load.WithFlattenAllOf(), flags.getFlattenAllOf())
-	flattenParams := load.GetOption(load.WithFlattenParams(), flags.getFlattenParams())
-	lowerHeaderNames := load.GetOption(load.WithLowercaseHeaders(), flags.getCaseInsensitiveHeaders())
-
 	newSpecInfo := loaderForOpen(flags.getOpen(), load.NewSpecInfo, load.NewSpecInfoWithCapture)
 
-	s1, err := newSpecInfo(loader, flags.getBase(), flattenAllOf, flattenParams, lowerHeaderNames)
+	s1, err := flags.watch.loadBase(flags.getBase(), func() (*load.SpecInfo, error) {
+		return newSpecInfo(loader, flags.getBase(), getLoadOptions(flags)...)
+	})
 	if err != nil {
 		return nil, getErrFailedToLoadSpec("base", flags.getBase(), err)
 	}
@@ -132,12 +145,28 @@ func normalDiff(loader *openapi3.Loader, flags *Flags) (*diffResult, *ReturnErro
 		specInfo := *s1
 		s2 = &specInfo
 	} else {
-		s2, err = newSpecInfo(loader, flags.getRevision(), flattenAllOf, flattenParams, lowerHeaderNames)
+		// in watch mode, the files that the revision is loaded from are recorded to be watched
+		newRevisionSpecInfo := loaderForOpen(flags.getOpen() || flags.watch != nil, load.NewSpecInfo, load.NewSpecInfoWithCapture)
+		s2, err = newRevisionSpecInfo(loader, flags.getRevision(), getLoadOptions(flags)...)
 		if err != nil {
 			return nil, getErrFailedToLoadSpec("revision", flags.getRevision(), err)
 		}
+		flags.watch.record(s2)
 	}
 
+	r, returnErr := diffSpecs(flags, s1, s2)
+	if returnErr != nil {
+		return nil, returnErr
+	}
+
+	r.baseSpecs, r.revSpecs = []*load.SpecInfo{s1}, []*load.SpecInfo{s2}
+	return r, nil
+}
+
+// diffSpecs compares two loaded specs; it is the part of a normal diff that
+// doesn't depend on where the specs came from.
+func diffSpecs(flags *Flags, s1, s2 *load.SpecInfo) (*diffResult, *ReturnError) {
+
 	autoUpgradeSpecs(flags.getAutoUpgrade(), s1, s2)
 
 	diffReport, operationsSources, err := diff.GetWithOperationsSourcesMap(flags.toConfig(), s1, s2)
@@ -146,24 +175,35 @@ func normalDiff(loader *openapi3.Loader, flags *Flags) (*diffResult, *ReturnErro
 	}
 
 	r := newDiffResult(diffReport, operationsSources, load.NewSpecInfoPair(s1, s2))
-	r.baseSpecs, r.revSpecs = []*load.SpecInfo{s1}, []*load.SpecInfo{s2}
+	if diffReport == nil {
+		if r.revisionContext, err = diff.GetRevisionContext(flags.toConfig(), s1.Spec, s2.Spec); err != nil {
+			return nil, getErrDiffFailed(err)
+		}
+	}
 	return r, nil
 }
 
-func composedDiff(loader *openapi3.Loader, flags *Flags) (*diffResult, *ReturnError) {
+// getLoadOptions returns the preprocessing options the flags ask for, in the
+// order every spec is preprocessed.
+func getLoadOptions(flags *Flags) []load.Option {
+	return []load.Option{
+		load.GetOption(load.WithFlattenAllOf(), flags.getFlattenAllOf()),
+		load.GetOption(load.WithFlattenParams(), flags.getFlattenParams()),
+		load.GetOption(load.WithNormalize(), flags.getNormalize()),
+		load.GetOption(load.WithLowercaseHeaders(), flags.getCaseInsensitiveHeaders()),
+	}
+}
 
-	flattenAllOf := load.GetOption(load.WithFlattenAllOf(), flags.getFlattenAllOf())
-	flattenParams := load.GetOption(load.WithFlattenParams(), flags.getFlattenParams())
-	lowerHeaderNames := load.GetOption(load.WithLowercaseHeaders(), flags.getCaseInsensitiveHeaders())
+func composedDiff(loader *openapi3.Loader, flags *Flags) (*diffResult, *ReturnError) {
 
 	newGlob := loaderForOpen(flags.getOpen(), load.NewSpecInfoFromGlob, load.NewSpecInfoFromGlobWithCapture)
 
-	s1, err := newGlob(loader, flags.getBase().Path, flattenAllOf, flattenParams, lowerHeaderNames)
+	s1, err := newGlob(loader, flags.getBase().Path, getLoadOptions(flags)...)
 	if err != nil {
 		return nil, getErrFailedToLoadSpecs("base", flags.getBase().Path, err)
 	}
 
-	s2, err := newGlob(loader, flags.getRevision().Path, flattenAllOf, flattenParams, lowerHeaderNames)
+	s2, err := newGlob(loader, flags.getRevision().Path, getLoadOptions(flags)...)
 	if err != nil {
 		return nil, getErrFailedToLoadSpecs("revision", flags.getRevision().Path, err)
 	}
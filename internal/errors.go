@@ -14,12 +14,36 @@ type ReturnError struct {
 	Code int
 }
 
-const generalExecutionErr = 100
+// the return codes of the errors
+const (
+	generalExecutionErr           = 100
+	invalidFlagsErr               = 101
+	failedToLoadSpecErr           = 102
+	failedToLoadSpecsErr          = 103
+	diffFailedErr                 = 104
+	failedPrintErr                = 105
+	failedToLoadSeverityLevelsErr = 106
+	configFileProblemErr          = 107
+	failedToListGitTagsErr        = 108
+	failedToResolveGitBaseErr     = 109
+	unsupportedFormatErr          = 110
+	templateNotSupportedErr       = 111
+	cantProcessConsumerUsageErr   = 112
+	cantProcessTrafficErr         = 113
+	invalidColorModeErr           = 114
+	cantProcessProtoErr           = 115
+	failedToLoadCustomRulesErr    = 116
+	cantProcessIgnoreFileErr      = 121
+	failedToFlattenErr            = 122
+	disallowedExternalRefErr      = 123
+	serveFailedErr                = 124
+	cantProcessBaselineErr        = 125
+)
 
 func getErrInvalidFlags(err error) *ReturnError {
 	return getError(
 		err,
-		101,
+		invalidFlagsErr,
 	)
 }
 
@@ -31,7 +55,7 @@ func getErrFailedToLoadSpec(what string, source *load.Source, err error) *Return
 	if isExternalRefError(err) {
 		return getErrDisallowedExternalRef(wrapped)
 	}
-	return getError(wrapped, 102)
+	return getError(wrapped, failedToLoadSpecErr)
 }
 
 func getErrFailedToLoadSpecs(what string, path string, err error) *ReturnError {
@@ -42,41 +66,69 @@ func getErrFailedToLoadSpecs(what string, path string, err error) *ReturnError {
 	if isExternalRefError(err) {
 		return getErrDisallowedExternalRef(wrapped)
 	}
-	return getError(wrapped, 103)
+	return getError(wrapped, failedToLoadSpecsErr)
+}
+
+func getErrFailedToLoadSchema(what string, source *load.Source, err error) *ReturnError {
+	return getError(
+		fmt.Errorf("failed to load %s schema from %s: %w", what, source.Out(), err),
+		failedToLoadSpecErr,
+	)
 }
 
 func getErrDiffFailed(err error) *ReturnError {
 	return getError(
 		fmt.Errorf("diff failed: %w", err),
-		104,
+		diffFailedErr,
 	)
 }
 
 func getErrFailedPrint(what string, err error) *ReturnError {
 	return getError(
 		fmt.Errorf("failed to print %q: %w", what, err),
-		105,
+		failedPrintErr,
 	)
 }
 
 func getErrFailedToLoadSeverityLevels(source string, err error) *ReturnError {
 	return getError(
 		fmt.Errorf("failed to load custom severity levels from %s: %w", source, err),
-		106,
+		failedToLoadSeverityLevelsErr,
+	)
+}
+
+func getErrFailedToLoadCustomRules(source string, err error) *ReturnError {
+	return getError(
+		fmt.Errorf("failed to load custom rules from %s: %w", source, err),
+		failedToLoadCustomRulesErr,
 	)
 }
 
 func getErrConfigFileProblem(err error) *ReturnError {
 	return getError(
 		fmt.Errorf("failed to load config file: %w", err),
-		107,
+		configFileProblemErr,
+	)
+}
+
+func getErrFailedToListGitTags(pattern string, err error) *ReturnError {
+	return getError(
+		fmt.Errorf("failed to list git tags matching %q: %w", pattern, err),
+		failedToListGitTagsErr,
+	)
+}
+
+func getErrFailedToResolveGitBase(err error) *ReturnError {
+	return getError(
+		fmt.Errorf("failed to find the base spec in git: %w", err),
+		failedToResolveGitBaseErr,
 	)
 }
 
 func getErrUnsupportedFormat(format, cmd string) *ReturnError {
 	return getError(
 		fmt.Errorf("format %q is not supported by %q", format, cmd),
-		110,
+		unsupportedFormatErr,
 	)
 }
 
@@ -84,21 +136,21 @@ func getErrTemplateNotSupported(format string) *ReturnError {
 	supportedFormats := formatters.GetSupportedTemplateFormats()
 	return getError(
 		fmt.Errorf("template flag is not supported for format %q. Supported formats for templates are: %s", format, strings.Join(supportedFormats, ", ")),
-		111,
+		templateNotSupportedErr,
 	)
 }
 
 func getErrInvalidColorMode(err error) *ReturnError {
 	return getError(
 		err,
-		114,
+		invalidColorModeErr,
 	)
 }
 
 func getErrCantProcessIgnoreFile(what string, err error) *ReturnError {
 	return getError(
 		fmt.Errorf("can't process %s ignore file: %w", what, err),
-		121,
+		cantProcessIgnoreFileErr,
 	)
 }
 
@@ -106,7 +158,7 @@ func getErrCantProcessIgnoreFile(what string, err error) *ReturnError {
 // already reports the offending file and the merge failure. The outer
 // "failed to load spec" wrap is misleading because loading succeeded.
 func getErrFailedToFlatten(err *load.FlattenError) *ReturnError {
-	return getError(err, 122)
+	return getError(err, failedToFlattenErr)
 }
 
 // getErrDisallowedExternalRef returns the dedicated exit code (123) for a spec
@@ -115,7 +167,42 @@ func getErrFailedToFlatten(err *load.FlattenError) *ReturnError {
 // "set allow-external-refs" remedy without matching error text. 123 is kept
 // under 125 to stay clear of the shell's reserved 126/127/128+ range.
 func getErrDisallowedExternalRef(err error) *ReturnError {
-	return getError(err, 123)
+	return getError(err, disallowedExternalRefErr)
+}
+
+func getErrServeFailed(err error) *ReturnError {
+	return getError(
+		fmt.Errorf("server failed: %w", err),
+		serveFailedErr,
+	)
+}
+
+func getErrCantProcessConsumerUsage(path string, err error) *ReturnError {
+	return getError(
+		fmt.Errorf("can't process consumer usage file %s: %w", path, err),
+		cantProcessConsumerUsageErr,
+	)
+}
+
+func getErrCantProcessTraffic(path string, err error) *ReturnError {
+	return getError(
+		fmt.Errorf("can't process traffic file %s: %w", path, err),
+		cantProcessTrafficErr,
+	)
+}
+
+func getErrCantProcessProto(path string, err error) *ReturnError {
+	return getError(
+		fmt.Errorf("can't process protobuf descriptor set %s: %w", path, err),
+		cantProcessProtoErr,
+	)
+}
+
+func getErrCantProcessBaseline(path string, err error) *ReturnError {
+	return getError(
+		fmt.Errorf("can't process baseline file %s: %w", path, err),
+		cantProcessBaselineErr,
+	)
 }
 
 // asFlattenError returns the *load.FlattenError in err's chain, or nil if err is
@@ -1,6 +1,9 @@
 package internal
 
 import (
+	"io"
+	"os"
+
 	"github.com/oasdiff/oasdiff/diff"
 	"github.com/oasdiff/oasdiff/load"
 	"github.com/spf13/viper"
@@ -10,6 +13,10 @@ type Flags struct {
 	v        *viper.Viper
 	base     *load.Source
 	revision *load.Source
+	// watch is set while the command runs in watch mode
+	watch *watchState
+	// stderr is the command's stderr, for warnings that must not corrupt the output
+	stderr io.Writer
 }
 
 func NewFlags() *Flags {
@@ -64,6 +71,10 @@ func (flags *Flags) getFlattenParams() bool {
 	return flags.v.GetBool("flatten-params")
 }
 
+func (flags *Flags) getNormalize() bool {
+	return flags.v.GetBool("normalize")
+}
+
 func (flags *Flags) getCaseInsensitiveHeaders() bool {
 	return flags.v.GetBool("case-insensitive-headers")
 }
@@ -76,6 +87,11 @@ func (flags *Flags) getAutoUpgrade() bool {
 	return flags.v.GetBool("auto-upgrade")
 }
 
+// getBaseSpec returns the base that the lsp command compares the open specs with
+func (flags *Flags) getBaseSpec() string {
+	return flags.v.GetString("base")
+}
+
 func (flags *Flags) getFetch() bool {
 	return flags.v.GetBool("fetch")
 }
@@ -142,6 +158,10 @@ func (flags *Flags) getLevel() string {
 	return flags.v.GetString("level")
 }
 
+func (flags *Flags) getDirection() string {
+	return flags.v.GetString("direction")
+}
+
 func (flags *Flags) getFailOnDiff() bool {
 	return flags.v.GetBool("fail-on-diff")
 }
@@ -150,6 +170,10 @@ func (flags *Flags) getSeverityLevelsFile() string {
 	return flags.v.GetString("severity-levels")
 }
 
+func (flags *Flags) getCustomRulesFile() string {
+	return flags.v.GetString("custom-rules")
+}
+
 func (flags *Flags) getExcludeElements() []string {
 	return fixViperStringSlice(flags.v.GetStringSlice("exclude-elements"))
 }
@@ -158,6 +182,18 @@ func (flags *Flags) getExcludeExtensions() []string {
 	return fixViperStringSlice(flags.v.GetStringSlice("exclude-extensions"))
 }
 
+func (flags *Flags) getWatch() bool {
+	return flags.v.GetBool("watch")
+}
+
+// getWatchedSource returns the spec that watch mode watches: the revision, or the only spec of a single-spec command
+func (flags *Flags) getWatchedSource() *load.Source {
+	if flags.revision != nil {
+		return flags.revision
+	}
+	return flags.base
+}
+
 func (flags *Flags) setBase(source *load.Source) {
 	flags.base = source
 }
@@ -166,6 +202,18 @@ func (flags *Flags) setRevision(source *load.Source) {
 	flags.revision = source
 }
 
+// getStderr returns the command's stderr, or the process's when the flags come from a tool call or a request
+func (flags *Flags) getStderr() io.Writer {
+	if flags.stderr == nil {
+		return os.Stderr
+	}
+	return flags.stderr
+}
+
+func (flags *Flags) setStderr(stderr io.Writer) {
+	flags.stderr = stderr
+}
+
 func (flags *Flags) addExcludeElements(element string) {
 	flags.v.Set("exclude-elements", append(flags.getExcludeElements(), element))
 }
@@ -185,3 +233,59 @@ func (flags *Flags) getTemplate() string {
 func (flags *Flags) getStabilityLevel() string {
 	return flags.v.GetString("stability-level")
 }
+
+func (flags *Flags) getListen() string {
+	return flags.v.GetString("listen")
+}
+
+func (flags *Flags) getMaxRequestSize() int64 {
+	return flags.v.GetInt64("max-request-size")
+}
+
+func (flags *Flags) getBaseline() string {
+	return flags.v.GetString("baseline")
+}
+
+func (flags *Flags) getBaselineWrite() string {
+	return flags.v.GetString("baseline-write")
+}
+
+func (flags *Flags) getConsumerUsage() []string {
+	return flags.v.GetStringSlice("consumer-usage")
+}
+
+func (flags *Flags) getTraffic() string {
+	return flags.v.GetString("traffic")
+}
+
+func (flags *Flags) getTrafficDemoteBelow() uint {
+	return flags.v.GetUint("traffic-demote-below")
+}
+
+func (flags *Flags) getTrafficEscalateAt() uint {
+	return flags.v.GetUint("traffic-escalate-at")
+}
+
+func (flags *Flags) getBaseProto() string {
+	return flags.v.GetString("base-proto")
+}
+
+func (flags *Flags) getRevisionProto() string {
+	return flags.v.GetString("revision-proto")
+}
+
+func (flags *Flags) getAsOf() string {
+	return flags.v.GetString("as-of")
+}
+
+func (flags *Flags) getGitTags() string {
+	return flags.v.GetString("git-tags")
+}
+
+func (flags *Flags) getBaseFromLatestTag() string {
+	return flags.v.GetString("base-from-latest-tag")
+}
+
+func (flags *Flags) getBaseMergeBase() string {
+	return flags.v.GetString("base-merge-base")
+}
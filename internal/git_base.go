@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/spf13/cobra"
+)
+
+// addGitBaseFlags registers the flags that pick the base spec from git, so
+// that the command takes the revision as its only argument.
+func addGitBaseFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("base-from-latest-tag", "", "use the spec at the latest git tag matching this pattern (default any tag) as the base; pass the revision as the only argument")
+	cmd.PersistentFlags().Lookup("base-from-latest-tag").NoOptDefVal = "*"
+	cmd.PersistentFlags().String("base-merge-base", "", "use the spec at the merge base of this branch and the revision as the base; pass the revision as the only argument")
+}
+
+func hasGitBaseFlags(cmd *cobra.Command) bool {
+	return cmd.Flags().Lookup("base-merge-base") != nil
+}
+
+// withGitBase returns the arguments with the base spec that --base-from-latest-tag
+// or --base-merge-base picks prepended: the same file as the revision, at the
+// tag or commit that git resolves.
+func withGitBase(cmd *cobra.Command, flags *Flags, args []string) ([]string, *ReturnError) {
+
+	if !hasGitBaseFlags(cmd) {
+		return args, nil
+	}
+
+	tagPattern, branch := flags.getBaseFromLatestTag(), flags.getBaseMergeBase()
+
+	if tagPattern == "" && branch == "" {
+		if len(args) < 2 {
+			return nil, getErrInvalidFlags(errors.New("please specify base and revision arguments, or only the revision with --base-from-latest-tag or --base-merge-base"))
+		}
+		return args, nil
+	}
+
+	if tagPattern != "" && branch != "" {
+		return nil, getErrInvalidFlags(errors.New("--base-from-latest-tag and --base-merge-base can't be used together"))
+	}
+	if len(args) != 1 {
+		return nil, getErrInvalidFlags(errors.New("with --base-from-latest-tag or --base-merge-base, please specify the revision as the only argument"))
+	}
+	if flags.getComposed() {
+		return nil, getErrInvalidFlags(errors.New("--base-from-latest-tag and --base-merge-base don't support composed mode"))
+	}
+
+	rev, path, returnErr := getGitRevisionPath(args[0])
+	if returnErr != nil {
+		return nil, returnErr
+	}
+
+	var ref string
+	var err error
+	if tagPattern != "" {
+		ref, err = load.LatestGitTag(tagPattern, rev, flags.getFetch())
+	} else {
+		ref, err = load.GitMergeBase(branch, rev, flags.getFetch())
+	}
+	if err != nil {
+		return nil, getErrFailedToResolveGitBase(err)
+	}
+
+	return []string{ref + ":" + path, args[0]}, nil
+}
+
+// getGitRevisionPath splits the revision argument into the commit it is at
+// and its path in the repository. A local file is at HEAD.
+func getGitRevisionPath(revision string) (string, string, *ReturnError) {
+
+	source := load.NewSource(revision)
+
+	switch {
+	case source.IsGitRevision():
+		rev, path, _ := strings.Cut(revision, ":")
+		return rev, path, nil
+	case source.IsFile():
+		path, err := load.GitRepoPath(revision)
+		if err != nil {
+			return "", "", getErrFailedToResolveGitBase(err)
+		}
+		return "HEAD", path, nil
+	default:
+		return "", "", getErrInvalidFlags(fmt.Errorf("with --base-from-latest-tag or --base-merge-base, the revision must be a local file or a git revision, not %s", source.Out()))
+	}
+}
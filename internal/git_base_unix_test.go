@@ -0,0 +1,93 @@
+//go:build unix
+
+package internal_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/oasdiff/oasdiff/formatters"
+	"github.com/oasdiff/oasdiff/internal"
+	"github.com/stretchr/testify/require"
+)
+
+// gitBaseRepo sets up a git repo with api/openapi.yaml committed on main and
+// tagged v1.0.0, and a feature branch that adds a response, and changes into
+// the repo's api directory.
+func gitBaseRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	gitRun("git", "init", "--initial-branch=main")
+	gitRun("git", "config", "user.email", "test@test.com")
+	gitRun("git", "config", "user.name", "Test")
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "api"), 0755))
+	specPath := filepath.Join(dir, "api", "openapi.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(minimalSpecV1), 0644))
+	gitRun("git", "add", ".")
+	gitRun("git", "commit", "-m", "v1")
+	gitRun("git", "tag", "v1.0.0")
+
+	gitRun("git", "checkout", "-b", "feature")
+	require.NoError(t, os.WriteFile(specPath, []byte(minimalSpecV2), 0644))
+	gitRun("git", "commit", "-am", "add 404")
+
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Join(dir, "api")))
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+
+	return dir
+}
+
+func requireResponseAdded(t *testing.T, stdout []byte) {
+	t.Helper()
+	changes := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout, &changes))
+	require.Len(t, changes, 1)
+	require.Equal(t, "response-non-success-status-added", changes[0].Id)
+}
+
+func Test_BaseFromLatestTag(t *testing.T) {
+	gitBaseRepo(t)
+
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff changelog --base-from-latest-tag=v* openapi.yaml --format json"), &stdout, io.Discard))
+	requireResponseAdded(t, stdout.Bytes())
+}
+
+func Test_BaseFromLatestTagAnyTag(t *testing.T) {
+	gitBaseRepo(t)
+
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff changelog openapi.yaml --base-from-latest-tag --format json"), &stdout, io.Discard))
+	requireResponseAdded(t, stdout.Bytes())
+}
+
+func Test_BaseMergeBase(t *testing.T) {
+	gitBaseRepo(t)
+
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff changelog --base-merge-base main HEAD:api/openapi.yaml --format json"), &stdout, io.Discard))
+	requireResponseAdded(t, stdout.Bytes())
+}
+
+func Test_BaseFromLatestTagNoMatch(t *testing.T) {
+	gitBaseRepo(t)
+
+	require.Equal(t, 109, internal.Run(cmdToArgs("oasdiff breaking --base-from-latest-tag=release-* openapi.yaml"), io.Discard, io.Discard))
+}
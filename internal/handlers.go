@@ -3,6 +3,9 @@ package internal
 import (
 	"errors"
 	"io"
+	"os"
+	"os/signal"
+	"slices"
 
 	"github.com/oasdiff/oasdiff/load"
 	"github.com/spf13/cobra"
@@ -12,9 +15,12 @@ const specHelp = `
 Base and revision can be a path to a file, a URL, a git ref (e.g. main:openapi.yaml), or '-' to read standard input.
 In 'composed' mode, base and revision can be a glob and oasdiff will compare matching endpoints between the two sets of files.`
 
+const gitBaseHelp = `
+With --base-from-latest-tag or --base-merge-base, pass only the revision: the base is the same file at the tag or commit that git picks.`
+
 func getParseArgs() cobra.PositionalArgs {
 	return func(cmd *cobra.Command, args []string) error {
-		if len(args) < 2 {
+		if len(args) < 2 && (len(args) == 0 || !hasGitBaseFlags(cmd)) {
 			return errors.New("please specify base and revision arguments as a path to a file, a glob (in composed mode), a URL, a git ref (e.g. main:openapi.yaml), or '-' to read standard input")
 		}
 		if len(args) > 2 {
@@ -40,12 +46,19 @@ func getRun(runner runner) cobra.PositionalArgs {
 	return func(cmd *cobra.Command, args []string) error {
 
 		flags := NewFlags()
+		flags.setStderr(cmd.ErrOrStderr())
 
 		if err := RunViper(cmd, flags.getViper()); err != nil {
 			setReturnValue(cmd, err.Code)
 			return err
 		}
 
+		args, returnErr := withGitBase(cmd, flags, args)
+		if returnErr != nil {
+			setReturnValue(cmd, returnErr.Code)
+			return returnErr
+		}
+
 		if len(args) > 0 {
 			base := load.NewSource(args[0])
 			base.Fetch = flags.getFetch()
@@ -61,6 +74,16 @@ func getRun(runner runner) cobra.PositionalArgs {
 		// by now flags have been parsed successfully so we don't need to show usage on any errors
 		cmd.Root().SilenceUsage = true
 
+		if flags.getWatch() {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			if err := runWatch(ctx, flags, runner, cmd.OutOrStdout(), cmd.ErrOrStderr()); err != nil {
+				setReturnValue(cmd, err.Code)
+				return err
+			}
+			return nil
+		}
+
 		failEmpty, err := runner(flags, cmd.OutOrStdout())
 		if err != nil {
 			setReturnValue(cmd, err.Code)
@@ -133,7 +156,7 @@ func checkStdinWithComposed(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	if args[0] == "-" || args[1] == "-" {
+	if slices.Contains(args, "-") {
 		return errors.New("can't read from stdin in composed mode")
 	}
 
@@ -0,0 +1,237 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/checker/localizations"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/oasdiff/oasdiff/formatters"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/spf13/cobra"
+)
+
+const historyCmd = "history"
+
+func getHistoryCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:   "history spec1 spec2 [spec3 ...] [flags]",
+		Short: "Display changelog across a sequence of releases",
+		Long: `Display the changes of a sequence of releases, each compared with the one before it.
+The specs are given oldest first and each can be a path to a file, a URL, a git ref (e.g. v1.0.0:openapi.yaml), or '-' to read standard input.
+
+With --git-tags, the only argument is the spec's path in the repository, and the releases are the local git tags
+matching the pattern, in version order; for example:
+  oasdiff history --git-tags 'v*' openapi.yaml
+
+Each release is headed by its info.version. Changes are matched across releases by fingerprint, to show the
+release that first introduced a change, the earlier change it reverts, and the later release that reverted it.`,
+		Args: getParseHistoryArgs(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// the specs are passed to runHistory rather than set as base and revision
+			return getRun(func(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
+				return runHistory(flags, stdout, args)
+			})(cmd, nil)
+		},
+	}
+
+	addCommonDiffFlags(&cmd)
+	hideFlag(&cmd, "composed")
+	cmd.PersistentFlags().String("git-tags", "", "compare the spec at each local git tag matching this pattern (e.g. 'v*'), in version order")
+	enumWithOptions(&cmd, newEnumValue(localizations.GetSupportedLanguages(), localizations.LangDefault), "lang", "l", "language for localized output")
+	enumWithOptions(&cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputHistory), string(formatters.FormatMarkdown)), "format", "f", "output format")
+	enumWithOptions(&cmd, newEnumValue(GetSupportedLevels(), LevelInfo), "level", "", "output changes with this level or higher")
+	cmd.PersistentFlags().Uint("deprecation-days-beta", checker.DefaultBetaDeprecationDays, "min days required between deprecating a beta resource and removing it")
+	cmd.PersistentFlags().Uint("deprecation-days-stable", checker.DefaultStableDeprecationDays, "min days required between deprecating a stable resource and removing it")
+	cmd.PersistentFlags().String("severity-levels", "", "configuration file for custom severity levels")
+	cmd.PersistentFlags().String("custom-rules", "", "YAML file of custom rules to check in addition to the built-in ones")
+	cmd.PersistentFlags().StringSlice("attributes", nil, "OpenAPI Extensions to include in json or yaml output")
+	enumWithOptions(&cmd, newEnumValue(checker.GetSupportedStabilityLevels(), ""), "stability-level", "", "minimum stability level to include")
+
+	return &cmd
+}
+
+func getParseHistoryArgs() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if composed, _ := cmd.Flags().GetBool("composed"); composed {
+			return errors.New("history doesn't support composed mode")
+		}
+
+		if gitTags, _ := cmd.Flags().GetString("git-tags"); gitTags != "" {
+			if len(args) != 1 {
+				return errors.New("with --git-tags, please specify the path of the spec in the repository as the only argument")
+			}
+			return nil
+		}
+
+		if len(args) < 2 {
+			return errors.New("please specify at least two specs, oldest first, as paths to files, URLs, git refs (e.g. v1.0.0:openapi.yaml), or '-' to read standard input")
+		}
+
+		stdin := 0
+		for _, arg := range args {
+			if arg == "-" {
+				stdin++
+			}
+		}
+		if stdin > 1 {
+			return errors.New("standard input ('-') can be read for one spec only")
+		}
+
+		return nil
+	}
+}
+
+func runHistory(flags *Flags, stdout io.Writer, args []string) (bool, *ReturnError) {
+
+	level, err := checker.NewLevel(flags.getLevel())
+	if err != nil {
+		return false, getErrInvalidFlags(fmt.Errorf("invalid level value: %q", flags.getLevel()))
+	}
+
+	sources, returnErr := getHistorySources(flags, args)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	specs, returnErr := loadHistorySpecs(flags, sources)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	bcConfig, returnErr := getCheckerConfig(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	releases := make([]checker.Release, 0, len(specs)-1)
+	for i := 1; i < len(specs); i++ {
+		release, returnErr := getRelease(flags, bcConfig, level, specs[i-1], specs[i])
+		if returnErr != nil {
+			return false, returnErr
+		}
+		releases = append(releases, release)
+	}
+
+	if returnErr := outputHistory(flags, stdout, checker.NewHistory(releases), specs[0].GetVersion(), specs[len(specs)-1].GetVersion()); returnErr != nil {
+		return false, returnErr
+	}
+
+	return false, nil
+}
+
+// getHistorySources returns the specs of the history, oldest first: the
+// arguments, or the spec path at each tag matching --git-tags.
+func getHistorySources(flags *Flags, args []string) ([]*load.Source, *ReturnError) {
+
+	paths := args
+	if pattern := flags.getGitTags(); pattern != "" {
+		tags, err := load.GitTags(pattern)
+		if err != nil {
+			return nil, getErrFailedToListGitTags(pattern, err)
+		}
+		if len(tags) < 2 {
+			return nil, getErrFailedToListGitTags(pattern, fmt.Errorf("found %d matching tags, at least two are needed", len(tags)))
+		}
+
+		paths = make([]string, len(tags))
+		for i, tag := range tags {
+			paths[i] = tag + ":" + args[0]
+		}
+	}
+
+	sources := make([]*load.Source, len(paths))
+	for i, path := range paths {
+		sources[i] = load.NewSource(path)
+		sources[i].Fetch = flags.getFetch()
+	}
+	return sources, nil
+}
+
+func loadHistorySpecs(flags *Flags, sources []*load.Source) ([]*load.SpecInfo, *ReturnError) {
+
+	loader := openapi3.NewLoader()
+	loader.IncludeOrigin = true
+	loader.IsExternalRefsAllowed = flags.getAllowExternalRefs()
+
+	specs := make([]*load.SpecInfo, len(sources))
+	for i, source := range sources {
+		specInfo, err := load.NewSpecInfo(loader, source, getLoadOptions(flags)...)
+		if err != nil {
+			return nil, getErrFailedToLoadSpec(fmt.Sprintf("release %d", i+1), source, err)
+		}
+		specs[i] = specInfo
+	}
+
+	autoUpgradeSpecs(flags.getAutoUpgrade(), specs...)
+
+	return specs, nil
+}
+
+// getRelease compares a release with the previous one in both directions: the
+// changes from previous to current are the release's changes, and those from
+// current back to previous are what a later release reverting it would report.
+func getRelease(flags *Flags, bcConfig *checker.Config, level checker.Level, previous, current *load.SpecInfo) (checker.Release, *ReturnError) {
+
+	config := flags.toConfig()
+	changes, returnErr := getHistoryChanges(config, bcConfig, level, previous, current)
+	if returnErr != nil {
+		return checker.Release{}, returnErr
+	}
+
+	// the reverse comparison swaps the sides, so it swaps their path prefixes too
+	reverseConfig := flags.toConfig()
+	reverseConfig.PathPrefixBase, reverseConfig.PathPrefixRevision = config.PathPrefixRevision, config.PathPrefixBase
+	reverseConfig.PathStripPrefixBase, reverseConfig.PathStripPrefixRevision = config.PathStripPrefixRevision, config.PathStripPrefixBase
+
+	// every reverse change is kept so that reverts are found whatever --level is
+	reverse, returnErr := getHistoryChanges(reverseConfig, bcConfig, checker.INFO, current, previous)
+	if returnErr != nil {
+		return checker.Release{}, returnErr
+	}
+
+	return checker.Release{
+		Version: current.GetVersion(),
+		Source:  current.Url,
+		Changes: changes,
+		Reverse: reverse,
+	}, nil
+}
+
+func getHistoryChanges(config *diff.Config, bcConfig *checker.Config, level checker.Level, s1, s2 *load.SpecInfo) (checker.Changes, *ReturnError) {
+
+	diffReport, operationsSources, err := diff.GetWithOperationsSourcesMap(config, s1, s2)
+	if err != nil {
+		return nil, getErrDiffFailed(err)
+	}
+
+	return checker.CheckBackwardCompatibilityUntilLevel(bcConfig, diffReport, operationsSources, level), nil
+}
+
+func outputHistory(flags *Flags, stdout io.Writer, history checker.History, baseVersion, revisionVersion string) *ReturnError {
+
+	// formatter lookup
+	formatter, err := formatters.Lookup(flags.getFormat(), formatters.FormatterOpts{
+		Language:        flags.getLang(),
+		BaseVersion:     baseVersion,
+		RevisionVersion: revisionVersion,
+	})
+	if err != nil {
+		return getErrUnsupportedFormat(flags.getFormat(), historyCmd)
+	}
+
+	// render
+	bytes, err := formatter.RenderHistory(history, formatters.NewRenderOpts())
+	if err != nil {
+		return getErrFailedPrint(historyCmd+" "+flags.getFormat(), err)
+	}
+
+	// print output
+	_, _ = fmt.Fprintf(stdout, "%s\n", bytes)
+
+	return nil
+}
@@ -0,0 +1,455 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/oasdiff/oasdiff/build"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/spf13/cobra"
+)
+
+func getLSPCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:   "lsp [flags]",
+		Short: "Run a Language Server Protocol server over stdio",
+		Long: `Run a Language Server Protocol (LSP) server that speaks JSON-RPC over standard
+input and output, so an editor can show oasdiff's findings while a spec is being written.
+
+Each OpenAPI spec open in the editor is validated, and compared with the spec
+given by --base, whenever it or a file that it references changes. Validation
+findings and breaking changes are published as diagnostics at the lines they
+refer to, including lines in the referenced files. Hovering over a diagnostic
+shows the description of its rule, and code actions mark the changed operation
+as deprecated or add the change to the --err-ignore or --warn-ignore file.
+
+The base can be a path to a file, a URL or a git ref (e.g. origin/main:openapi.yaml).
+Without a base, only validation findings are published. The .oasdiff.* config
+file is honored, so the base can be set there for everyone working on the spec.
+`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			flags := NewFlags()
+			if err := RunViper(cmd, flags.getViper()); err != nil {
+				setReturnValue(cmd, err.Code)
+				return err
+			}
+			if flags.getBaseSpec() == "-" {
+				// standard input carries the protocol
+				err := getErrInvalidFlags(errors.New("the base can't be read from standard input"))
+				setReturnValue(cmd, err.Code)
+				return err
+			}
+
+			// the server owns stdout from here on; cobra must not print usage into the protocol stream
+			cmd.Root().SilenceUsage = true
+			return newLSPServer(flags, cmd.OutOrStdout(), cmd.ErrOrStderr()).serve(cmd.InOrStdin())
+		},
+	}
+
+	cmd.PersistentFlags().String("base", "", "spec to find breaking changes against: a path to a file, a URL or a git ref (e.g. origin/main:openapi.yaml)")
+	addCommonDiffFlags(&cmd)
+	addCommonBreakingFlags(&cmd)
+
+	// the server compares single specs and reports diagnostics rather than a rendered output,
+	// so these are accepted, as a shared config file may set them, but ignored
+	for _, flag := range []string{"composed", "format", "color", "template", "attributes"} {
+		hideFlag(&cmd, flag)
+	}
+
+	return &cmd
+}
+
+type lspMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type lspResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *mcpError       `json:"error,omitempty"`
+}
+
+type lspNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+// LSP error codes, in addition to the JSON-RPC 2.0 ones
+const (
+	lspServerNotInitialized = -32002
+)
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+// overlaps reports whether the two ranges share a line
+func (r lspRange) overlaps(other lspRange) bool {
+	return r.Start.Line <= other.End.Line && other.Start.Line <= r.End.Line
+}
+
+type lspTextDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type lspTextDocumentItem struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type lspDidOpenParams struct {
+	TextDocument lspTextDocumentItem `json:"textDocument"`
+}
+
+type lspDidChangeParams struct {
+	TextDocument   lspTextDocumentIdentifier `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type lspDidSaveParams struct {
+	TextDocument lspTextDocumentIdentifier `json:"textDocument"`
+	Text         *string                   `json:"text"`
+}
+
+type lspDidCloseParams struct {
+	TextDocument lspTextDocumentIdentifier `json:"textDocument"`
+}
+
+type lspTextDocumentPositionParams struct {
+	TextDocument lspTextDocumentIdentifier `json:"textDocument"`
+	Position     lspPosition               `json:"position"`
+}
+
+type lspCodeActionParams struct {
+	TextDocument lspTextDocumentIdentifier `json:"textDocument"`
+	Range        lspRange                  `json:"range"`
+}
+
+type lspInitializeResult struct {
+	Capabilities lspServerCapabilities `json:"capabilities"`
+	ServerInfo   lspServerInfo         `json:"serverInfo"`
+}
+
+type lspServerCapabilities struct {
+	TextDocumentSync   lspTextDocumentSyncOptions `json:"textDocumentSync"`
+	HoverProvider      bool                       `json:"hoverProvider"`
+	CodeActionProvider lspCodeActionOptions       `json:"codeActionProvider"`
+}
+
+type lspTextDocumentSyncOptions struct {
+	OpenClose bool           `json:"openClose"`
+	Change    int            `json:"change"`
+	Save      lspSaveOptions `json:"save"`
+}
+
+type lspSaveOptions struct {
+	IncludeText bool `json:"includeText"`
+}
+
+type lspCodeActionOptions struct {
+	CodeActionKinds []string `json:"codeActionKinds"`
+}
+
+type lspServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// lspTextDocumentSyncFull asks the client to send the full text of a document on every change
+const lspTextDocumentSyncFull = 1
+
+// lspServer is a language server that publishes the validation findings and breaking changes of the open specs
+type lspServer struct {
+	flags  *Flags
+	out    io.Writer
+	stderr io.Writer
+
+	initialized bool
+	shutdown    bool
+
+	// documents holds the text of the documents open in the editor, by absolute path
+	documents map[string]string
+	// revisions holds the spec last loaded from each open root document
+	revisions map[string]*load.SpecInfo
+	// problems holds the problems last published for each file
+	problems map[string][]lspProblem
+	// base is the base spec, when it is loaded from a source that isn't a local file
+	base *load.SpecInfo
+	// lines caches the lines of the files read during an analysis
+	lines map[string][]string
+}
+
+func newLSPServer(flags *Flags, out, stderr io.Writer) *lspServer {
+	return &lspServer{
+		flags:     flags,
+		out:       out,
+		stderr:    stderr,
+		documents: map[string]string{},
+		revisions: map[string]*load.SpecInfo{},
+		problems:  map[string][]lspProblem{},
+	}
+}
+
+// serve reads LSP messages from in and handles them, one at a time and in order, until the client sends exit or closes in
+func (server *lspServer) serve(in io.Reader) error {
+	reader := bufio.NewReader(in)
+
+	for {
+		body, err := readLSPMessage(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var message lspMessage
+		if err := json.Unmarshal(body, &message); err != nil {
+			if err := server.send(lspResponse{JSONRPC: "2.0", Id: json.RawMessage("null"), Error: &mcpError{Code: mcpParseError, Message: err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if message.Method == "exit" {
+			if !server.shutdown {
+				return errors.New("the client asked the language server to exit before shutting it down")
+			}
+			return nil
+		}
+
+		result, rpcErr := server.handle(message)
+		if len(message.Id) == 0 {
+			// notification: no response
+			continue
+		}
+
+		response := lspResponse{JSONRPC: "2.0", Id: message.Id, Result: result, Error: rpcErr}
+		if rpcErr != nil {
+			response.Result = nil
+		} else if response.Result == nil {
+			response.Result = json.RawMessage("null")
+		}
+		if err := server.send(response); err != nil {
+			return err
+		}
+	}
+}
+
+func (server *lspServer) handle(message lspMessage) (json.RawMessage, *mcpError) {
+	if server.shutdown {
+		return nil, &mcpError{Code: mcpInvalidRequest, Message: "the server is shut down"}
+	}
+	if !server.initialized && message.Method != "initialize" {
+		return nil, &mcpError{Code: lspServerNotInitialized, Message: "the server isn't initialized"}
+	}
+
+	switch message.Method {
+	case "initialize":
+		server.initialized = true
+		return getLSPResult(server.initialize())
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		server.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params lspDidOpenParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, &mcpError{Code: mcpInvalidParams, Message: err.Error()}
+		}
+		if path, ok := getLSPPath(params.TextDocument.URI); ok {
+			server.documents[path] = params.TextDocument.Text
+			server.analyze()
+		}
+		return nil, nil
+	case "textDocument/didChange":
+		var params lspDidChangeParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, &mcpError{Code: mcpInvalidParams, Message: err.Error()}
+		}
+		// the server asks for full sync, so the last change holds the whole text
+		if path, ok := getLSPPath(params.TextDocument.URI); ok && len(params.ContentChanges) > 0 {
+			server.documents[path] = params.ContentChanges[len(params.ContentChanges)-1].Text
+			server.analyze()
+		}
+		return nil, nil
+	case "textDocument/didSave":
+		var params lspDidSaveParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, &mcpError{Code: mcpInvalidParams, Message: err.Error()}
+		}
+		if path, ok := getLSPPath(params.TextDocument.URI); ok {
+			if params.Text != nil {
+				server.documents[path] = *params.Text
+			}
+			// a saved file, like an ignore file, may affect the specs without being referenced by them
+			server.analyze()
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		var params lspDidCloseParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, &mcpError{Code: mcpInvalidParams, Message: err.Error()}
+		}
+		if path, ok := getLSPPath(params.TextDocument.URI); ok {
+			delete(server.documents, path)
+			delete(server.revisions, path)
+			server.analyze()
+		}
+		return nil, nil
+	case "textDocument/hover":
+		var params lspTextDocumentPositionParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, &mcpError{Code: mcpInvalidParams, Message: err.Error()}
+		}
+		return getLSPResult(server.hover(params))
+	case "textDocument/codeAction":
+		var params lspCodeActionParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, &mcpError{Code: mcpInvalidParams, Message: err.Error()}
+		}
+		return getLSPResult(server.getCodeActions(params))
+	}
+
+	if len(message.Id) == 0 {
+		// notifications that the server doesn't handle, like $/cancelRequest, are ignored
+		return nil, nil
+	}
+	return nil, &mcpError{Code: mcpMethodNotFound, Message: fmt.Sprintf("method not found: %s", message.Method)}
+}
+
+func (server *lspServer) initialize() lspInitializeResult {
+	return lspInitializeResult{
+		Capabilities: lspServerCapabilities{
+			TextDocumentSync: lspTextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    lspTextDocumentSyncFull,
+				Save:      lspSaveOptions{IncludeText: true},
+			},
+			HoverProvider:      true,
+			CodeActionProvider: lspCodeActionOptions{CodeActionKinds: []string{"quickfix"}},
+		},
+		ServerInfo: lspServerInfo{
+			Name:    "oasdiff",
+			Version: build.Version,
+		},
+	}
+}
+
+func getLSPResult(result any) (json.RawMessage, *mcpError) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, &mcpError{Code: mcpInternalError, Message: err.Error()}
+	}
+	return data, nil
+}
+
+func (server *lspServer) send(message any) error {
+	if err := writeLSPMessage(server.out, message); err != nil {
+		return fmt.Errorf("failed to write a language server message: %w", err)
+	}
+	return nil
+}
+
+func (server *lspServer) notify(method string, params any) {
+	if err := server.send(lspNotification{JSONRPC: "2.0", Method: method, Params: params}); err != nil {
+		_, _ = fmt.Fprintln(server.stderr, err)
+	}
+}
+
+// readLSPMessage reads the body of a message framed with LSP's base protocol headers
+func readLSPMessage(reader *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) && (line != "" || length >= 0) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil || length < 0 {
+				return nil, fmt.Errorf("invalid Content-Length header %q", strings.TrimSpace(value))
+			}
+		}
+	}
+
+	if length < 0 {
+		return nil, errors.New("missing Content-Length header")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return body, nil
+}
+
+func writeLSPMessage(out io.Writer, message any) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	return err
+}
+
+// getLSPPath returns the path of a file URI
+func getLSPPath(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return "", false
+	}
+
+	path := u.Path
+	// on Windows, file:///C:/openapi.yaml stands for C:/openapi.yaml
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.Clean(filepath.FromSlash(path)), true
+}
+
+// getLSPURI returns the file URI of a path
+func getLSPURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
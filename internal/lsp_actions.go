@@ -0,0 +1,331 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/diff"
+	"go.yaml.in/yaml/v3"
+)
+
+type lspMarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type lspHover struct {
+	Contents lspMarkupContent `json:"contents"`
+	Range    lspRange         `json:"range"`
+}
+
+type lspTextEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type lspCreateFile struct {
+	Kind    string               `json:"kind"`
+	URI     string               `json:"uri"`
+	Options lspCreateFileOptions `json:"options"`
+}
+
+type lspCreateFileOptions struct {
+	IgnoreIfExists bool `json:"ignoreIfExists"`
+}
+
+type lspTextDocumentEdit struct {
+	TextDocument lspVersionedTextDocumentIdentifier `json:"textDocument"`
+	Edits        []lspTextEdit                      `json:"edits"`
+}
+
+type lspVersionedTextDocumentIdentifier struct {
+	URI     string `json:"uri"`
+	Version *int   `json:"version"`
+}
+
+type lspWorkspaceEdit struct {
+	Changes         map[string][]lspTextEdit `json:"changes,omitempty"`
+	DocumentChanges []any                    `json:"documentChanges,omitempty"`
+}
+
+type lspCodeAction struct {
+	Title       string           `json:"title"`
+	Kind        string           `json:"kind"`
+	Diagnostics []lspDiagnostic  `json:"diagnostics,omitempty"`
+	Edit        lspWorkspaceEdit `json:"edit"`
+}
+
+// lspIgnoreStructuredPattern matches the top-level ignore key of a structured ignore file
+var lspIgnoreStructuredPattern = regexp.MustCompile(`(?m)^"?ignore"?\s*:`)
+
+// lspIgnoreItemPattern matches the indentation of the first entry of a structured ignore file
+var lspIgnoreItemPattern = regexp.MustCompile(`(?m)^([ \t]*)- `)
+
+// getProblemsAt returns the problems published for the file in the given range
+func (server *lspServer) getProblemsAt(uri string, r lspRange) []lspProblem {
+	path, ok := getLSPPath(uri)
+	if !ok {
+		return nil
+	}
+
+	result := []lspProblem{}
+	for _, problem := range server.problems[path] {
+		if problem.diagnostic.Range.overlaps(r) {
+			result = append(result, problem)
+		}
+	}
+	return result
+}
+
+// hover describes the problems at the position: their message, the comment that explains them, and the description of their rule
+func (server *lspServer) hover(params lspTextDocumentPositionParams) *lspHover {
+	problems := server.getProblemsAt(params.TextDocument.URI, lspRange{Start: params.Position, End: params.Position})
+	if len(problems) == 0 {
+		return nil
+	}
+
+	l := checker.NewLocalizer(server.flags.getLang())
+	sections := make([]string, len(problems))
+	for i, problem := range problems {
+		sections[i] = getLSPHoverText(problem, l)
+	}
+
+	return &lspHover{
+		Contents: lspMarkupContent{
+			Kind:  "markdown",
+			Value: strings.Join(sections, "\n\n---\n\n"),
+		},
+		Range: problems[0].diagnostic.Range,
+	}
+}
+
+func getLSPHoverText(problem lspProblem, l checker.Localizer) string {
+	id, text, comment := problem.diagnostic.Code, problem.diagnostic.Message, ""
+	switch {
+	case problem.change != nil:
+		comment = problem.change.GetComment(l)
+	case problem.finding != nil:
+		comment = problem.finding.Comment
+	}
+
+	parts := []string{text}
+	if id != "" {
+		parts[0] = fmt.Sprintf("**%s**: %s", id, text)
+	}
+	if comment != "" {
+		parts = append(parts, comment)
+	}
+	if description := l(id + "-description"); id != "" && description != id+"-description" {
+		parts = append(parts, description)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// getCodeActions returns the fixes for the breaking changes in the range: marking the changed operation as deprecated, and ignoring the change
+func (server *lspServer) getCodeActions(params lspCodeActionParams) []lspCodeAction {
+	result := []lspCodeAction{}
+	titles := map[string]bool{}
+	add := func(action *lspCodeAction) {
+		if action == nil || titles[action.Title] {
+			return
+		}
+		titles[action.Title] = true
+		result = append(result, *action)
+	}
+
+	for _, problem := range server.getProblemsAt(params.TextDocument.URI, params.Range) {
+		if problem.change == nil {
+			continue
+		}
+		add(server.getDeprecationAction(problem))
+		add(server.getIgnoreAction(problem))
+	}
+	return result
+}
+
+// getDeprecationAction marks the operation of a change as deprecated, with a sunset date that meets the deprecation policy.
+// It is offered for operations that are written in block-style YAML, which can be edited by inserting lines.
+func (server *lspServer) getDeprecationAction(problem lspProblem) *lspCodeAction {
+	spec := server.revisions[problem.root]
+	change := problem.change
+	if spec == nil || spec.Spec.Paths == nil {
+		return nil
+	}
+
+	pathItem := spec.Spec.Paths.Value(change.GetPath())
+	if pathItem == nil {
+		return nil
+	}
+	operation := pathItem.GetOperation(change.GetOperation())
+	if operation == nil || operation.Deprecated || operation.Origin == nil || operation.Origin.Key == nil {
+		return nil
+	}
+
+	key := operation.Origin.Key
+	file := problem.root
+	if key.File != "" {
+		file = key.File
+	}
+	lines := server.getLines(file)
+	if key.Line < 1 || key.Line > len(lines) || !strings.HasSuffix(strings.TrimSpace(lines[key.Line-1]), ":") {
+		return nil
+	}
+
+	indent := getIndent(lines[key.Line-1]) + "  "
+	if key.Line < len(lines) && strings.TrimSpace(lines[key.Line]) != "" && len(getIndent(lines[key.Line])) > len(getIndent(lines[key.Line-1])) {
+		indent = getIndent(lines[key.Line])
+	}
+
+	newText := indent + "deprecated: true\n"
+	if _, ok := operation.Extensions[diff.SunsetExtension]; !ok {
+		if days := server.getDeprecationDays(operation.Extensions); days > 0 {
+			newText += fmt.Sprintf("%s%s: %s\n", indent, diff.SunsetExtension, time.Now().AddDate(0, 0, int(days)).Format("2006-01-02"))
+		}
+	}
+
+	position := lspPosition{Line: key.Line}
+	return &lspCodeAction{
+		Title:       fmt.Sprintf("Mark %s %s as deprecated", strings.ToUpper(change.GetOperation()), change.GetPath()),
+		Kind:        "quickfix",
+		Diagnostics: []lspDiagnostic{problem.diagnostic},
+		Edit: lspWorkspaceEdit{
+			Changes: map[string][]lspTextEdit{
+				getLSPURI(file): {{Range: lspRange{Start: position, End: position}, NewText: newText}},
+			},
+		},
+	}
+}
+
+// getDeprecationDays returns the minimum number of days between deprecating an operation with the given extensions and removing it
+func (server *lspServer) getDeprecationDays(extensions map[string]any) uint {
+	stability, _ := extensions[diff.XStabilityLevelExtension].(string)
+	switch stability {
+	case checker.StabilityDraft, checker.StabilityAlpha:
+		return 0
+	case checker.StabilityBeta:
+		return server.flags.getDeprecationDaysBeta()
+	}
+	return server.flags.getDeprecationDaysStable()
+}
+
+func getIndent(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// getIgnoreAction adds a change to the ignore file of its level: an entry to a structured ignore file, or a line to a free-text one.
+// It is offered when the ignore file is configured; a missing file is created in the structured format.
+func (server *lspServer) getIgnoreAction(problem lspProblem) *lspCodeAction {
+	change := problem.change
+	what, ignoreFile := "warn", server.flags.getWarnIgnoreFile()
+	if change.GetLevel() == checker.ERR {
+		what, ignoreFile = "err", server.flags.getErrIgnoreFile()
+	}
+	if ignoreFile == "" {
+		return nil
+	}
+
+	path, err := filepath.Abs(ignoreFile)
+	if err != nil {
+		return nil
+	}
+	uri := getLSPURI(path)
+
+	text, open := server.documents[path]
+	exists := open
+	if !open {
+		data, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		text, exists = string(data), err == nil
+	}
+
+	newText, err := getIgnoreEntry(text, change, checker.NewLocalizer(server.flags.getLang()))
+	if err != nil {
+		return nil
+	}
+
+	action := lspCodeAction{
+		Title:       fmt.Sprintf("Add %s to the %s ignore file", change.GetId(), what),
+		Kind:        "quickfix",
+		Diagnostics: []lspDiagnostic{problem.diagnostic},
+	}
+
+	if !exists {
+		action.Edit.DocumentChanges = []any{
+			lspCreateFile{Kind: "create", URI: uri, Options: lspCreateFileOptions{IgnoreIfExists: true}},
+			lspTextDocumentEdit{
+				TextDocument: lspVersionedTextDocumentIdentifier{URI: uri},
+				Edits:        []lspTextEdit{{NewText: newText}},
+			},
+		}
+		return &action
+	}
+
+	end := getEndPosition(text)
+	action.Edit.Changes = map[string][]lspTextEdit{
+		uri: {{Range: lspRange{Start: end, End: end}, NewText: newText}},
+	}
+	return &action
+}
+
+// getIgnoreEntry returns the text to append to an ignore file with the given text to ignore a change.
+// A structured file is assumed to end with its list of entries, whose indentation is kept.
+func getIgnoreEntry(text string, change checker.Change, l checker.Localizer) (string, error) {
+	prefix := ""
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		prefix = "\n"
+	}
+
+	if strings.TrimSpace(text) != "" && !lspIgnoreStructuredPattern.MatchString(text) {
+		return prefix + fmt.Sprintf("%s %s %s\n", change.GetOperation(), change.GetPath(), change.GetUncolorizedText(l)), nil
+	}
+
+	entry := checker.IgnoreEntry{
+		Id:        change.GetId(),
+		Operation: change.GetOperation(),
+		Path:      change.GetPath(),
+	}
+	if entry.Operation == "" && entry.Path == "" {
+		// without an operation and a path, the id alone would ignore every change of the rule
+		entry.Fingerprint = checker.Fingerprint(change)
+	}
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode([]checker.IgnoreEntry{entry}); err != nil {
+		return "", err
+	}
+
+	indent := "  "
+	if match := lspIgnoreItemPattern.FindStringSubmatch(text); match != nil {
+		indent = match[1]
+	}
+	if strings.TrimSpace(text) == "" {
+		prefix = "ignore:\n"
+	}
+
+	lines := strings.SplitAfter(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	for i := range lines {
+		lines[i] = indent + lines[i]
+	}
+	return prefix + strings.Join(lines, "") + "\n", nil
+}
+
+// getEndPosition returns the position at the end of the text
+func getEndPosition(text string) lspPosition {
+	lines := strings.Split(text, "\n")
+	last := lines[len(lines)-1]
+	return lspPosition{
+		Line:      len(lines) - 1,
+		Character: getUTF16Length([]rune(strings.TrimSuffix(last, "\r"))),
+	}
+}
@@ -0,0 +1,345 @@
+package internal
+
+import (
+	"errors"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/formatters"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/oasdiff/oasdiff/validate"
+)
+
+// lspRootPattern matches the top-level openapi or swagger field of a root spec, in YAML or JSON
+var lspRootPattern = regexp.MustCompile(`(?m)^\s*"?(openapi|swagger)"?\s*:`)
+
+// lspErrorLinePattern matches the line number in a YAML parse error
+var lspErrorLinePattern = regexp.MustCompile(`\bline (\d+)\b`)
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Code     string   `json:"code,omitempty"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspPublishDiagnosticsParams struct {
+	URI         string          `json:"uri"`
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+}
+
+// LSP diagnostic severities
+const (
+	lspSeverityError       = 1
+	lspSeverityWarning     = 2
+	lspSeverityInformation = 3
+	lspSeverityHint        = 4
+)
+
+// lspProblem is a published diagnostic along with the finding or change that it reports
+type lspProblem struct {
+	// file is the file that the diagnostic is published for
+	file string
+	// root is the spec that the problem was found in
+	root       string
+	diagnostic lspDiagnostic
+	// change is the breaking change, if the problem is one
+	change checker.Change
+	// finding is the validation finding, if the problem is one
+	finding *formatters.Finding
+}
+
+// analyze checks every open spec and publishes the diagnostics of each file, clearing those of files that no longer have any
+func (server *lspServer) analyze() {
+	server.lines = map[string][]string{}
+
+	problems := map[string][]lspProblem{}
+	for _, root := range server.getRoots() {
+		for _, problem := range server.analyzeRoot(root) {
+			problems[problem.file] = append(problems[problem.file], problem)
+		}
+	}
+
+	files := maps.Clone(problems)
+	maps.Copy(files, server.problems)
+	for _, file := range slices.Sorted(maps.Keys(files)) {
+		diagnostics := make([]lspDiagnostic, len(problems[file]))
+		for i, problem := range problems[file] {
+			diagnostics[i] = problem.diagnostic
+		}
+		server.notify("textDocument/publishDiagnostics", lspPublishDiagnosticsParams{
+			URI:         getLSPURI(file),
+			Diagnostics: diagnostics,
+		})
+	}
+	server.problems = problems
+}
+
+// getRoots returns the open documents that are OpenAPI specs, rather than files that specs reference
+func (server *lspServer) getRoots() []string {
+	result := []string{}
+	for path, text := range server.documents {
+		if lspRootPattern.MatchString(text) {
+			result = append(result, path)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+func (server *lspServer) analyzeRoot(root string) []lspProblem {
+	source := load.NewSource(root)
+	spec, err := load.NewSpecInfo(server.newLoader(source), source, getLoadOptions(server.flags)...)
+	if err != nil {
+		delete(server.revisions, root)
+		return []lspProblem{server.newLoadProblem(root, err)}
+	}
+	server.revisions[root] = spec
+
+	result := []lspProblem{}
+	for _, finding := range validate.Validate(spec.Spec, root) {
+		result = append(result, server.newFindingProblem(root, finding))
+	}
+
+	if server.flags.getBaseSpec() == "" {
+		return result
+	}
+
+	changes, returnErr := server.getChanges(spec)
+	if returnErr != nil {
+		return append(result, lspProblem{
+			file:       root,
+			root:       root,
+			diagnostic: server.newDiagnostic(root, 0, 0, lspSeverityError, "", returnErr.Error()),
+		})
+	}
+
+	l := checker.NewLocalizer(server.flags.getLang())
+	for _, change := range changes {
+		result = append(result, server.newChangeProblem(root, spec, change, l))
+	}
+	return result
+}
+
+// newLoader returns a loader that reads the open documents from the editor, and the other files from disk
+func (server *lspServer) newLoader(root *load.Source) *openapi3.Loader {
+	loader := openapi3.NewLoader()
+	loader.IncludeOrigin = true
+	loader.IsExternalRefsAllowed = server.flags.getAllowExternalRefs()
+	loader.ReadFromURIFunc = restrictReader(loader, func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Scheme == "" || location.Scheme == "file" {
+			if text, ok := server.documents[filepath.Clean(filepath.FromSlash(location.Path))]; ok {
+				return []byte(text), nil
+			}
+		}
+		return uncachedReadFromURI(loader, location)
+	}, root)
+	return loader
+}
+
+// getChanges returns the breaking changes between the base and the given spec, except for the ignored ones
+func (server *lspServer) getChanges(spec *load.SpecInfo) (checker.Changes, *ReturnError) {
+	base, returnErr := server.loadBase()
+	if returnErr != nil {
+		return nil, returnErr
+	}
+
+	diffResult, returnErr := diffSpecs(server.flags, base, spec)
+	if returnErr != nil {
+		return nil, returnErr
+	}
+
+	config, returnErr := getCheckerConfig(server.flags)
+	if returnErr != nil {
+		return nil, returnErr
+	}
+
+	changes := checker.CheckBackwardCompatibilityUntilLevel(config, diffResult.checkReport(), diffResult.operationsSources, checker.WARN)
+
+	asOf, returnErr := getAsOf(server.flags)
+	if returnErr != nil {
+		return nil, returnErr
+	}
+
+	// an ignore file that doesn't exist yet is created by the first change that is ignored, see getIgnoreAction
+	return filterIgnored(
+		changes,
+		getExistingFile(server.flags.getWarnIgnoreFile()),
+		getExistingFile(server.flags.getErrIgnoreFile()),
+		checker.NewLocalizer(server.flags.getLang()),
+		asOf,
+		server.stderr)
+}
+
+// getExistingFile returns the path if it names an existing file, and an empty string otherwise
+func getExistingFile(path string) string {
+	if path == "" {
+		return ""
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return ""
+	}
+	return path
+}
+
+// loadBase returns the base spec.
+// A base from a git revision or a URL doesn't change while the spec is being edited, so it is loaded once and reused.
+func (server *lspServer) loadBase() (*load.SpecInfo, *ReturnError) {
+	if server.base != nil {
+		return server.base, nil
+	}
+
+	source := load.NewSource(server.flags.getBaseSpec())
+	source.Fetch = server.flags.getFetch()
+
+	base, err := load.NewSpecInfo(server.newLoader(source), source, getLoadOptions(server.flags)...)
+	if err != nil {
+		return nil, getErrFailedToLoadSpec("base", source, err)
+	}
+
+	if !source.IsFile() {
+		server.base = base
+	}
+	return base, nil
+}
+
+func (server *lspServer) newLoadProblem(root string, err error) lspProblem {
+	line := 0
+	if match := lspErrorLinePattern.FindStringSubmatch(err.Error()); match != nil {
+		line, _ = strconv.Atoi(match[1])
+	}
+
+	return lspProblem{
+		file:       root,
+		root:       root,
+		diagnostic: server.newDiagnostic(root, line, 0, lspSeverityError, "", "failed to load the spec: "+err.Error()),
+	}
+}
+
+func (server *lspServer) newFindingProblem(root string, finding formatters.Finding) lspProblem {
+	file := root
+	if finding.Source.File != "" {
+		file = finding.Source.File
+	}
+
+	return lspProblem{
+		file:       file,
+		root:       root,
+		diagnostic: server.newDiagnostic(file, finding.Source.Line, finding.Source.Column, getLSPSeverity(finding.Level), finding.Id, finding.Text),
+		finding:    &finding,
+	}
+}
+
+// newChangeProblem reports a change at its location in the spec.
+// A change without a location in the spec, like a removed operation, is reported at its path if the spec still has it, and at the top of the spec otherwise.
+func (server *lspServer) newChangeProblem(root string, spec *load.SpecInfo, change checker.Change, l checker.Localizer) lspProblem {
+	file, line, column := root, 0, 0
+	if source := change.GetRevisionSource(); source != nil && source.Line > 0 {
+		if source.File != "" {
+			file = source.File
+		}
+		line, column = source.Line, source.Column
+	} else if location := getPathLocation(spec, change.GetPath()); location != nil {
+		if location.File != "" {
+			file = location.File
+		}
+		line, column = location.Line, location.Column
+	}
+
+	return lspProblem{
+		file:       file,
+		root:       root,
+		diagnostic: server.newDiagnostic(file, line, column, getLSPSeverity(change.GetLevel()), change.GetId(), change.GetUncolorizedText(l)),
+		change:     change,
+	}
+}
+
+func getPathLocation(spec *load.SpecInfo, path string) *openapi3.Location {
+	if spec.Spec.Paths == nil || path == "" {
+		return nil
+	}
+
+	pathItem := spec.Spec.Paths.Value(path)
+	if pathItem == nil || pathItem.Origin == nil {
+		return nil
+	}
+	return pathItem.Origin.Key
+}
+
+// newDiagnostic returns a diagnostic from the given 1-based line and column to the end of the line, or on the first line if the line is unknown
+func (server *lspServer) newDiagnostic(file string, line, column, severity int, code, message string) lspDiagnostic {
+	return lspDiagnostic{
+		Range:    server.getLineRange(file, line, column),
+		Severity: severity,
+		Code:     code,
+		Source:   "oasdiff",
+		Message:  message,
+	}
+}
+
+func (server *lspServer) getLineRange(file string, line, column int) lspRange {
+	if line < 1 {
+		line, column = 1, 1
+	}
+
+	lines := server.getLines(file)
+	if line > len(lines) {
+		position := lspPosition{Line: line - 1}
+		return lspRange{Start: position, End: position}
+	}
+
+	text := []rune(lines[line-1])
+	column = min(max(column-1, 0), len(text))
+	return lspRange{
+		Start: lspPosition{Line: line - 1, Character: getUTF16Length(text[:column])},
+		End:   lspPosition{Line: line - 1, Character: getUTF16Length(text)},
+	}
+}
+
+// getLines returns the lines of a file, as open in the editor or else as saved
+func (server *lspServer) getLines(file string) []string {
+	if lines, ok := server.lines[file]; ok {
+		return lines
+	}
+
+	text, ok := server.documents[file]
+	if !ok {
+		data, err := os.ReadFile(file)
+		if err == nil {
+			text = string(data)
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if server.lines != nil {
+		server.lines[file] = lines
+	}
+	return lines
+}
+
+// getUTF16Length returns the length of the text in UTF-16 code units, which LSP positions count
+func getUTF16Length(text []rune) int {
+	return len(utf16.Encode(text))
+}
+
+func getLSPSeverity(level checker.Level) int {
+	switch level {
+	case checker.ERR:
+		return lspSeverityError
+	case checker.WARN:
+		return lspSeverityWarning
+	case checker.INFO:
+		return lspSeverityInformation
+	}
+	return lspSeverityHint
+}
@@ -0,0 +1,383 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/stretchr/testify/require"
+)
+
+// lspClient writes the messages of an LSP session, and reads the messages that the server sent in response
+type lspClient struct {
+	t     *testing.T
+	input bytes.Buffer
+	id    int
+}
+
+func (client *lspClient) send(message map[string]any) {
+	message["jsonrpc"] = "2.0"
+	require.NoError(client.t, writeLSPMessage(&client.input, message))
+}
+
+func (client *lspClient) request(method string, params any) int {
+	client.id++
+	client.send(map[string]any{"id": client.id, "method": method, "params": params})
+	return client.id
+}
+
+func (client *lspClient) notify(method string, params any) {
+	client.send(map[string]any{"method": method, "params": params})
+}
+
+func (client *lspClient) open(path string, text string) {
+	client.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": getLSPURI(path), "languageId": "yaml", "version": 1, "text": text},
+	})
+}
+
+// lspServerMessage is a response or a notification of the server
+type lspServerMessage struct {
+	Id     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *mcpError       `json:"error"`
+}
+
+// runLSP runs the lsp command with the given arguments on the client's messages, and returns the messages of the server and its exit code
+func runLSP(t *testing.T, client *lspClient, args ...string) ([]lspServerMessage, int) {
+	t.Helper()
+
+	cmd := getLSPCmd()
+	var stdout, stderr bytes.Buffer
+	cmd.SetArgs(args)
+	cmd.SetIn(&client.input)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	code := 0
+	if err := cmd.Execute(); err != nil {
+		code = max(getReturnValue(cmd), 1)
+	}
+
+	messages := []lspServerMessage{}
+	reader := bufio.NewReader(&stdout)
+	for {
+		body, err := readLSPMessage(reader)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		var message lspServerMessage
+		require.NoError(t, json.Unmarshal(body, &message))
+		messages = append(messages, message)
+	}
+	return messages, code
+}
+
+func getLSPResponse(t *testing.T, messages []lspServerMessage, id int) lspServerMessage {
+	t.Helper()
+	for _, message := range messages {
+		if message.Id != nil && *message.Id == id {
+			return message
+		}
+	}
+	require.Failf(t, "missing response", "no response to request %d", id)
+	return lspServerMessage{}
+}
+
+// getLSPDiagnostics returns the diagnostics last published for each file
+func getLSPDiagnostics(t *testing.T, messages []lspServerMessage) map[string][]lspDiagnostic {
+	t.Helper()
+	result := map[string][]lspDiagnostic{}
+	for _, message := range messages {
+		if message.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+		var params lspPublishDiagnosticsParams
+		require.NoError(t, json.Unmarshal(message.Params, &params))
+		path, ok := getLSPPath(params.URI)
+		require.True(t, ok)
+		result[filepath.Base(path)] = params.Diagnostics
+	}
+	return result
+}
+
+func copyLSPFiles(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, file := range []string{"base.yaml", "openapi.yaml", "schemas.yaml"} {
+		data, err := os.ReadFile(filepath.Join("../data/lsp", file))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), data, 0o600))
+	}
+	return dir
+}
+
+func newLSPClient(t *testing.T) *lspClient {
+	client := &lspClient{t: t}
+	client.request("initialize", map[string]any{"capabilities": map[string]any{}})
+	client.notify("initialized", map[string]any{})
+	return client
+}
+
+func (client *lspClient) shutdown() {
+	client.request("shutdown", nil)
+	client.notify("exit", nil)
+}
+
+func readLSPFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestLSP_Initialize(t *testing.T) {
+	client := newLSPClient(t)
+	client.shutdown()
+
+	messages, code := runLSP(t, client)
+	require.Zero(t, code)
+
+	var result lspInitializeResult
+	require.NoError(t, json.Unmarshal(getLSPResponse(t, messages, 1).Result, &result))
+	require.Equal(t, lspTextDocumentSyncFull, result.Capabilities.TextDocumentSync.Change)
+	require.True(t, result.Capabilities.HoverProvider)
+	require.Equal(t, "oasdiff", result.ServerInfo.Name)
+
+	shutdown := getLSPResponse(t, messages, 2)
+	require.Nil(t, shutdown.Error)
+	require.Equal(t, "null", string(shutdown.Result))
+}
+
+func TestLSP_Diagnostics(t *testing.T) {
+	dir := copyLSPFiles(t)
+	revision := filepath.Join(dir, "openapi.yaml")
+
+	client := newLSPClient(t)
+	client.open(revision, readLSPFile(t, revision))
+	client.shutdown()
+
+	messages, code := runLSP(t, client, "--base", filepath.Join(dir, "base.yaml"))
+	require.Zero(t, code)
+
+	diagnostics := getLSPDiagnostics(t, messages)
+	require.Equal(t, []lspDiagnostic{{
+		Range:    lspRange{Start: lspPosition{Line: 10, Character: 10}, End: lspPosition{Line: 10, Character: 24}},
+		Severity: lspSeverityError,
+		Code:     "request-parameter-became-required",
+		Source:   "oasdiff",
+		Message:  "the `query` request parameter `limit` became required",
+	}}, diagnostics["openapi.yaml"])
+
+	// the referenced file gets the diagnostics of the changes in it
+	require.Len(t, diagnostics["schemas.yaml"], 1)
+	require.Equal(t, "response-property-type-changed", diagnostics["schemas.yaml"][0].Code)
+	require.Equal(t, 4, diagnostics["schemas.yaml"][0].Range.Start.Line)
+}
+
+func TestLSP_UnsavedChanges(t *testing.T) {
+	dir := copyLSPFiles(t)
+	revision := filepath.Join(dir, "openapi.yaml")
+	schemas := filepath.Join(dir, "schemas.yaml")
+
+	client := newLSPClient(t)
+	client.open(revision, readLSPFile(t, revision))
+	// the open documents are read as they are in the editor, rather than as saved
+	client.open(schemas, "Pet:\n  type: object\n  properties:\n    name:\n      type: string\n")
+	client.notify("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": getLSPURI(revision), "version": 2},
+		"contentChanges": []map[string]any{{"text": strings.Replace(readLSPFile(t, revision), "required: true", "required: false", 1)}},
+	})
+	client.shutdown()
+
+	messages, code := runLSP(t, client, "--base", filepath.Join(dir, "base.yaml"))
+	require.Zero(t, code)
+
+	// the diagnostics of the fixed changes are cleared
+	diagnostics := getLSPDiagnostics(t, messages)
+	require.Empty(t, diagnostics["openapi.yaml"])
+	require.Empty(t, diagnostics["schemas.yaml"])
+}
+
+func TestLSP_Validate(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "openapi.yaml")
+
+	client := newLSPClient(t)
+	client.open(spec, "openapi: 3.0.3\ninfo:\n  title: Pets\npaths: {}\n")
+	client.shutdown()
+
+	messages, code := runLSP(t, client)
+	require.Zero(t, code)
+
+	diagnostics := getLSPDiagnostics(t, messages)["openapi.yaml"]
+	require.NotEmpty(t, diagnostics)
+	require.Equal(t, lspSeverityError, diagnostics[0].Severity)
+	require.Contains(t, diagnostics[0].Message, "version")
+}
+
+func TestLSP_LoadError(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "openapi.yaml")
+
+	client := newLSPClient(t)
+	client.open(spec, "openapi: 3.0.3\ninfo:\n  title: [\n")
+	client.shutdown()
+
+	messages, code := runLSP(t, client)
+	require.Zero(t, code)
+
+	diagnostics := getLSPDiagnostics(t, messages)["openapi.yaml"]
+	require.Len(t, diagnostics, 1)
+	require.Contains(t, diagnostics[0].Message, "failed to load the spec")
+}
+
+func TestLSP_Hover(t *testing.T) {
+	dir := copyLSPFiles(t)
+	revision := filepath.Join(dir, "openapi.yaml")
+
+	client := newLSPClient(t)
+	client.open(revision, readLSPFile(t, revision))
+	hover := client.request("textDocument/hover", map[string]any{
+		"textDocument": map[string]any{"uri": getLSPURI(revision)},
+		"position":     map[string]any{"line": 10, "character": 14},
+	})
+	nothing := client.request("textDocument/hover", map[string]any{
+		"textDocument": map[string]any{"uri": getLSPURI(revision)},
+		"position":     map[string]any{"line": 0, "character": 0},
+	})
+	client.shutdown()
+
+	messages, _ := runLSP(t, client, "--base", filepath.Join(dir, "base.yaml"))
+
+	var result lspHover
+	require.NoError(t, json.Unmarshal(getLSPResponse(t, messages, hover).Result, &result))
+	require.Equal(t, "markdown", result.Contents.Kind)
+	require.Contains(t, result.Contents.Value, "**request-parameter-became-required**: the `query` request parameter `limit` became required")
+	require.Contains(t, result.Contents.Value, "request parameter became required")
+
+	require.Equal(t, "null", string(getLSPResponse(t, messages, nothing).Result))
+}
+
+func TestLSP_CodeActions(t *testing.T) {
+	dir := copyLSPFiles(t)
+	revision := filepath.Join(dir, "openapi.yaml")
+	ignoreFile := filepath.Join(dir, "ignore.yaml")
+
+	client := newLSPClient(t)
+	client.open(revision, readLSPFile(t, revision))
+	actions := client.request("textDocument/codeAction", map[string]any{
+		"textDocument": map[string]any{"uri": getLSPURI(revision)},
+		"range":        map[string]any{"start": map[string]any{"line": 10, "character": 0}, "end": map[string]any{"line": 10, "character": 0}},
+		"context":      map[string]any{"diagnostics": []any{}},
+	})
+	client.shutdown()
+
+	messages, _ := runLSP(t, client, "--base", filepath.Join(dir, "base.yaml"), "--err-ignore", ignoreFile, "--deprecation-days-stable", "0")
+
+	var result []lspCodeAction
+	require.NoError(t, json.Unmarshal(getLSPResponse(t, messages, actions).Result, &result))
+	require.Len(t, result, 2)
+
+	require.Equal(t, "Mark GET /pets as deprecated", result[0].Title)
+	require.Equal(t, map[string][]lspTextEdit{
+		getLSPURI(revision): {{Range: lspRange{Start: lspPosition{Line: 7}, End: lspPosition{Line: 7}}, NewText: "      deprecated: true\n"}},
+	}, result[0].Edit.Changes)
+
+	// a missing ignore file is created in the structured format
+	require.Equal(t, "Add request-parameter-became-required to the err ignore file", result[1].Title)
+	edit, err := json.Marshal(result[1].Edit.DocumentChanges)
+	require.NoError(t, err)
+	require.Contains(t, string(edit), `"kind":"create"`)
+	require.Contains(t, string(edit), `"newText":"ignore:\n  - id: request-parameter-became-required\n    operation: GET\n    path: /pets\n"`)
+}
+
+func TestLSP_CodeActions_Sunset(t *testing.T) {
+	dir := copyLSPFiles(t)
+	revision := filepath.Join(dir, "openapi.yaml")
+
+	client := newLSPClient(t)
+	client.open(revision, readLSPFile(t, revision))
+	actions := client.request("textDocument/codeAction", map[string]any{
+		"textDocument": map[string]any{"uri": getLSPURI(revision)},
+		"range":        map[string]any{"start": map[string]any{"line": 10, "character": 0}, "end": map[string]any{"line": 10, "character": 0}},
+	})
+	client.shutdown()
+
+	messages, _ := runLSP(t, client, "--base", filepath.Join(dir, "base.yaml"), "--deprecation-days-stable", "180")
+
+	var result []lspCodeAction
+	require.NoError(t, json.Unmarshal(getLSPResponse(t, messages, actions).Result, &result))
+	// without an ignore file, only the deprecation is offered
+	require.Len(t, result, 1)
+	require.Contains(t, result[0].Edit.Changes[getLSPURI(revision)][0].NewText, "      deprecated: true\n      x-sunset: ")
+}
+
+func TestGetIgnoreEntry(t *testing.T) {
+	change := checker.ApiChange{
+		Id:        "request-parameter-became-required",
+		Args:      []any{"query", "limit"},
+		Level:     checker.ERR,
+		Operation: "GET",
+		Path:      "/pets",
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"empty", "", "ignore:\n  - id: request-parameter-became-required\n    operation: GET\n    path: /pets\n"},
+		{"structured", "ignore:\n    - id: api-removed-without-deprecation", "\n    - id: request-parameter-became-required\n      operation: GET\n      path: /pets\n"},
+		{"free text", "GET /pets api removed without deprecation\n", "GET /pets the `query` request parameter `limit` became required\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entry, err := getIgnoreEntry(test.text, change, checker.NewDefaultLocalizer())
+			require.NoError(t, err)
+			require.Equal(t, test.expected, entry)
+		})
+	}
+}
+
+func TestLSP_Protocol(t *testing.T) {
+	client := &lspClient{t: t}
+	early := client.request("textDocument/hover", map[string]any{})
+	client.request("initialize", map[string]any{})
+	unknown := client.request("workspace/symbol", map[string]any{})
+	client.notify("$/cancelRequest", map[string]any{"id": 1})
+	client.notify("exit", nil)
+
+	messages, code := runLSP(t, client)
+	// exiting without a shutdown request is an error
+	require.NotZero(t, code)
+
+	require.Equal(t, lspServerNotInitialized, getLSPResponse(t, messages, early).Error.Code)
+	require.Equal(t, mcpMethodNotFound, getLSPResponse(t, messages, unknown).Error.Code)
+}
+
+func TestLSP_StdinBase(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 101, Run([]string{"oasdiff", "lsp", "--base", "-"}, io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "the base can't be read from standard input")
+}
+
+func TestLSPPath(t *testing.T) {
+	path, err := filepath.Abs("openapi.yaml")
+	require.NoError(t, err)
+
+	result, ok := getLSPPath(getLSPURI(path))
+	require.True(t, ok)
+	require.Equal(t, path, result)
+
+	_, ok = getLSPPath("untitled:Untitled-1")
+	require.False(t, ok)
+}
@@ -0,0 +1,369 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/oasdiff/oasdiff/build"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// mcpProtocolVersion is the MCP revision this server implements. A client that
+// asks for another revision is answered with this one, as the spec requires;
+// the client then decides whether it can proceed.
+const mcpProtocolVersion = "2025-06-18"
+
+func getMCPCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:   "mcp",
+		Short: "Run a local Model Context Protocol server over stdio",
+		Long: `Run a Model Context Protocol (MCP) server that speaks JSON-RPC over standard
+input and output, so an AI assistant can run oasdiff on local specs.
+
+The server exposes the breaking, changelog, diff, summary, validate and flatten
+commands as tools. Specs are read by the same loader as the CLI: a path to a
+file, a URL, or a git ref (e.g. main:openapi.yaml), so multi-file specs and
+relative $refs work. Tool options mirror the command-line flags of each command
+and the .oasdiff.* config file in the working directory is honored.
+
+Register it with an MCP client, for example:
+
+    claude mcp add oasdiff -- oasdiff mcp
+`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// the server owns stdout from here on; cobra must not print usage into the protocol stream
+			cmd.Root().SilenceUsage = true
+			return serveMCP(cmd.InOrStdin(), cmd.OutOrStdout(), getMCPTools(), explicitConfigPath(cmd))
+		},
+	}
+
+	return &cmd
+}
+
+// mcpTool binds an MCP tool to an oasdiff command: the command contributes the
+// flags (and so the tool's options and their defaults) and the runner is the
+// same one the command line uses.
+type mcpTool struct {
+	name        string
+	description string
+	command     func() *cobra.Command
+	runner      runner
+	specArgs    []string
+}
+
+func getMCPTools() []mcpTool {
+	twoSpecs := []string{"base", "revision"}
+	oneSpec := []string{"spec"}
+
+	return []mcpTool{
+		{name: "breaking", description: "Detect breaking changes between two OpenAPI specs", command: getBreakingChangesCmd, runner: runBreakingChanges, specArgs: twoSpecs},
+		{name: "changelog", description: "Generate a changelog of the changes between two OpenAPI specs", command: getChangelogCmd, runner: runChangelog, specArgs: twoSpecs},
+		{name: "diff", description: "Generate a full diff report between two OpenAPI specs", command: getDiffCmd, runner: runDiff, specArgs: twoSpecs},
+		{name: "summary", description: "Summarize the differences between two OpenAPI specs", command: getSummaryCmd, runner: runSummary, specArgs: twoSpecs},
+		{name: "validate", description: "Validate an OpenAPI spec and report per-RFC violations", command: getValidateCmd, runner: runValidate, specArgs: oneSpec},
+		{name: "flatten", description: "Display an OpenAPI spec with all allOf schemas merged", command: getFlattenCmd, runner: runFlatten, specArgs: oneSpec},
+	}
+}
+
+// mcpExcludedFlags are command-line flags that a tool call may not set: --open
+// launches a browser, --color targets a terminal and --watch never returns; the
+// flags that read or write files (config, ignore files, severity levels, custom
+// rules, template, baseline, consumer usage, traffic and protobuf descriptor
+// sets) and the git-base flags are excluded so that the only files a tool call
+// reads are the specs it names.
+var mcpExcludedFlags = map[string]bool{
+	"open":                 true,
+	"review-token":         true,
+	"review-meta":          true,
+	"color":                true,
+	"watch":                true,
+	"config":               true,
+	"err-ignore":           true,
+	"warn-ignore":          true,
+	"severity-levels":      true,
+	"custom-rules":         true,
+	"template":             true,
+	"baseline":             true,
+	"baseline-write":       true,
+	"consumer-usage":       true,
+	"traffic":              true,
+	"base-proto":           true,
+	"revision-proto":       true,
+	"base-from-latest-tag": true,
+	"base-merge-base":      true,
+}
+
+type mcpRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type mcpResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *mcpError       `json:"error,omitempty"`
+}
+
+type mcpError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// JSON-RPC 2.0 error codes
+const (
+	mcpParseError     = -32700
+	mcpInvalidRequest = -32600
+	mcpMethodNotFound = -32601
+	mcpInvalidParams  = -32602
+	mcpInternalError  = -32603
+)
+
+type mcpToolCallParams struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type mcpToolResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError,omitempty"`
+}
+
+// serveMCP reads newline-delimited JSON-RPC messages from in and writes the
+// responses to out until in is exhausted, as defined by the MCP stdio transport.
+// Requests are handled one at a time, in order. Tool calls read the config file
+// at configPath, or the default one when it is empty.
+func serveMCP(in io.Reader, out io.Writer, tools []mcpTool, configPath string) error {
+	scanner := bufio.NewScanner(in)
+	// a tool result can carry a large spec, and so can a request; lift bufio's 64KB line limit
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	encoder := json.NewEncoder(out)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		response := handleMCPMessage(line, tools, configPath)
+		if response == nil {
+			// notification: no response
+			continue
+		}
+
+		if err := encoder.Encode(response); err != nil {
+			return fmt.Errorf("failed to write MCP response: %w", err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func handleMCPMessage(message []byte, tools []mcpTool, configPath string) *mcpResponse {
+	var request mcpRequest
+	if err := json.Unmarshal(message, &request); err != nil {
+		return newMCPError(json.RawMessage("null"), mcpParseError, fmt.Sprintf("parse error: %v", err))
+	}
+
+	if request.JSONRPC != "2.0" || request.Method == "" {
+		return newMCPError(request.Id, mcpInvalidRequest, "invalid request")
+	}
+
+	// notifications carry no id and get no response, not even an error
+	if len(request.Id) == 0 {
+		return nil
+	}
+
+	switch request.Method {
+	case "initialize":
+		return newMCPResult(request.Id, map[string]any{
+			"protocolVersion": mcpProtocolVersion,
+			"capabilities": map[string]any{
+				"tools": map[string]any{},
+			},
+			"serverInfo": map[string]any{
+				"name":    "oasdiff",
+				"version": build.Version,
+			},
+		})
+	case "ping":
+		return newMCPResult(request.Id, map[string]any{})
+	case "tools/list":
+		return newMCPResult(request.Id, map[string]any{
+			"tools": listMCPTools(tools),
+		})
+	case "tools/call":
+		var params mcpToolCallParams
+		if err := json.Unmarshal(request.Params, &params); err != nil {
+			return newMCPError(request.Id, mcpInvalidParams, fmt.Sprintf("invalid params: %v", err))
+		}
+		tool, ok := findMCPTool(tools, params.Name)
+		if !ok {
+			return newMCPError(request.Id, mcpInvalidParams, fmt.Sprintf("unknown tool %q", params.Name))
+		}
+		return newMCPResult(request.Id, callMCPTool(tool, configPath, params.Arguments))
+	default:
+		return newMCPError(request.Id, mcpMethodNotFound, fmt.Sprintf("method not found: %s", request.Method))
+	}
+}
+
+func newMCPResult(id json.RawMessage, result any) *mcpResponse {
+	return &mcpResponse{JSONRPC: "2.0", Id: id, Result: result}
+}
+
+func newMCPError(id json.RawMessage, code int, message string) *mcpResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &mcpResponse{JSONRPC: "2.0", Id: id, Error: &mcpError{Code: code, Message: message}}
+}
+
+func findMCPTool(tools []mcpTool, name string) (mcpTool, bool) {
+	for _, tool := range tools {
+		if tool.name == name {
+			return tool, true
+		}
+	}
+	return mcpTool{}, false
+}
+
+func listMCPTools(tools []mcpTool) []map[string]any {
+	result := make([]map[string]any, 0, len(tools))
+	for _, tool := range tools {
+		result = append(result, map[string]any{
+			"name":        tool.name,
+			"description": tool.description,
+			"inputSchema": getMCPInputSchema(tool),
+		})
+	}
+	return result
+}
+
+// getMCPInputSchema derives the JSON Schema of a tool's arguments from the
+// command's flags, so every option the command line accepts is also accepted
+// by the tool, with the same name, description and allowed values.
+func getMCPInputSchema(tool mcpTool) map[string]any {
+	properties := map[string]any{}
+
+	for _, arg := range tool.specArgs {
+		properties[arg] = map[string]any{
+			"type":        "string",
+			"description": "path to a local file, a URL, or a git ref (e.g. main:openapi.yaml)",
+		}
+	}
+
+	tool.command().PersistentFlags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden || mcpExcludedFlags[flag.Name] {
+			return
+		}
+		properties[flag.Name] = getMCPFlagSchema(flag)
+	})
+
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   tool.specArgs,
+	}
+}
+
+func getMCPFlagSchema(flag *pflag.Flag) map[string]any {
+	schema := map[string]any{
+		"description": flag.Usage,
+	}
+
+	switch value := flag.Value.(type) {
+	case *enumValue:
+		schema["type"] = "string"
+		schema["enum"] = value.allowedValues
+		return schema
+	case *enumSliceValue:
+		schema["type"] = "array"
+		schema["items"] = map[string]any{"type": "string", "enum": value.allowedValues}
+		return schema
+	}
+
+	switch flag.Value.Type() {
+	case "bool":
+		schema["type"] = "boolean"
+	case "int", "uint":
+		schema["type"] = "integer"
+	case "stringSlice", "strings":
+		schema["type"] = "array"
+		schema["items"] = map[string]any{"type": "string"}
+	default:
+		schema["type"] = "string"
+	}
+
+	return schema
+}
+
+// callMCPTool runs one tool call. Failures are reported in the tool result
+// (isError) rather than as JSON-RPC errors, so the model can read them and
+// correct its call.
+func callMCPTool(tool mcpTool, configPath string, arguments map[string]any) mcpToolResult {
+	output, err := runMCPTool(tool, configPath, arguments)
+	if err != nil {
+		return mcpToolResult{
+			Content: []mcpContent{{Type: "text", Text: err.Error()}},
+			IsError: true,
+		}
+	}
+
+	return mcpToolResult{
+		Content: []mcpContent{{Type: "text", Text: output}},
+	}
+}
+
+func runMCPTool(tool mcpTool, configPath string, arguments map[string]any) (string, error) {
+
+	sources := make([]*load.Source, 0, len(tool.specArgs))
+	for _, arg := range tool.specArgs {
+		value, ok := arguments[arg].(string)
+		if !ok || value == "" {
+			return "", fmt.Errorf("missing required argument %q", arg)
+		}
+		if value == "-" {
+			return "", fmt.Errorf("argument %q: standard input is reserved for the MCP protocol", arg)
+		}
+		sources = append(sources, load.NewSource(value))
+	}
+
+	flags, err := newFlagsFromArguments(tool.command(), configPath, arguments, tool.specArgs, mcpExcludedFlags)
+	if err != nil {
+		return "", err
+	}
+
+	// text output goes to a model, not a terminal
+	flags.getViper().Set("color", "never")
+
+	for _, source := range sources {
+		source.Fetch = flags.getFetch()
+	}
+	flags.setBase(sources[0])
+	if len(sources) > 1 {
+		flags.setRevision(sources[1])
+	}
+
+	var stdout bytes.Buffer
+	if _, err := tool.runner(flags, &stdout); err != nil {
+		return "", err
+	}
+
+	return stdout.String(), nil
+}
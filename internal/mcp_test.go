@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runMCPSession(t *testing.T, messages ...string) []map[string]any {
+	t.Helper()
+
+	var out bytes.Buffer
+	require.NoError(t, serveMCP(strings.NewReader(strings.Join(messages, "\n")), &out, getMCPTools(), ""))
+
+	var responses []map[string]any
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var response map[string]any
+		require.NoError(t, decoder.Decode(&response))
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+func getMCPToolText(t *testing.T, response map[string]any) (string, bool) {
+	t.Helper()
+
+	result, ok := response["result"].(map[string]any)
+	require.True(t, ok, response)
+	content := result["content"].([]any)
+	require.Len(t, content, 1)
+	isError, _ := result["isError"].(bool)
+	return content[0].(map[string]any)["text"].(string), isError
+}
+
+func Test_MCPInitialize(t *testing.T) {
+	responses := runMCPSession(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"ping"}`,
+	)
+
+	// the notification gets no response
+	require.Len(t, responses, 2)
+	result := responses[0]["result"].(map[string]any)
+	require.Equal(t, mcpProtocolVersion, result["protocolVersion"])
+	require.Equal(t, "oasdiff", result["serverInfo"].(map[string]any)["name"])
+	require.Equal(t, float64(2), responses[1]["id"])
+}
+
+func Test_MCPToolsList(t *testing.T) {
+	responses := runMCPSession(t, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	require.Len(t, responses, 1)
+
+	tools := responses[0]["result"].(map[string]any)["tools"].([]any)
+	names := []string{}
+	for _, tool := range tools {
+		names = append(names, tool.(map[string]any)["name"].(string))
+	}
+	require.Equal(t, []string{"breaking", "changelog", "diff", "summary", "validate", "flatten"}, names)
+
+	breaking := tools[0].(map[string]any)["inputSchema"].(map[string]any)
+	properties := breaking["properties"].(map[string]any)
+	require.Equal(t, []any{"base", "revision"}, breaking["required"])
+	require.Contains(t, properties, "fail-on")
+	require.Contains(t, properties["format"].(map[string]any)["enum"], "json")
+	require.NotContains(t, properties, "open")
+	require.NotContains(t, properties, "color")
+	require.NotContains(t, properties, "flatten")
+	for _, flag := range []string{"watch", "err-ignore", "warn-ignore", "severity-levels", "template", "baseline", "baseline-write", "custom-rules", "consumer-usage", "traffic", "base-proto", "revision-proto", "base-from-latest-tag", "base-merge-base"} {
+		require.NotContains(t, properties, flag)
+	}
+}
+
+func Test_MCPBreaking(t *testing.T) {
+	responses := runMCPSession(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"breaking","arguments":{"base":"../data/openapi-test1.yaml","revision":"../data/openapi-test3.yaml","format":"json"}}}`,
+	)
+	require.Len(t, responses, 1)
+
+	text, isError := getMCPToolText(t, responses[0])
+	require.False(t, isError, text)
+
+	var changes []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(text), &changes))
+	require.NotEmpty(t, changes)
+}
+
+func Test_MCPValidate(t *testing.T) {
+	responses := runMCPSession(t,
+		`{"jsonrpc":"2.0","id":"a","method":"tools/call","params":{"name":"validate","arguments":{"spec":"../data/openapi-test1.yaml","format":"yaml"}}}`,
+	)
+	require.Len(t, responses, 1)
+	require.Equal(t, "a", responses[0]["id"])
+
+	_, isError := getMCPToolText(t, responses[0])
+	require.False(t, isError)
+}
+
+// a tool call may not make the server read files other than the specs it names
+func Test_MCPFileFlags(t *testing.T) {
+	responses := runMCPSession(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"breaking","arguments":{"base":"../data/openapi-test1.yaml","revision":"../data/openapi-test3.yaml","err-ignore":"../data/ignore-err-example.txt"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"breaking","arguments":{"base":"../data/openapi-test1.yaml","revision":"../data/openapi-test3.yaml","severity-levels":"../data/severity-levels.txt"}}}`,
+	)
+	require.Len(t, responses, 2)
+
+	text, isError := getMCPToolText(t, responses[0])
+	require.True(t, isError)
+	require.Equal(t, `unknown argument "err-ignore"`, text)
+
+	text, isError = getMCPToolText(t, responses[1])
+	require.True(t, isError)
+	require.Equal(t, `unknown argument "severity-levels"`, text)
+}
+
+func Test_MCPToolErrors(t *testing.T) {
+	responses := runMCPSession(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"changelog","arguments":{"base":"../data/openapi-test1.yaml"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"changelog","arguments":{"base":"../data/openapi-test1.yaml","revision":"../data/openapi-test3.yaml","open":true}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"changelog","arguments":{"base":"../data/openapi-test1.yaml","revision":"../data/openapi-test3.yaml","format":"invalid"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"diff","arguments":{"base":"../data/no-such-file.yaml","revision":"../data/openapi-test3.yaml"}}}`,
+	)
+	require.Len(t, responses, 4)
+
+	text, isError := getMCPToolText(t, responses[0])
+	require.True(t, isError)
+	require.Equal(t, `missing required argument "revision"`, text)
+
+	text, isError = getMCPToolText(t, responses[1])
+	require.True(t, isError)
+	require.Equal(t, `unknown argument "open"`, text)
+
+	text, isError = getMCPToolText(t, responses[2])
+	require.True(t, isError)
+	require.Contains(t, text, `invalid argument "format"`)
+
+	text, isError = getMCPToolText(t, responses[3])
+	require.True(t, isError)
+	require.Contains(t, text, "failed to load base spec")
+}
+
+func Test_MCPProtocolErrors(t *testing.T) {
+	responses := runMCPSession(t,
+		`not json`,
+		`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"no-such-tool"}}`,
+	)
+	require.Len(t, responses, 3)
+
+	require.Equal(t, float64(mcpParseError), responses[0]["error"].(map[string]any)["code"])
+	require.Equal(t, float64(mcpMethodNotFound), responses[1]["error"].(map[string]any)["code"])
+	require.Equal(t, float64(mcpInvalidParams), responses[2]["error"].(map[string]any)["code"])
+}
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"io"
 	"strconv"
 
@@ -9,6 +10,11 @@ import (
 )
 
 func Run(args []string, stdout io.Writer, stderr io.Writer) int {
+	return runWithContext(context.Background(), args, stdout, stderr)
+}
+
+// runWithContext is Run with a context that stops long-running commands, like those in watch mode, when it is done
+func runWithContext(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
 
 	rootCmd := &cobra.Command{
 		Use:   "oasdiff",
@@ -41,10 +47,17 @@ func Run(args []string, stdout io.Writer, stderr io.Writer) int {
 		getChecksCmd(),
 		getValidateCmd(),
 		getSchemaCmd(),
+		getSchemaDiffCmd(),
+		getVerifyCmd(),
 		getGitDiffDriverCmd(),
+		getMCPCmd(),
+		getServeCmd(),
+		getLSPCmd(),
+		getHistoryCmd(),
+		getDeprecationsCmd(),
 	)
 
-	return run(rootCmd)
+	return run(ctx, rootCmd)
 }
 
 func setReturnValue(cmd *cobra.Command, code int) {
@@ -74,9 +87,9 @@ func getReturnValue(cmd *cobra.Command) int {
 	return code
 }
 
-func run(cmd *cobra.Command) int {
+func run(ctx context.Context, cmd *cobra.Command) int {
 
-	if err := cmd.Execute(); err != nil {
+	if err := cmd.ExecuteContext(ctx); err != nil {
 		if ret := getReturnValue(cmd); ret != 0 {
 			return ret
 		}
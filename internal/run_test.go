@@ -269,6 +269,390 @@ func Test_BreakingChangesIgnoreErrsApiSchemaOptional(t *testing.T) {
 	require.Len(t, bc, 4)
 }
 
+func Test_BreakingChangesIgnoreStructured(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --err-ignore ../data/ignore-err-example.yaml --warn-ignore ../data/ignore-warn-example.json --format json"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 3)
+}
+
+func Test_BreakingChangesIgnoreStructuredReport(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --err-ignore ../data/ignore-err-example.yaml"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "warning: ../data/ignore-err-example.yaml: entry expired on 2020-01-01")
+	require.Contains(t, stderr.String(), "warning: ../data/ignore-err-example.yaml: stale entry matches no change")
+}
+
+func Test_BreakingChangesBaseline(t *testing.T) {
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --fail-on ERR --baseline-write "+baseline), io.Discard, io.Discard))
+
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --fail-on ERR --format json --baseline "+baseline), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Empty(t, bc)
+}
+
+func Test_BreakingChangesBaselineStaleEntries(t *testing.T) {
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --fail-on ERR --baseline-write "+baseline), io.Discard, io.Discard))
+
+	var stderr bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test1.yaml --fail-on ERR --baseline "+baseline), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "baseline entry no longer occurs and can be removed")
+}
+
+func Test_BreakingChangesInvalidBaseline(t *testing.T) {
+	require.Equal(t, 125, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --baseline no-file"), io.Discard, io.Discard))
+}
+
+func Test_BreakingChangesConsumerUsage(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/consumer-usage/base.yaml ../data/consumer-usage/revision.yaml --format json --consumer-usage ../data/consumer-usage/mobile.yaml --consumer-usage ../data/consumer-usage/partner.har"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 3)
+
+	consumers := map[string][]string{}
+	for _, change := range bc {
+		consumers[change.Text] = change.Consumers
+	}
+	require.Equal(t, map[string][]string{
+		"removed the optional property `items/email` from the response with the `200` status": {"mobile-app"},
+		"removed the optional property `items/phone` from the response with the `200` status": {"partner"},
+		"removed the optional property `nickname` from the response with the `200` status":    {"mobile-app"},
+	}, consumers)
+}
+
+func Test_BreakingChangesConsumerUsageFailOn(t *testing.T) {
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/consumer-usage/base.yaml ../data/consumer-usage/revision.yaml --fail-on ERR --consumer-usage ../data/consumer-usage/mobile.yaml"), io.Discard, io.Discard))
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/consumer-usage/base.yaml ../data/consumer-usage/revision.yaml --fail-on ERR --consumer-usage ../data/consumer-usage/web.yaml"), io.Discard, io.Discard))
+}
+
+func Test_ChangelogConsumerUsageText(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff changelog ../data/consumer-usage/base.yaml ../data/consumer-usage/revision.yaml --consumer-usage ../data/consumer-usage/cli.txt"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), "api removed without deprecation\n\t\taffects cli\n")
+}
+
+func Test_BreakingChangesInvalidConsumerUsage(t *testing.T) {
+	require.Equal(t, 112, internal.Run(cmdToArgs("oasdiff breaking ../data/consumer-usage/base.yaml ../data/consumer-usage/revision.yaml --consumer-usage no-file"), io.Discard, io.Discard))
+}
+
+func Test_BreakingChangesTraffic(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/consumer-usage/base.yaml ../data/consumer-usage/revision.yaml --format json --traffic ../data/traffic/traffic.csv --traffic-demote-below 10 --traffic-escalate-at 1000"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 4)
+
+	require.Equal(t, "GET", bc[0].Operation)
+	require.Equal(t, checker.ERR, bc[0].Level)
+	require.Equal(t, int64(1200), *bc[0].UsageCount)
+
+	require.Equal(t, "DELETE", bc[3].Operation)
+	require.Equal(t, checker.WARN, bc[3].Level)
+	require.Equal(t, int64(0), *bc[3].UsageCount)
+}
+
+func Test_BreakingChangesTrafficFailOn(t *testing.T) {
+	// under /users/, the only error is removing DELETE /users/{userId}, which is demoted since it isn't called
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/consumer-usage/base.yaml ../data/consumer-usage/revision.yaml --fail-on ERR --match-path ^/users/"), io.Discard, io.Discard))
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/consumer-usage/base.yaml ../data/consumer-usage/revision.yaml --fail-on ERR --match-path ^/users/ --traffic ../data/traffic/traffic.csv --traffic-demote-below 0"), io.Discard, io.Discard))
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/consumer-usage/base.yaml ../data/consumer-usage/revision.yaml --fail-on ERR --match-path ^/users/ --traffic ../data/traffic/traffic.csv"), io.Discard, io.Discard))
+}
+
+func Test_ChangelogTrafficMarkdown(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff changelog ../data/consumer-usage/base.yaml ../data/consumer-usage/revision.yaml --format markdown --traffic ../data/traffic/traffic.json"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), "api removed without deprecation _(called 0 times)_")
+}
+
+func Test_BreakingChangesInvalidTrafficThresholds(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff breaking ../data/consumer-usage/base.yaml ../data/consumer-usage/revision.yaml --traffic ../data/traffic/traffic.csv --traffic-demote-below 10 --traffic-escalate-at 5"), io.Discard, io.Discard))
+}
+
+func Test_BreakingChangesInvalidTraffic(t *testing.T) {
+	require.Equal(t, 113, internal.Run(cmdToArgs("oasdiff breaking ../data/consumer-usage/base.yaml ../data/consumer-usage/revision.yaml --traffic no-file"), io.Discard, io.Discard))
+}
+
+func Test_BreakingChangesSwagger2(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/swagger2/base.yaml ../data/swagger2/revision.yaml --format json"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 6)
+
+	// the Swagger 2.0 base is located in the original file
+	require.Equal(t, "request-parameter-max-decreased", bc[1].Id)
+	require.Equal(t, "../data/swagger2/base.yaml", bc[1].BaseSource.File)
+	require.Equal(t, 21, bc[1].BaseSource.Line)
+
+	require.Equal(t, "api-removed-without-deprecation", bc[2].Id)
+	require.Equal(t, "DELETE", bc[2].Operation)
+	require.Equal(t, 59, bc[2].BaseSource.Line)
+}
+
+func Test_BreakingChangesAsyncAPI(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/asyncapi/base-v3.yaml ../data/asyncapi/revision-v3.yaml --format json"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 4)
+
+	require.Equal(t, "asyncapi-channel-removed", bc[0].Id)
+	require.Equal(t, "SEND", bc[0].Operation)
+	require.Equal(t, "user/deleted", bc[0].Path)
+	require.NotEmpty(t, bc[0].Fingerprint)
+}
+
+func Test_DiffAsyncAPIWithOpenAPI(t *testing.T) {
+	require.Equal(t, 104, internal.Run(cmdToArgs("oasdiff diff ../data/asyncapi/base-v2.yaml ../data/simple.yaml"), io.Discard, io.Discard))
+}
+
+func Test_BreakingChangesProto(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/simple.yaml ../data/simple.yaml --base-proto ../data/proto/base.pb --revision-proto ../data/proto/revision.pb --format json"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 31)
+
+	require.Equal(t, "proto-field-became-required", bc[0].Id)
+	require.Equal(t, "components", bc[0].Section)
+
+	require.Equal(t, "proto-rpc-http-binding-changed", bc[10].Id)
+	require.Equal(t, "GET", bc[10].Operation)
+	require.Equal(t, "/v1/accounts/{id}", bc[10].Path)
+	require.Equal(t, "acme.users.v1.Users.GetUser", bc[10].OperationId)
+}
+
+func Test_BreakingChangesProtoFailOn(t *testing.T) {
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/simple.yaml ../data/simple.yaml --base-proto ../data/proto/base.pb --revision-proto ../data/proto/revision.pb --fail-on ERR"), io.Discard, io.Discard))
+}
+
+func Test_BreakingChangesProtoMissingRevision(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff breaking ../data/simple.yaml ../data/simple.yaml --base-proto ../data/proto/base.pb"), io.Discard, io.Discard))
+}
+
+func Test_BreakingChangesProtoInvalid(t *testing.T) {
+	require.Equal(t, 115, internal.Run(cmdToArgs("oasdiff breaking ../data/simple.yaml ../data/simple.yaml --base-proto ../data/proto/base.proto --revision-proto ../data/proto/revision.pb"), io.Discard, io.Discard))
+}
+
+func Test_SchemaDiff(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff schema-diff ../data/json-schema/base.json ../data/json-schema/revision.json --direction request --format json"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 5)
+	require.Equal(t, "request-property-became-required", bc[0].Id)
+	require.Equal(t, "#/properties/address/properties/zip", bc[0].Path)
+	require.Equal(t, "schema", bc[0].Section)
+}
+
+func Test_SchemaDiffFailOn(t *testing.T) {
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff schema-diff ../data/json-schema/base.json ../data/json-schema/revision.json --direction request --fail-on ERR"), io.Discard, io.Discard))
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff schema-diff ../data/json-schema/base.json ../data/json-schema/revision.json --direction response --fail-on ERR"), io.Discard, io.Discard))
+}
+
+func Test_SchemaDiffMissingDirection(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff schema-diff ../data/json-schema/base.json ../data/json-schema/revision.json"), io.Discard, io.Discard))
+}
+
+func Test_SchemaDiffInvalid(t *testing.T) {
+	require.Equal(t, 102, internal.Run(cmdToArgs("oasdiff schema-diff ../data/json-schema/base.json ../data/json-schema/missing.json --direction request"), io.Discard, io.Discard))
+}
+
+func Test_Verify(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff verify ../data/verify/base.yaml ../data/verify/revision.yaml --format json"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 9)
+	require.Equal(t, "request-parameter-max-decreased", bc[0].Id)
+	require.True(t, bc[0].Verification.Confirmed)
+	require.Equal(t, "100", bc[0].Verification.Counterexample)
+	require.Equal(t, "request-property-max-length-decreased", bc[5].Id)
+	require.False(t, bc[5].Verification.Confirmed)
+}
+
+func Test_VerifyText(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff verify ../data/verify/base.yaml ../data/verify/revision.yaml"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), `counterexample in the request body application/json: {"item":"a"}`)
+	require.Contains(t, stdout.String(), "possibly a false positive: no counterexample was found in the request body application/json")
+}
+
+func Test_VerifyFailOn(t *testing.T) {
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff verify ../data/verify/base.yaml ../data/verify/revision.yaml --fail-on ERR"), io.Discard, io.Discard))
+}
+
+func Test_VerifyComposed(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff verify ../data/verify/base.yaml ../data/verify/revision.yaml --composed"), io.Discard, io.Discard))
+}
+
+func Test_UpgradeCmdSwagger2(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff upgrade ../data/swagger2/base.yaml"), &stdout, io.Discard))
+	out := stdout.String()
+	require.Contains(t, out, "openapi: 3.2.0")
+	require.Contains(t, out, "url: https://api.example.com/v1")
+	require.Contains(t, out, "$ref: '#/components/schemas/Pet'")
+	require.NotContains(t, out, "swagger:")
+}
+
+func Test_History(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff history ../data/history/openapi-1.0.0.yaml ../data/history/openapi-1.1.0.yaml ../data/history/openapi-1.2.0.yaml ../data/history/openapi-1.3.0.yaml --format json"), &stdout, io.Discard))
+	releases := formatters.HistoryReleases{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &releases))
+	require.Len(t, releases, 3)
+
+	require.Equal(t, "1.1.0", releases[0].Version)
+	require.Len(t, releases[0].Changes, 2)
+	require.Equal(t, "endpoint-added", releases[0].Changes[0].Id)
+	require.Equal(t, "1.2.0", releases[0].Changes[0].RevertedIn)
+
+	require.Equal(t, "1.2.0", releases[1].Version)
+	require.Equal(t, "api-path-removed-without-deprecation", releases[1].Changes[0].Id)
+	require.Equal(t, "1.1.0", releases[1].Changes[0].Reverts)
+
+	require.Equal(t, "1.3.0", releases[2].Version)
+	require.Len(t, releases[2].Changes, 1)
+	require.Equal(t, "1.1.0", releases[2].Changes[0].Introduced)
+	require.Equal(t, "1.2.0", releases[2].Changes[0].Reverts)
+}
+
+func Test_HistoryMarkdown(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff history ../data/history/openapi-1.0.0.yaml ../data/history/openapi-1.1.0.yaml ../data/history/openapi-1.2.0.yaml"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), "# API History 1.0.0 to 1.2.0")
+	require.Contains(t, stdout.String(), "## 1.1.0")
+	require.Contains(t, stdout.String(), "endpoint added _(reverted in 1.2.0)_")
+	require.Contains(t, stdout.String(), "api path removed without deprecation _(reverts a change from 1.1.0)_")
+}
+
+func Test_HistoryLevel(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff history ../data/history/openapi-1.0.0.yaml ../data/history/openapi-1.1.0.yaml ../data/history/openapi-1.2.0.yaml --level ERR --format json"), &stdout, io.Discard))
+	releases := formatters.HistoryReleases{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &releases))
+	require.Empty(t, releases[0].Changes)
+	require.Len(t, releases[1].Changes, 1)
+	// the reverted change is filtered out, but the revert is still recognized
+	require.Equal(t, "1.1.0", releases[1].Changes[0].Reverts)
+}
+
+func Test_HistoryOneSpec(t *testing.T) {
+	require.Equal(t, 100, internal.Run(cmdToArgs("oasdiff history ../data/history/openapi-1.0.0.yaml"), io.Discard, io.Discard))
+}
+
+func Test_HistoryUnsupportedFormat(t *testing.T) {
+	require.Equal(t, 100, internal.Run(cmdToArgs("oasdiff history ../data/history/openapi-1.0.0.yaml ../data/history/openapi-1.1.0.yaml --format junit"), io.Discard, io.Discard))
+}
+
+func Test_HistoryGitTagsNotFound(t *testing.T) {
+	require.Equal(t, 108, internal.Run(cmdToArgs("oasdiff history --git-tags no-such-tag-* openapi.yaml"), io.Discard, io.Discard))
+}
+
+func Test_Deprecations(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff deprecations ../data/deprecations/openapi.yaml --as-of 2026-10-18 --format json"), &stdout, io.Discard))
+	deprecations := formatters.Deprecations{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &deprecations))
+	require.Len(t, deprecations, 8)
+
+	endpoint := deprecations[6]
+	require.Equal(t, "endpoint", endpoint.Kind)
+	require.Equal(t, "/pets/{id}", endpoint.Path)
+	require.Equal(t, "2026-09-30", endpoint.Sunset)
+	require.Equal(t, -18, *endpoint.DaysRemaining)
+	require.True(t, endpoint.Overdue)
+	require.Equal(t, "beta", endpoint.Stability)
+}
+
+func Test_DeprecationsText(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff deprecations ../data/deprecations/openapi.yaml --as-of 2026-10-18"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), "8 deprecations as of 2026-10-18, 1 overdue")
+	require.Contains(t, stdout.String(), "DELETE /pets/{id}  endpoint")
+	require.Contains(t, stdout.String(), "OVERDUE")
+}
+
+func Test_DeprecationsICS(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff deprecations ../data/deprecations/openapi.yaml --as-of 2026-10-18 --format ics"), &stdout, io.Discard))
+	require.True(t, strings.HasPrefix(stdout.String(), "BEGIN:VCALENDAR\r\n"))
+	require.Contains(t, stdout.String(), "X-WR-CALNAME:Pet Store sunsets\r\n")
+	require.Equal(t, 5, strings.Count(stdout.String(), "BEGIN:VEVENT"))
+}
+
+func Test_DeprecationsInvalidAsOf(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff deprecations ../data/deprecations/openapi.yaml --as-of 18/10/2026"), io.Discard, io.Discard))
+}
+
+func Test_DeprecationsUnsupportedFormat(t *testing.T) {
+	require.Equal(t, 100, internal.Run(cmdToArgs("oasdiff deprecations ../data/deprecations/openapi.yaml --format sarif"), io.Discard, io.Discard))
+}
+
+func Test_BreakingSunsetDatePassed(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/deprecations/base.yaml ../data/deprecations/openapi.yaml --as-of 2026-10-18 --fail-on WARN --format json"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 1)
+	require.Equal(t, checker.APISunsetDatePassedId, bc[0].Id)
+	require.Equal(t, "/pets/{id}", bc[0].Path)
+}
+
+func Test_BreakingSunsetDateNotPassed(t *testing.T) {
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/deprecations/base.yaml ../data/deprecations/openapi.yaml --as-of 2026-09-30 --fail-on WARN"), io.Discard, io.Discard))
+}
+
+func Test_BreakingSunsetDatePassedIdenticalSpecs(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/deprecations/openapi.yaml ../data/deprecations/openapi.yaml --as-of 2026-10-18 --fail-on WARN --format json"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 1)
+	require.Equal(t, checker.APISunsetDatePassedId, bc[0].Id)
+	require.Equal(t, "/pets/{id}", bc[0].Path)
+}
+
+func Test_BreakingSunsetDatePassedIdenticalSpecsUnmatchPath(t *testing.T) {
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/deprecations/openapi.yaml ../data/deprecations/openapi.yaml --as-of 2026-10-18 --fail-on WARN --unmatch-path ^/pets/"), io.Discard, io.Discard))
+}
+
+func Test_BreakingAsOfExpiresIgnoreEntries(t *testing.T) {
+	ignoreFile := filepath.Join(t.TempDir(), "ignore.yaml")
+	require.NoError(t, os.WriteFile(ignoreFile, []byte("ignore:\n  - id: api-sunset-date-passed\n    expires: 2026-10-20\n    reason: removed in the next release\n"), 0o600))
+
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/deprecations/base.yaml ../data/deprecations/openapi.yaml --as-of 2026-10-20 --fail-on WARN --warn-ignore "+ignoreFile), io.Discard, io.Discard))
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/deprecations/base.yaml ../data/deprecations/openapi.yaml --as-of 2026-10-21 --fail-on WARN --warn-ignore "+ignoreFile), io.Discard, io.Discard))
+}
+
+func Test_BreakingInvalidAsOf(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff breaking ../data/deprecations/base.yaml ../data/deprecations/openapi.yaml --as-of tomorrow"), io.Discard, io.Discard))
+}
+
+func Test_BaseFromGitBothFlags(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff summary --base-from-latest-tag --base-merge-base main openapi.yaml"), io.Discard, io.Discard))
+}
+
+func Test_BaseFromGitTwoArgs(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff breaking --base-merge-base main ../data/openapi-test1.yaml ../data/openapi-test3.yaml"), io.Discard, io.Discard))
+}
+
+func Test_BaseFromGitURLRevision(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff breaking --base-merge-base main https://example.com/openapi.yaml"), io.Discard, io.Discard))
+}
+
+func Test_BreakingOneArgWithoutGitBase(t *testing.T) {
+	require.Equal(t, 101, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml"), io.Discard, io.Discard))
+}
+
 func Test_BreakingChangesInvalidIgnoreFile(t *testing.T) {
 	require.Equal(t, 121, internal.Run(cmdToArgs("oasdiff breaking ../data/openapi-test1.yaml ../data/openapi-test3.yaml --err-ignore no-file"), io.Discard, io.Discard))
 }
@@ -353,6 +737,11 @@ func Test_BreakingChangesFlattenAllOf(t *testing.T) {
 	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/allof/simple.yaml ../data/allof/revision.yaml --flatten-allof --fail-on ERR"), io.Discard, io.Discard))
 }
 
+func Test_BreakingChangesNormalize(t *testing.T) {
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/normalize/nullable_type_array_base.yaml ../data/normalize/nullable_type_array_revision.yaml --fail-on ERR"), io.Discard, io.Discard))
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/normalize/nullable_type_array_base.yaml ../data/normalize/nullable_type_array_revision.yaml --normalize --fail-on ERR"), io.Discard, io.Discard))
+}
+
 func Test_BreakingChangesInvalidDeprecationDays(t *testing.T) {
 	var stderr bytes.Buffer
 	require.Equal(t, 100, internal.Run(cmdToArgs("oasdiff breaking ../data/deprecation/base.yaml ../data/deprecation/deprecated-with-sunset.yaml --deprecation-days-stable=-1"), io.Discard, &stderr))
@@ -488,6 +877,39 @@ func Test_CustomSeverityLevelsInvalidFile(t *testing.T) {
 	require.Equal(t, 106, internal.Run(cmdToArgs("oasdiff changelog ../data/openapi-test1.yaml ../data/openapi-test3.yaml --severity-levels ../data/invalid.txt"), io.Discard, io.Discard))
 }
 
+func Test_CustomRules(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Equal(t, 1, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --custom-rules ../data/custom-rules/rules.yaml --fail-on ERR --format json"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	ids := []string{}
+	for _, change := range bc {
+		ids = append(ids, change.Id)
+	}
+	require.Contains(t, ids, "endpoint-added-without-owner")
+	require.Contains(t, ids, "response-array-max-items-removed")
+	require.Contains(t, ids, "public-enum-value-removed")
+}
+
+func Test_CustomRulesSeverityLevels(t *testing.T) {
+	dir := t.TempDir()
+	severityLevels := filepath.Join(dir, "severity-levels.txt")
+	require.NoError(t, os.WriteFile(severityLevels, []byte("endpoint-added-without-owner none\nresponse-array-max-items-removed none\npublic-enum-value-removed none\n"), 0o600))
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/custom-rules/base.yaml ../data/custom-rules/revision.yaml --custom-rules ../data/custom-rules/rules.yaml --severity-levels "+severityLevels+" --fail-on ERR"), io.Discard, io.Discard))
+}
+
+func Test_CustomRulesInvalidFile(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 116, internal.Run(cmdToArgs("oasdiff changelog ../data/openapi-test1.yaml ../data/openapi-test3.yaml --custom-rules ../data/invalid.txt"), io.Discard, &stderr))
+	require.Contains(t, stderr.String(), "failed to load custom rules from ../data/invalid.txt")
+}
+
+func Test_ChecksChangelogCustomRules(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff checks changelog --custom-rules ../data/custom-rules/rules.yaml --format json"), &stdout, io.Discard))
+	require.Contains(t, stdout.String(), `{"id":"public-enum-value-removed","level":"error","direction":"response","area":"schema","kind":"values","action":"remove","description":"Enum values of x-public schemas must never be removed"}`)
+}
+
 func Test_Changelog_WithoutMatchPath(t *testing.T) {
 	var stdout bytes.Buffer
 	require.Zero(t, internal.Run(cmdToArgs("oasdiff changelog ../data/path-filter/base.yaml ../data/path-filter/revision.yaml --format json"), &stdout, io.Discard))
@@ -583,3 +1005,15 @@ func Test_BothSidesStdin(t *testing.T) {
 	require.Equal(t, 2, strings.Count(stdout.String(), "1.2.3"), "both sides carry the document's version")
 	require.NotContains(t, stdout.String(), "n/a")
 }
+
+func Test_BreakingChangesJSONSourceLocations(t *testing.T) {
+	var stdout bytes.Buffer
+	require.Zero(t, internal.Run(cmdToArgs("oasdiff breaking ../data/json-origin/openapi.json ../data/json-origin/revision.json --format json"), &stdout, io.Discard))
+	bc := formatters.Changes{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &bc))
+	require.Len(t, bc, 1)
+
+	// the base property lives in the $ref'd JSON file
+	require.Equal(t, &checker.Source{File: "../data/json-origin/schemas.json", Line: 10, Column: 21}, bc[0].BaseSource)
+	require.Equal(t, &checker.Source{File: "../data/json-origin/revision.json", Line: 18, Column: 31}, bc[0].RevisionSource)
+}
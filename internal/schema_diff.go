@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/spf13/cobra"
+)
+
+const (
+	directionRequest  = "request"
+	directionResponse = "response"
+)
+
+func getSchemaDiffCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:   "schema-diff base revision --direction request|response [flags]",
+		Short: "Display changes between standalone JSON Schema documents",
+		Long: `Display the changes between two standalone JSON Schema documents, such as config schemas or event payloads, that are not part of an OpenAPI spec.
+Base and revision can be a path to a file or a URL. The schemas are JSON Schema 2020-12, as in OpenAPI 3.1, and their $refs are resolved relative to their location.
+
+The schemas are checked by the rules of request bodies or of responses, depending on --direction:
+  request  - the schema describes data that is accepted, e.g. a config file or a consumed event
+  response - the schema describes data that is produced, e.g. a published event
+
+Changes are located by JSON pointers into the schemas, e.g. #/properties/address/properties/zip.`,
+		Args: getParseSchemaDiffArgs(),
+		RunE: getRun(runSchemaDiff),
+	}
+
+	enumWithOptions(&cmd, newEnumValue([]string{directionRequest, directionResponse}, ""), "direction", "", "check the schemas as the data that is accepted (request) or produced (response)")
+	addCommonBreakingFlags(&cmd)
+	hideFlag(&cmd, "stability-level")
+	enumWithOptions(&cmd, newEnumValue(GetSupportedLevels(), LevelInfo), "level", "", "output changes with this level or higher")
+	enumWithOptions(&cmd, newEnumValue(GetSupportedLevels(), ""), "fail-on", "o", "exit with return code 1 when output includes changes with this level or higher")
+
+	return &cmd
+}
+
+func getParseSchemaDiffArgs() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return errors.New("please specify base and revision arguments as a path to a file or a URL")
+		}
+		return checkColor(cmd)
+	}
+}
+
+func runSchemaDiff(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
+
+	level, err := checker.NewLevel(flags.getLevel())
+	if err != nil {
+		return false, getErrInvalidFlags(fmt.Errorf("invalid level value: %q", flags.getLevel()))
+	}
+
+	if flags.getDirection() == "" {
+		return false, getErrInvalidFlags(errors.New("please specify --direction request or --direction response"))
+	}
+	asRequest := flags.getDirection() == directionRequest
+
+	s1, returnErr := loadSchema("base", flags.getBase(), asRequest)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	s2, returnErr := loadSchema("revision", flags.getRevision(), asRequest)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	diffReport, operationsSources, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	if err != nil {
+		return false, getErrDiffFailed(err)
+	}
+
+	bcConfig, returnErr := getCheckerConfig(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	asOf, returnErr := getAsOf(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	errs, returnErr := filterIgnored(
+		checker.CheckSchemaCompatibility(bcConfig, diffReport, operationsSources, level),
+		flags.getWarnIgnoreFile(),
+		flags.getErrIgnoreFile(),
+		checker.NewLocalizer(flags.getLang()),
+		asOf,
+		flags.getStderr())
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	if returnErr := outputChangelog(flags, stdout, errs, load.NewSpecInfoPair(s1, s2), diffReport.Empty(), false); returnErr != nil {
+		return false, returnErr
+	}
+
+	if flags.getFailOn() != "" {
+		level, err := checker.NewLevel(flags.getFailOn())
+		if err != nil {
+			return false, getErrInvalidFlags(fmt.Errorf("invalid fail-on value %s", flags.getFailOn()))
+		}
+		return errs.HasLevelOrHigher(level), nil
+	}
+
+	return false, nil
+}
+
+// loadSchema loads a JSON Schema document wrapped in a spec. Each document gets
+// its own loader, since the wrapping specs share a location.
+func loadSchema(what string, source *load.Source, asRequest bool) (*load.SpecInfo, *ReturnError) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+
+	specInfo, err := load.NewSpecInfoFromSchema(loader, source, asRequest)
+	if err != nil {
+		return nil, getErrFailedToLoadSchema(what, source, err)
+	}
+	return specInfo, nil
+}
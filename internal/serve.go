@@ -0,0 +1,528 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/build"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/oasdiff/oasdiff/formatters"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/oasdiff/oasdiff/validate"
+	"github.com/spf13/cobra"
+)
+
+const serveCmd = "serve"
+
+const defaultMaxRequestSize = 10 << 20
+
+func getServeCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:   "serve [flags]",
+		Short: "Run an HTTP API server",
+		Long: `Run an HTTP server that exposes the diff, summary, breaking, changelog and
+validate commands as an API.
+
+Endpoints:
+  POST /breaking, /changelog, /diff, /summary   body carries "base" and "revision" specs
+  POST /validate                                body carries a "spec"
+  GET  /health                                  liveness probe
+
+The body is either multipart/form-data, with each spec as a file (or text)
+part, or a JSON object whose spec fields hold the spec text (or the spec itself
+as a JSON object). Any other field is an option named like the command-line
+flag of the same command, e.g. "format", "lang" or "match-path".
+
+The output format is taken from the "format" field or, when it is absent, from
+the Accept header (application/json, application/yaml, text/markdown,
+text/html, application/xml for junit, text/plain for text).
+
+The .oasdiff.* config file is read for every request, so it sets the defaults
+for options that a request doesn't pass. Options that name files on the server
+(err-ignore, warn-ignore, severity-levels, custom-rules, template, baseline,
+consumer-usage, traffic, base-proto, revision-proto) can only be set in the config file.
+`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getRun(func(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
+				return runServe(flags, stdout, explicitConfigPath(cmd))
+			})(cmd, args)
+		},
+	}
+
+	cmd.PersistentFlags().String("listen", "localhost:8080", "address to listen on, host:port")
+	cmd.PersistentFlags().Int64("max-request-size", defaultMaxRequestSize, "maximum size of a request body in bytes")
+	cmd.PersistentFlags().Bool("allow-external-refs", false, "allow external $refs in uploaded specs; keep disabled unless every client is trusted, as it lets a request read server files and URLs")
+
+	return &cmd
+}
+
+// serveExcludedFlags are command flags a request may not set: the interactive
+// ones, and those that reach outside the request (server files, git, network).
+var serveExcludedFlags = map[string]bool{
+	"open":                 true,
+	"review-token":         true,
+	"review-meta":          true,
+	"color":                true,
+	"config":               true,
+	"composed":             true,
+	"fetch":                true,
+	"allow-external-refs":  true,
+	"err-ignore":           true,
+	"warn-ignore":          true,
+	"severity-levels":      true,
+	"custom-rules":         true,
+	"template":             true,
+	"baseline":             true,
+	"baseline-write":       true,
+	"consumer-usage":       true,
+	"traffic":              true,
+	"base-proto":           true,
+	"revision-proto":       true,
+	"base-from-latest-tag": true,
+	"base-merge-base":      true,
+}
+
+type serveEndpoint struct {
+	command  func() *cobra.Command
+	specArgs []string
+	run      func(flags *Flags, stdout io.Writer, specs []*load.SpecInfo) (bool, *ReturnError)
+}
+
+func getServeEndpoints() map[string]serveEndpoint {
+	twoSpecs := []string{"base", "revision"}
+
+	return map[string]serveEndpoint{
+		"/breaking":  {command: getBreakingChangesCmd, specArgs: twoSpecs, run: serveBreakingChanges},
+		"/changelog": {command: getChangelogCmd, specArgs: twoSpecs, run: serveChangelog},
+		"/diff":      {command: getDiffCmd, specArgs: twoSpecs, run: serveDiff},
+		"/summary":   {command: getSummaryCmd, specArgs: twoSpecs, run: serveSummary},
+		"/validate":  {command: getValidateCmd, specArgs: []string{"spec"}, run: serveValidate},
+	}
+}
+
+type apiServer struct {
+	endpoints         map[string]serveEndpoint
+	configPath        string
+	maxRequestSize    int64
+	allowExternalRefs bool
+}
+
+// runServe serves until SIGINT or SIGTERM. Requests read the config file at
+// configPath, or the default one when it is empty, like the command line does.
+func runServe(flags *Flags, stdout io.Writer, configPath string) (bool, *ReturnError) {
+
+	s := &apiServer{
+		endpoints:         getServeEndpoints(),
+		configPath:        configPath,
+		maxRequestSize:    flags.getMaxRequestSize(),
+		allowExternalRefs: flags.getAllowExternalRefs(),
+	}
+
+	httpServer := &http.Server{
+		Addr:              flags.getListen(),
+		Handler:           s.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- httpServer.ListenAndServe()
+	}()
+
+	_, _ = fmt.Fprintf(stdout, "oasdiff %s listening on %s\n", serveCmd, httpServer.Addr)
+
+	select {
+	case err := <-errs:
+		return false, getErrServeFailed(err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return false, getErrServeFailed(err)
+	}
+
+	return false, nil
+}
+
+func (s *apiServer) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, _ *http.Request) {
+		writeServeJSON(w, http.StatusOK, map[string]string{"status": "ok", "version": build.Version})
+	})
+
+	for path, endpoint := range s.endpoints {
+		mux.HandleFunc("POST "+path, func(w http.ResponseWriter, r *http.Request) {
+			s.serve(w, r, endpoint)
+		})
+	}
+
+	return mux
+}
+
+func (s *apiServer) serve(w http.ResponseWriter, r *http.Request, endpoint serveEndpoint) {
+
+	r.Body = http.MaxBytesReader(w, r.Body, s.maxRequestSize)
+
+	specs, arguments, err := s.parseRequest(r, endpoint.specArgs)
+	if err != nil {
+		if maxBytesErr, ok := errors.AsType[*http.MaxBytesError](err); ok {
+			writeServeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds %d bytes", maxBytesErr.Limit))
+			return
+		}
+		writeServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, ok := arguments["format"]; !ok {
+		format, ok := negotiateFormat(r.Header.Get("Accept"), getFormatFlagValues(endpoint.command()))
+		if !ok {
+			writeServeError(w, http.StatusNotAcceptable, fmt.Errorf("none of the accepted media types %q is supported", r.Header.Get("Accept")))
+			return
+		}
+		if format != "" {
+			arguments["format"] = format
+		}
+	}
+
+	flags, returnErr := newFlagsFromArguments(endpoint.command(), s.configPath, arguments, endpoint.specArgs, serveExcludedFlags)
+	if returnErr != nil {
+		writeServeError(w, getServeStatus(returnErr), returnErr)
+		return
+	}
+	flags.getViper().Set("color", "never")
+	flags.getViper().Set("allow-external-refs", s.allowExternalRefs)
+
+	specInfos := make([]*load.SpecInfo, 0, len(endpoint.specArgs))
+	for _, arg := range endpoint.specArgs {
+		specInfo, err := loadServeSpec(flags, specs[arg])
+		if err != nil {
+			returnErr := getErrFailedToLoadSpec(arg, load.NewSource(specs[arg].name), err)
+			writeServeError(w, getServeStatus(returnErr), returnErr)
+			return
+		}
+		specInfos = append(specInfos, specInfo)
+	}
+
+	var body strings.Builder
+	failed, returnErr := endpoint.run(flags, &body, specInfos)
+	if returnErr != nil {
+		writeServeError(w, getServeStatus(returnErr), returnErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", getFormatContentType(flags.getFormat()))
+	// the CLI turns this into exit code 1; a client can read it without parsing the body
+	w.Header().Set("X-Oasdiff-Failed", fmt.Sprint(failed))
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, body.String())
+}
+
+type serveSpec struct {
+	name string
+	data []byte
+}
+
+// parseRequest reads the specs and the options from a multipart or JSON body.
+func (s *apiServer) parseRequest(r *http.Request, specArgs []string) (map[string]serveSpec, map[string]any, error) {
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, nil, errors.New("missing or invalid Content-Type, use multipart/form-data or application/json")
+	}
+
+	var specs map[string]serveSpec
+	var arguments map[string]any
+
+	switch mediaType {
+	case "multipart/form-data":
+		specs, arguments, err = parseMultipartRequest(r, s.maxRequestSize)
+	case "application/json":
+		specs, arguments, err = parseJSONRequest(r, specArgs)
+	default:
+		return nil, nil, fmt.Errorf("unsupported Content-Type %q, use multipart/form-data or application/json", mediaType)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, arg := range specArgs {
+		if _, ok := specs[arg]; !ok {
+			return nil, nil, fmt.Errorf("missing required spec %q", arg)
+		}
+	}
+
+	return specs, arguments, nil
+}
+
+func parseMultipartRequest(r *http.Request, maxMemory int64) (map[string]serveSpec, map[string]any, error) {
+
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		return nil, nil, err
+	}
+
+	specs := map[string]serveSpec{}
+	for name, headers := range r.MultipartForm.File {
+		if len(headers) != 1 {
+			return nil, nil, fmt.Errorf("expected a single file in part %q", name)
+		}
+		file, err := headers[0].Open()
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := io.ReadAll(file)
+		_ = file.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		specs[name] = serveSpec{name: specName(headers[0].Filename, name), data: data}
+	}
+
+	arguments := map[string]any{}
+	for name, values := range r.MultipartForm.Value {
+		if _, ok := specs[name]; ok {
+			return nil, nil, fmt.Errorf("part %q is given twice", name)
+		}
+		arguments[name] = values
+	}
+
+	// a spec can also be sent as a text part
+	for _, name := range []string{"base", "revision", "spec"} {
+		if values, ok := r.MultipartForm.Value[name]; ok {
+			specs[name] = serveSpec{name: name, data: []byte(strings.Join(values, "\n"))}
+			delete(arguments, name)
+		}
+	}
+
+	return specs, arguments, nil
+}
+
+func parseJSONRequest(r *http.Request, specArgs []string) (map[string]serveSpec, map[string]any, error) {
+
+	arguments := map[string]any{}
+	if err := json.NewDecoder(r.Body).Decode(&arguments); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON body: %w", err)
+	}
+
+	specs := map[string]serveSpec{}
+	for _, arg := range specArgs {
+		value, ok := arguments[arg]
+		if !ok {
+			continue
+		}
+		delete(arguments, arg)
+
+		switch v := value.(type) {
+		case string:
+			specs[arg] = serveSpec{name: arg, data: []byte(v)}
+		case map[string]any:
+			// the spec itself, embedded as a JSON object
+			data, err := json.Marshal(v)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid spec %q: %w", arg, err)
+			}
+			specs[arg] = serveSpec{name: arg, data: data}
+		default:
+			return nil, nil, fmt.Errorf("spec %q must be a string or an object", arg)
+		}
+	}
+
+	return specs, arguments, nil
+}
+
+func specName(filename, field string) string {
+	if filename != "" {
+		return filename
+	}
+	return field
+}
+
+// loadServeSpec loads an uploaded spec with the same options as a spec read
+// from disk. Each spec gets its own loader so two uploads with the same name
+// don't share the loader's document cache.
+func loadServeSpec(flags *Flags, spec serveSpec) (*load.SpecInfo, error) {
+	loader := openapi3.NewLoader()
+	loader.IncludeOrigin = true
+	loader.IsExternalRefsAllowed = flags.getAllowExternalRefs()
+
+	return load.NewSpecInfoFromData(loader, spec.data, spec.name, getLoadOptions(flags)...)
+}
+
+func serveBreakingChanges(flags *Flags, stdout io.Writer, specs []*load.SpecInfo) (bool, *ReturnError) {
+	diffResult, returnErr := diffSpecs(flags, specs[0], specs[1])
+	if returnErr != nil {
+		return false, returnErr
+	}
+	return reportChangelog(flags, stdout, diffResult, checker.WARN, true)
+}
+
+func serveChangelog(flags *Flags, stdout io.Writer, specs []*load.SpecInfo) (bool, *ReturnError) {
+	level, err := checker.NewLevel(flags.getLevel())
+	if err != nil {
+		return false, getErrInvalidFlags(fmt.Errorf("invalid level value: %q", flags.getLevel()))
+	}
+
+	diffResult, returnErr := diffSpecs(flags, specs[0], specs[1])
+	if returnErr != nil {
+		return false, returnErr
+	}
+	return reportChangelog(flags, stdout, diffResult, level, false)
+}
+
+func serveDiff(flags *Flags, stdout io.Writer, specs []*load.SpecInfo) (bool, *ReturnError) {
+	if flags.getFormat() == string(formatters.FormatJSON) {
+		flags.addExcludeElements(diff.ExcludeEndpointsOption)
+	}
+
+	diffResult, returnErr := diffSpecs(flags, specs[0], specs[1])
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	if returnErr := outputDiff(stdout, diffResult.diffReport, flags.getFormat()); returnErr != nil {
+		return false, returnErr
+	}
+
+	return flags.getFailOnDiff() && !diffResult.diffReport.Empty(), nil
+}
+
+func serveSummary(flags *Flags, stdout io.Writer, specs []*load.SpecInfo) (bool, *ReturnError) {
+	diffResult, returnErr := diffSpecs(flags, specs[0], specs[1])
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	if returnErr := outputSummary(stdout, diffResult.diffReport, flags.getFormat()); returnErr != nil {
+		return false, returnErr
+	}
+
+	return flags.getFailOnDiff() && !diffResult.diffReport.Empty(), nil
+}
+
+func serveValidate(flags *Flags, stdout io.Writer, specs []*load.SpecInfo) (bool, *ReturnError) {
+	findings := validate.Validate(specs[0].Spec, specs[0].Url)
+
+	if returnErr := outputFindings(flags, stdout, findings); returnErr != nil {
+		return false, returnErr
+	}
+
+	failOn, err := checker.NewLevel(flags.getFailOn())
+	if err != nil {
+		return false, getErrInvalidFlags(fmt.Errorf("invalid fail-on value: %q", flags.getFailOn()))
+	}
+
+	return findings.HasLevelOrHigher(failOn), nil
+}
+
+// formatMediaTypes maps the Accept header media types to output formats.
+var formatMediaTypes = map[string]formatters.Format{
+	"application/json":       formatters.FormatJSON,
+	"application/yaml":       formatters.FormatYAML,
+	"application/x-yaml":     formatters.FormatYAML,
+	"text/yaml":              formatters.FormatYAML,
+	"text/markdown":          formatters.FormatMarkdown,
+	"text/html":              formatters.FormatHTML,
+	"application/xml":        formatters.FormatJUnit,
+	"text/xml":               formatters.FormatJUnit,
+	"text/plain":             formatters.FormatText,
+	"application/sarif+json": formatters.FormatSarif,
+}
+
+// negotiateFormat picks the first media type in the Accept header that the
+// command supports, in the order the client listed them. It returns "" when the
+// client accepts anything, so the command's default format applies, and false
+// when it accepts nothing the command can produce.
+func negotiateFormat(accept string, supported []string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return "", true
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if mediaType == "*/*" {
+			return "", true
+		}
+		if format, ok := formatMediaTypes[mediaType]; ok && slices.Contains(supported, string(format)) {
+			return string(format), true
+		}
+	}
+
+	return "", false
+}
+
+func getFormatFlagValues(cmd *cobra.Command) []string {
+	if flag := cmd.PersistentFlags().Lookup("format"); flag != nil {
+		if value, ok := flag.Value.(*enumValue); ok {
+			return value.allowedValues
+		}
+	}
+	return nil
+}
+
+func getFormatContentType(format string) string {
+	switch formatters.Format(format) {
+	case formatters.FormatJSON:
+		return "application/json"
+	case formatters.FormatYAML:
+		return "application/yaml"
+	case formatters.FormatMarkdown, formatters.FormatMarkup:
+		return "text/markdown; charset=utf-8"
+	case formatters.FormatHTML:
+		return "text/html; charset=utf-8"
+	case formatters.FormatJUnit:
+		return "application/xml"
+	case formatters.FormatSarif:
+		return "application/sarif+json"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// getServeStatus maps a command's return code onto an HTTP status: problems
+// with the request are the client's, problems with the server config are not.
+func getServeStatus(err *ReturnError) int {
+	switch err.Code {
+	case invalidFlagsErr, templateNotSupportedErr:
+		return http.StatusBadRequest
+	case failedToLoadSpecErr, diffFailedErr, failedToFlattenErr, disallowedExternalRefErr:
+		return http.StatusUnprocessableEntity
+	case unsupportedFormatErr:
+		return http.StatusNotAcceptable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeServeError(w http.ResponseWriter, status int, err error) {
+	writeServeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeServeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
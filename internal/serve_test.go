@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer() *apiServer {
+	return &apiServer{
+		endpoints:      getServeEndpoints(),
+		maxRequestSize: defaultMaxRequestSize,
+	}
+}
+
+func readTestSpec(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func postServeJSON(t *testing.T, s *apiServer, path string, body map[string]any, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	request.Header.Set("Content-Type", "application/json")
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.handler().ServeHTTP(recorder, request)
+	return recorder
+}
+
+func Test_ServeHealth(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	newTestServer().handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `"status":"ok"`)
+}
+
+func Test_ServeBreakingJSON(t *testing.T) {
+	recorder := postServeJSON(t, newTestServer(), "/breaking", map[string]any{
+		"base":     readTestSpec(t, "../data/openapi-test1.yaml"),
+		"revision": readTestSpec(t, "../data/openapi-test3.yaml"),
+		"format":   "json",
+		"fail-on":  "ERR",
+	}, "")
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	require.Equal(t, "true", recorder.Header().Get("X-Oasdiff-Failed"))
+
+	var changes []map[string]any
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &changes))
+	require.NotEmpty(t, changes)
+}
+
+func Test_ServeAcceptHeader(t *testing.T) {
+	recorder := postServeJSON(t, newTestServer(), "/changelog", map[string]any{
+		"base":     readTestSpec(t, "../data/openapi-test1.yaml"),
+		"revision": readTestSpec(t, "../data/openapi-test3.yaml"),
+	}, "application/vnd.unknown, text/markdown")
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "text/markdown; charset=utf-8", recorder.Header().Get("Content-Type"))
+	require.Equal(t, "false", recorder.Header().Get("X-Oasdiff-Failed"))
+}
+
+func Test_ServeNotAcceptable(t *testing.T) {
+	recorder := postServeJSON(t, newTestServer(), "/diff", map[string]any{
+		"base":     readTestSpec(t, "../data/openapi-test1.yaml"),
+		"revision": readTestSpec(t, "../data/openapi-test3.yaml"),
+	}, "application/xml")
+
+	require.Equal(t, http.StatusNotAcceptable, recorder.Code)
+}
+
+func Test_ServeMultipart(t *testing.T) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, path := range map[string]string{"base": "../data/openapi-test1.yaml", "revision": "../data/openapi-test3.yaml"} {
+		part, err := writer.CreateFormFile(name, path)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(readTestSpec(t, path)))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.WriteField("format", "yaml"))
+	require.NoError(t, writer.Close())
+
+	request := httptest.NewRequest(http.MethodPost, "/summary", &body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	recorder := httptest.NewRecorder()
+	newTestServer().handler().ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "application/yaml", recorder.Header().Get("Content-Type"))
+	require.Contains(t, recorder.Body.String(), "diff: true")
+}
+
+func Test_ServeValidate(t *testing.T) {
+	recorder := postServeJSON(t, newTestServer(), "/validate", map[string]any{
+		"spec": readTestSpec(t, "../data/openapi-test1.yaml"),
+	}, "application/json")
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+}
+
+func Test_ServeErrors(t *testing.T) {
+	s := newTestServer()
+
+	recorder := postServeJSON(t, s, "/breaking", map[string]any{
+		"base": readTestSpec(t, "../data/openapi-test1.yaml"),
+	}, "")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `missing required spec \"revision\"`)
+
+	recorder = postServeJSON(t, s, "/breaking", map[string]any{
+		"base":       readTestSpec(t, "../data/openapi-test1.yaml"),
+		"revision":   readTestSpec(t, "../data/openapi-test3.yaml"),
+		"err-ignore": "/etc/passwd",
+	}, "")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `unknown argument \"err-ignore\"`)
+
+	recorder = postServeJSON(t, s, "/breaking", map[string]any{
+		"base":     "not: [a spec",
+		"revision": readTestSpec(t, "../data/openapi-test3.yaml"),
+	}, "")
+	require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "failed to load base spec")
+
+	recorder = httptest.NewRecorder()
+	s.handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/breaking", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func Test_ServeRequestTooLarge(t *testing.T) {
+	s := newTestServer()
+	s.maxRequestSize = 100
+
+	recorder := postServeJSON(t, s, "/breaking", map[string]any{
+		"base":     strings.Repeat("a", 200),
+		"revision": "b",
+	}, "")
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+}
+
+func Test_ServeStatus(t *testing.T) {
+	tests := []struct {
+		code   int
+		status int
+	}{
+		{generalExecutionErr, http.StatusInternalServerError},
+		{invalidFlagsErr, http.StatusBadRequest},
+		{failedToLoadSpecErr, http.StatusUnprocessableEntity},
+		{failedToLoadSpecsErr, http.StatusInternalServerError},
+		{diffFailedErr, http.StatusUnprocessableEntity},
+		{failedPrintErr, http.StatusInternalServerError},
+		{failedToLoadSeverityLevelsErr, http.StatusInternalServerError},
+		{configFileProblemErr, http.StatusInternalServerError},
+		{failedToListGitTagsErr, http.StatusInternalServerError},
+		{failedToResolveGitBaseErr, http.StatusInternalServerError},
+		{unsupportedFormatErr, http.StatusNotAcceptable},
+		{templateNotSupportedErr, http.StatusBadRequest},
+		{cantProcessConsumerUsageErr, http.StatusInternalServerError},
+		{cantProcessTrafficErr, http.StatusInternalServerError},
+		{invalidColorModeErr, http.StatusInternalServerError},
+		{cantProcessProtoErr, http.StatusInternalServerError},
+		{failedToLoadCustomRulesErr, http.StatusInternalServerError},
+		{cantProcessIgnoreFileErr, http.StatusInternalServerError},
+		{failedToFlattenErr, http.StatusUnprocessableEntity},
+		{disallowedExternalRefErr, http.StatusUnprocessableEntity},
+		{serveFailedErr, http.StatusInternalServerError},
+		{cantProcessBaselineErr, http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.status, getServeStatus(getError(nil, test.code)), "code %d", test.code)
+	}
+}
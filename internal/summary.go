@@ -16,7 +16,7 @@ func getSummaryCmd() *cobra.Command {
 	cmd := cobra.Command{
 		Use:   "summary base revision [flags]",
 		Short: "Generate a diff summary",
-		Long:  "Display a summary of changes between base and revision specs." + specHelp,
+		Long:  "Display a summary of changes between base and revision specs." + specHelp + gitBaseHelp,
 		Args:  getParseArgs(),
 		RunE:  getRun(runSummary),
 	}
@@ -25,6 +25,7 @@ func getSummaryCmd() *cobra.Command {
 	enumWithOptions(&cmd, newEnumSliceValue(diff.GetExcludeDiffOptions(), nil), "exclude-elements", "e", "elements to exclude")
 	enumWithOptions(&cmd, newEnumValue(formatters.SupportedFormatsByContentType(formatters.OutputSummary), string(formatters.FormatYAML)), "format", "f", "output format")
 	cmd.PersistentFlags().BoolP("fail-on-diff", "", false, "exit with return code 1 when any change is found")
+	addGitBaseFlags(&cmd)
 
 	return &cmd
 }
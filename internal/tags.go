@@ -9,7 +9,7 @@ func getAllTags() []string {
 		// action
 		"add", "remove", "change", "generalize", "specialize", "increase", "decrease", "set",
 		// area (OpenAPI object)
-		"schema", "parameters", "requestBody", "responses", "paths", "headers", "security", "tags", "components",
+		"schema", "parameters", "requestBody", "responses", "paths", "headers", "security", "tags", "components", "channels",
 		// kind (aspect of the contract)
 		"existence", "requiredness", "mutability", "type", "constraints", "values", "structure", "lifecycle",
 	}
@@ -104,6 +104,8 @@ func matchAreaTag(tag string, area checker.Area) bool {
 		return area == checker.AreaTags
 	case "components":
 		return area == checker.AreaComponents
+	case "channels":
+		return area == checker.AreaChannels
 	}
 
 	return false
@@ -17,8 +17,11 @@ func getUpgradeCmd() *cobra.Command {
 
 	cmd := cobra.Command{
 		Use:   "upgrade spec",
-		Short: "Canonicalize an OpenAPI 3.x spec to the latest 3.x version",
-		Long: `Convert an OpenAPI 3.x spec to the latest 3.x representation.
+		Short: "Canonicalize an OpenAPI 3.x or Swagger 2.0 spec to the latest 3.x version",
+		Long: `Convert an OpenAPI 3.x or Swagger 2.0 spec to the latest 3.x representation.
+
+A Swagger 2.0 spec is first converted to OpenAPI 3 (definitions -> components,
+body and form parameters -> request bodies, host and basePath -> servers).
 
 The walker rewrites schema-level constructs in place (nullable -> type array,
 boolean exclusiveMinimum/Maximum -> numeric, example -> examples, and similar),
@@ -63,6 +63,7 @@ Spec can be a path to a file, a URL, a git ref (e.g. main:openapi.yaml), or '-'
 	enumWithOptions(&cmd, newEnumValue(checker.GetSupportedColorValues(), "auto"), "color", "", "when to colorize textual output")
 	enumWithOptions(&cmd, newEnumValue(GetSupportedLevels(), LevelErr), "fail-on", "o", "exit with code 1 when a finding has this severity or higher")
 	cmd.PersistentFlags().Bool("allow-external-refs", true, "allow external $refs in specs; disable to prevent SSRF when processing untrusted specs")
+	addWatchFlag(&cmd, "spec")
 
 	return &cmd
 }
@@ -75,11 +76,15 @@ func runValidate(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
 	// pointing at the offending element. Cheap to leave on; consumers
 	// that don't surface line/column simply ignore the extra fields.
 	loader.IncludeOrigin = true
+	flags.watch.setLoader(loader, flags.getBase())
 
-	spec, err := load.NewSpecInfo(loader, flags.getBase())
+	// in watch mode, the files that the spec is loaded from are recorded to be watched
+	newSpecInfo := loaderForOpen(flags.watch != nil, load.NewSpecInfo, load.NewSpecInfoWithCapture)
+	spec, err := newSpecInfo(loader, flags.getBase())
 	if err != nil {
 		return false, getErrFailedToLoadSpec("original", flags.getBase(), err)
 	}
+	flags.watch.record(spec)
 
 	// Render zero findings through the formatter too: the empty representation
 	// is format-specific, so it's the formatter's call, not an early return's.
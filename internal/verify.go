@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/verify"
+	"github.com/spf13/cobra"
+)
+
+func getVerifyCmd() *cobra.Command {
+
+	cmd := cobra.Command{
+		Use:   "verify base revision [flags]",
+		Short: "Verify breaking changes with counterexamples",
+		Long: `Display breaking changes between base and revision specs, each with a counterexample: a payload that the base accepts and the revision rejects, for a request, or that the revision allows and the base rejects, for a response.
+Changes to request bodies, response bodies and request parameters are verified; breaking changes without a counterexample are flagged as possibly false positives.` + specHelp + gitBaseHelp,
+		Args: getParseArgs(),
+		RunE: getRun(runVerify),
+	}
+
+	addCommonDiffFlags(&cmd)
+	addCommonBreakingFlags(&cmd)
+	enumWithOptions(&cmd, newEnumValue(GetBreakingLevels(), ""), "fail-on", "o", "exit with return code 1 when output includes errors with this level or higher")
+	addGitBaseFlags(&cmd)
+
+	return &cmd
+}
+
+func runVerify(flags *Flags, stdout io.Writer) (bool, *ReturnError) {
+
+	if flags.getComposed() {
+		return false, getErrInvalidFlags(errors.New("verify doesn't support composed mode"))
+	}
+
+	diffResult, returnErr := calcDiff(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	bcConfig, returnErr := getCheckerConfig(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	asOf, returnErr := getAsOf(flags)
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	errs, returnErr := filterIgnored(
+		verify.Changes(
+			checker.CheckBackwardCompatibilityUntilLevel(bcConfig, diffResult.checkReport(), diffResult.operationsSources, checker.WARN),
+			diffResult.specInfoPair.Base.Spec,
+			diffResult.specInfoPair.Revision.Spec),
+		flags.getWarnIgnoreFile(),
+		flags.getErrIgnoreFile(),
+		checker.NewLocalizer(flags.getLang()),
+		asOf,
+		flags.getStderr())
+	if returnErr != nil {
+		return false, returnErr
+	}
+
+	if returnErr := outputChangelog(flags, stdout, errs, diffResult.specInfoPair, diffResult.diffReport.Empty(), true); returnErr != nil {
+		return false, returnErr
+	}
+
+	if flags.getFailOn() != "" {
+		level, err := checker.NewLevel(flags.getFailOn())
+		if err != nil {
+			return false, getErrInvalidFlags(fmt.Errorf("invalid fail-on value %s", flags.getFailOn()))
+		}
+		return errs.HasLevelOrHigher(level), nil
+	}
+
+	return false, nil
+}
@@ -28,7 +28,13 @@ var configRelativePathKeys = []string{
 	"err-ignore",
 	"warn-ignore",
 	"severity-levels",
+	"custom-rules",
 	"template",
+	"baseline",
+	"baseline-write",
+	"traffic",
+	"base-proto",
+	"revision-proto",
 }
 
 type IViper interface {
@@ -181,6 +187,7 @@ type Config struct {
 	Composed               bool     `mapstructure:"composed"`
 	FlattenAllof           bool     `mapstructure:"flatten-allof"`
 	FlattenParams          bool     `mapstructure:"flatten-params"`
+	Normalize              bool     `mapstructure:"normalize"`
 	CaseInsensitiveHeaders bool     `mapstructure:"case-insensitive-headers"`
 	DeprecationDaysBeta    uint     `mapstructure:"deprecation-days-beta"`
 	DeprecationDaysStable  uint     `mapstructure:"deprecation-days-stable"`
@@ -193,6 +200,7 @@ type Config struct {
 	Level                  string   `mapstructure:"level"`
 	FailOnDiff             bool     `mapstructure:"fail-on-diff"`
 	SeverityLevels         string   `mapstructure:"severity-levels"`
+	CustomRules            string   `mapstructure:"custom-rules"`
 	StabilityLevel         string   `mapstructure:"stability-level"`
 	ExcludeElements        []string `mapstructure:"exclude-elements"`
 	ExcludeExtensions      []string `mapstructure:"exclude-extensions"`
@@ -211,6 +219,22 @@ type Config struct {
 	AutoUpgrade            bool     `mapstructure:"auto-upgrade"`
 	Fetch                  bool     `mapstructure:"fetch"`
 	Template               string   `mapstructure:"template"`
+	Baseline               string   `mapstructure:"baseline"`
+	BaselineWrite          string   `mapstructure:"baseline-write"`
+	ConsumerUsage          []string `mapstructure:"consumer-usage"`
+	Traffic                string   `mapstructure:"traffic"`
+	TrafficDemoteBelow     uint     `mapstructure:"traffic-demote-below"`
+	TrafficEscalateAt      uint     `mapstructure:"traffic-escalate-at"`
+	BaseProto              string   `mapstructure:"base-proto"`
+	RevisionProto          string   `mapstructure:"revision-proto"`
+	Direction              string   `mapstructure:"direction"`
+	Listen                 string   `mapstructure:"listen"`
+	MaxRequestSize         int64    `mapstructure:"max-request-size"`
+	GitTags                string   `mapstructure:"git-tags"`
+	AsOf                   string   `mapstructure:"as-of"`
+	BaseFromLatestTag      string   `mapstructure:"base-from-latest-tag"`
+	BaseMergeBase          string   `mapstructure:"base-merge-base"`
+	Base                   string   `mapstructure:"base"`
 }
 
 // validateViperConfig checks that each of the provided configuration values is one of the generally accepted values
@@ -242,6 +266,10 @@ func validateViperConfig(v IViper) error {
 		return err
 	}
 
+	if err := validateString([]string{directionRequest, directionResponse}, config.Direction, "direction"); err != nil {
+		return err
+	}
+
 	if err := validateStrings(diff.GetExcludeDiffOptions(), config.ExcludeElements, "exclude-elements"); err != nil {
 		return err
 	}
@@ -87,7 +87,7 @@ func TestViper_InvalidFormat(t *testing.T) {
 
 	cmd := cobra.Command{}
 
-	require.EqualError(t, internal.RunViper(&cmd, v), "failed to load config file: invalid format \"invalid\", allowed values: yaml, json, text, markup, markdown, singleline, html, githubactions, junit, sarif")
+	require.EqualError(t, internal.RunViper(&cmd, v), "failed to load config file: invalid format \"invalid\", allowed values: yaml, json, text, markup, markdown, singleline, html, githubactions, junit, sarif, ics")
 }
 
 func TestViper_InvalidFailOn(t *testing.T) {
@@ -142,7 +142,7 @@ func TestViper_InvalidTags(t *testing.T) {
 
 	cmd := cobra.Command{}
 
-	require.EqualError(t, internal.RunViper(&cmd, v), "failed to load config file: invalid tags \"invalid\", allowed values: request, response, add, remove, change, generalize, specialize, increase, decrease, set, schema, parameters, requestBody, responses, paths, headers, security, tags, components, existence, requiredness, mutability, type, constraints, values, structure, lifecycle")
+	require.EqualError(t, internal.RunViper(&cmd, v), "failed to load config file: invalid tags \"invalid\", allowed values: request, response, add, remove, change, generalize, specialize, increase, decrease, set, schema, parameters, requestBody, responses, paths, headers, security, tags, components, channels, existence, requiredness, mutability, type, constraints, values, structure, lifecycle")
 }
 
 func TestViper_ValidTags(t *testing.T) {
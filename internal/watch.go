@@ -0,0 +1,257 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/spf13/cobra"
+)
+
+// watchInterval is how often watch mode checks the watched files for changes.
+// A change is acted upon once the files stay unchanged for one more interval,
+// so that an editor that saves in several writes triggers a single run.
+var watchInterval = 300 * time.Millisecond
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+func addWatchFlag(cmd *cobra.Command, specName string) {
+	cmd.PersistentFlags().Bool("watch", false, fmt.Sprintf("keep running and re-run whenever the %s or a file that it references changes", specName))
+}
+
+// watchState is what watch mode keeps from one run to the next
+type watchState struct {
+	// root is the watched spec
+	root string
+	// files are the local files that the watched spec was loaded from in the last successful load
+	files []string
+	// base is the base spec, when it is loaded from a source that isn't a local file
+	base *load.SpecInfo
+}
+
+func newWatchState(root string) *watchState {
+	return &watchState{
+		root:  root,
+		files: []string{root},
+	}
+}
+
+// uncachedReadFromURI reads referenced files like kin-openapi's default reader, without its process-wide cache,
+// so that each run sees the current version of the files
+var uncachedReadFromURI = openapi3.ReadFromURIs(openapi3.ReadFromHTTP(http.DefaultClient), openapi3.ReadFromFile)
+
+// getUncachedReader returns uncachedReadFromURI for a loader, see restrictReader
+func getUncachedReader(loader *openapi3.Loader, roots ...*load.Source) openapi3.ReadFromURIFunc {
+	return restrictReader(loader, uncachedReadFromURI, roots...)
+}
+
+// restrictReader returns the read function for a loader.
+// A custom reader bypasses the loader's IsExternalRefsAllowed, so when external refs are disallowed the returned reader only reads the given root specs.
+func restrictReader(loader *openapi3.Loader, read openapi3.ReadFromURIFunc, roots ...*load.Source) openapi3.ReadFromURIFunc {
+	if loader.IsExternalRefsAllowed {
+		return read
+	}
+
+	allowed := map[string]bool{}
+	for _, root := range roots {
+		if root != nil {
+			allowed[root.Path] = true
+			allowed[filepath.ToSlash(root.Path)] = true
+		}
+	}
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if !allowed[location.Path] && !allowed[location.String()] {
+			return nil, fmt.Errorf("encountered disallowed external reference: %q", location.String())
+		}
+		return read(loader, location)
+	}
+}
+
+// setLoader makes the loader read the current version of the files that the given specs reference
+func (state *watchState) setLoader(loader *openapi3.Loader, roots ...*load.Source) {
+	if state == nil {
+		return
+	}
+	loader.ReadFromURIFunc = getUncachedReader(loader, roots...)
+}
+
+// loadBase returns the base spec loaded by newSpecInfo.
+// A base from a git revision, a URL or stdin doesn't change while the revision is being edited, so it is loaded once and reused.
+func (state *watchState) loadBase(source *load.Source, newSpecInfo func() (*load.SpecInfo, error)) (*load.SpecInfo, error) {
+	if state == nil || source.Type == load.SourceTypeFile {
+		return newSpecInfo()
+	}
+
+	if state.base == nil {
+		specInfo, err := newSpecInfo()
+		if err != nil {
+			return nil, err
+		}
+		state.base = specInfo
+	}
+	return state.base, nil
+}
+
+// record sets the watched files to the local files that the watched spec was loaded from
+func (state *watchState) record(specInfo *load.SpecInfo) {
+	if state == nil || specInfo == nil {
+		return
+	}
+
+	files := map[string]bool{state.root: true}
+	for key := range specInfo.Sources {
+		if path, ok := getLocalPath(key); ok {
+			files[path] = true
+		}
+	}
+	state.files = slices.Sorted(maps.Keys(files))
+}
+
+// getLocalPath returns the path of a file that the loader read, if it is a local file
+func getLocalPath(key string) (string, bool) {
+	u, err := url.Parse(key)
+	if err != nil {
+		return key, true
+	}
+
+	switch u.Scheme {
+	case "":
+		return key, true
+	case "file":
+		return u.Path, true
+	}
+	return "", false
+}
+
+// fileStamp identifies a version of a file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+type fileStamps map[string]fileStamp
+
+func (state *watchState) getStamps() fileStamps {
+	result := fileStamps{}
+	for _, file := range state.files {
+		info, err := os.Stat(file)
+		if err != nil {
+			result[file] = fileStamp{}
+			continue
+		}
+		result[file] = fileStamp{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+			exists:  true,
+		}
+	}
+	return result
+}
+
+// waitForChange polls the watched files until they differ from stamps and then stay unchanged for an interval.
+// It returns false if the context is done first.
+func (state *watchState) waitForChange(ctx context.Context, stamps fileStamps) bool {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	var changed fileStamps
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+
+		current := state.getStamps()
+		if changed != nil && maps.Equal(current, changed) {
+			return true
+		}
+		if !maps.Equal(current, stamps) {
+			changed = current
+		}
+	}
+}
+
+// runWatch runs the command, and runs it again whenever the watched spec or one of the files it references changes, until the context is done.
+// The output is redrawn only when it changes; errors, such as a spec that fails to load while it is being edited, are reported and watching goes on.
+func runWatch(ctx context.Context, flags *Flags, runner runner, stdout, stderr io.Writer) *ReturnError {
+
+	source := flags.getWatchedSource()
+	if source == nil || source.Type != load.SourceTypeFile {
+		return getErrInvalidFlags(errors.New("--watch requires the watched spec to be a local file"))
+	}
+	if flags.getComposed() {
+		return getErrInvalidFlags(errors.New("--watch can't be used in composed mode"))
+	}
+	if flags.getOpen() {
+		return getErrInvalidFlags(errors.New("--watch can't be used with --open"))
+	}
+
+	state := newWatchState(source.Path)
+	flags.watch = state
+
+	terminal := isTerminal(stdout)
+	var previous []byte
+	for {
+		stamps := state.getStamps()
+
+		var output bytes.Buffer
+		_, returnErr := runner(flags, &output)
+
+		switch {
+		case returnErr != nil:
+			previous = nil
+			writeWatchOutput(stdout, terminal, nil)
+			_, _ = fmt.Fprintf(stderr, "Error: %v\n", returnErr)
+		case !bytes.Equal(output.Bytes(), previous):
+			previous = output.Bytes()
+			writeWatchOutput(stdout, terminal, previous)
+		default:
+			_, _ = fmt.Fprintln(stderr, "The output didn't change")
+		}
+
+		// the run may have changed the watched files: files read for the first time are compared to their current version
+		current := state.getStamps()
+		for file, stamp := range stamps {
+			if _, ok := current[file]; ok {
+				current[file] = stamp
+			}
+		}
+		stamps = current
+
+		_, _ = fmt.Fprintf(stderr, "Watching %d file(s) for changes, press Ctrl+C to stop\n", len(state.files))
+		if !state.waitForChange(ctx, stamps) {
+			return nil
+		}
+	}
+}
+
+func writeWatchOutput(stdout io.Writer, terminal bool, output []byte) {
+	if terminal {
+		_, _ = io.WriteString(stdout, clearScreen)
+	}
+	_, _ = stdout.Write(output)
+}
+
+// isTerminal reports whether w writes to a terminal
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
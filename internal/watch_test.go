@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a bytes.Buffer that can be read while a watched command writes to it
+type syncBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.String()
+}
+
+func copyWatchFiles(t *testing.T, files ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join("../data/watch", file))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), data, 0o600))
+	}
+	return dir
+}
+
+// startWatch runs a command in watch mode until the test ends, and returns its stdout and stderr
+func startWatch(t *testing.T, args ...string) (*syncBuffer, *syncBuffer) {
+	t.Helper()
+
+	saved := watchInterval
+	watchInterval = 20 * time.Millisecond
+	t.Cleanup(func() { watchInterval = saved })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan int)
+	t.Cleanup(func() {
+		cancel()
+		require.Zero(t, <-done)
+	})
+
+	var stdout, stderr syncBuffer
+	go func() {
+		done <- runWithContext(ctx, append([]string{"oasdiff"}, args...), &stdout, &stderr)
+	}()
+	return &stdout, &stderr
+}
+
+func waitForOutput(t *testing.T, buffer *syncBuffer, text string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		return strings.Contains(buffer.String(), text)
+	}, 10*time.Second, 10*time.Millisecond, "missing %q in %q", text, buffer.String())
+}
+
+func TestWatch_ReferencedFile(t *testing.T) {
+	dir := copyWatchFiles(t, "revision.yaml", "schemas.yaml")
+	stdout, stderr := startWatch(t, "breaking", "../data/watch/base.yaml", filepath.Join(dir, "revision.yaml"), "--watch", "--format", "singleline")
+
+	waitForOutput(t, stderr, "Watching 2 file(s) for changes")
+	require.Contains(t, stdout.String(), "No breaking changes")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas.yaml"), []byte("Pet:\n  type: object\n  properties:\n    name:\n      type: integer\n"), 0o600))
+	waitForOutput(t, stdout, "response-property-type-changed")
+}
+
+func TestWatch_LoadError(t *testing.T) {
+	dir := copyWatchFiles(t, "revision.yaml", "schemas.yaml")
+	revision := filepath.Join(dir, "revision.yaml")
+	_, stderr := startWatch(t, "validate", revision, "--watch")
+
+	waitForOutput(t, stderr, "Watching 2 file(s) for changes")
+
+	require.NoError(t, os.WriteFile(revision, []byte("openapi: [\n"), 0o600))
+	waitForOutput(t, stderr, "Error: failed to load original spec")
+	waitForOutput(t, stderr, "Watching 2 file(s) for changes")
+}
+
+func TestWatch_NotLocalFile(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Equal(t, 101, Run([]string{"oasdiff", "breaking", "../data/watch/base.yaml", "HEAD:data/watch/revision.yaml", "--watch"}, &bytes.Buffer{}, &stderr))
+	require.Contains(t, stderr.String(), "--watch requires the watched spec to be a local file")
+}
+
+func TestWatch_BaseCache(t *testing.T) {
+	state := newWatchState("revision.yaml")
+	loads := 0
+	newSpecInfo := func() (*load.SpecInfo, error) {
+		loads++
+		return &load.SpecInfo{}, nil
+	}
+
+	for range 2 {
+		_, err := state.loadBase(load.NewSource("HEAD~1:openapi.yaml"), newSpecInfo)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, loads)
+
+	for range 2 {
+		_, err := state.loadBase(load.NewSource("openapi.yaml"), newSpecInfo)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, loads)
+}
+
+func TestWatch_ExternalRefsDisallowed(t *testing.T) {
+	dir := copyWatchFiles(t, "revision.yaml", "schemas.yaml")
+	_, stderr := startWatch(t, "validate", filepath.Join(dir, "revision.yaml"), "--watch", "--allow-external-refs=false")
+
+	waitForOutput(t, stderr, "encountered disallowed external reference")
+}
@@ -0,0 +1,40 @@
+package load
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/asyncapi"
+)
+
+// asyncAPIExtension carries a parsed AsyncAPI document from the loader to
+// newSpecInfo, which moves it to SpecInfo.AsyncAPI
+const asyncAPIExtension = "x-oasdiff-asyncapi"
+
+// loadAsyncAPI parses an AsyncAPI document into an OpenAPI spec without paths,
+// which holds the document until newSpecInfo takes it
+func loadAsyncAPI(data []byte) (*openapi3.T, error) {
+	doc, err := asyncapi.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &openapi3.T{
+		OpenAPI:    "3.1.0",
+		Info:       doc.Info,
+		Paths:      openapi3.NewPaths(),
+		Extensions: map[string]any{asyncAPIExtension: doc},
+	}, nil
+}
+
+// takeAsyncAPI removes the AsyncAPI document from a spec loaded by loadAsyncAPI
+// and returns it, or nil for an OpenAPI spec
+func takeAsyncAPI(spec *openapi3.T) *asyncapi.Document {
+	if spec == nil {
+		return nil
+	}
+	doc, ok := spec.Extensions[asyncAPIExtension].(*asyncapi.Document)
+	if !ok {
+		return nil
+	}
+	delete(spec.Extensions, asyncAPIExtension)
+	return doc
+}
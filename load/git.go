@@ -13,7 +13,7 @@ import (
 )
 
 // loadFromGitRevision loads an OpenAPI spec from a git revision reference (e.g. "origin/main:openapi.yaml").
-// It runs "git show <ref>" to obtain the content and loads it via LoadFromDataWithPath so that
+// It runs "git show <ref>" to obtain the content and loads it via loadDataWithPath so that
 // relative $refs are resolved against the spec's path.
 //
 // Relative $refs (e.g. "./schemas/pet.yaml") are resolved by kin-openapi; we install a
@@ -93,7 +93,13 @@ func loadFromGitRevision(loader *openapi3.Loader, gitRef string, fetch bool, cap
 		capture.record(u.String(), out)
 	}
 
-	t, err := loaderCopy.LoadFromDataWithPath(out, u)
+	// outermost, so the capture above records the files as they are
+	if loaderCopy.IncludeOrigin {
+		loaderCopy.ReadFromURIFunc = jsonOriginReader(loaderCopy.ReadFromURIFunc)
+		out = withJSONOrigins(out)
+	}
+
+	t, err := loadDataWithPath(&loaderCopy, out, u)
 	if err != nil && blockedRef != "" {
 		// Return the typed error even if kin-openapi wrapped ours in plain text,
 		// so callers can errors.As it to a dedicated exit code.
@@ -263,3 +269,111 @@ func gitShow(ref string) ([]byte, error) {
 	}
 	return out, nil
 }
+
+// GitTags returns the tags that match pattern (a git glob such as "v*"), oldest
+// version first, as sorted by "git tag --sort=version:refname": v1.9 comes
+// before v1.10. Like the revisions it reads, the tags are local ones only.
+func GitTags(pattern string) ([]string, error) {
+	if err := checkRef(pattern); err != nil {
+		return nil, err
+	}
+	out, err := gitOutput("tag", "--list", "--sort=version:refname", "--end-of-options", pattern)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+// LatestGitTag returns the most recent tag matching pattern (a git glob such as
+// "v*") that is reachable from rev, as found by "git describe". With fetch, the
+// tags are fetched from "origin" and the lookup retried when none is found, e.g.
+// in a CI checkout that cloned without tags.
+func LatestGitTag(pattern, rev string, fetch bool) (string, error) {
+	if err := checkRef(rev); err != nil {
+		return "", err
+	}
+	describe := func() (string, error) {
+		return gitOutput("describe", "--tags", "--abbrev=0", "--match="+pattern, "--end-of-options", rev)
+	}
+
+	tag, err := describe()
+	if err != nil && fetch {
+		if _, ferr := gitOutput("fetch", "--tags", "origin"); ferr != nil {
+			return "", fmt.Errorf("failed to fetch tags from origin: %w\n\noriginal error: %v", ferr, err)
+		}
+		tag, err = describe()
+	}
+	if err != nil {
+		return "", fmt.Errorf("no tag matching %q is reachable from %q: %w", pattern, rev, err)
+	}
+	return tag, nil
+}
+
+// GitMergeBase returns the commit where rev forked from branch, as found by
+// "git merge-base". With fetch, a branch that isn't in the local clone is
+// fetched from "origin" (dropping an "origin/" prefix) and the lookup retried.
+func GitMergeBase(branch, rev string, fetch bool) (string, error) {
+	if err := checkRef(branch); err != nil {
+		return "", err
+	}
+	if err := checkRef(rev); err != nil {
+		return "", err
+	}
+	mergeBase := func() (string, error) {
+		return gitOutput("merge-base", "--end-of-options", branch, rev)
+	}
+
+	commit, err := mergeBase()
+	if err != nil && fetch && !commitExistsLocally(branch) {
+		if ferr := gitFetch(strings.TrimPrefix(branch, "origin/")); ferr != nil {
+			return "", fmt.Errorf("failed to fetch %q from origin: %w\n\noriginal error: %v", branch, ferr, err)
+		}
+		commit, err = mergeBase()
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find the merge base of %q and %q: %w", branch, rev, err)
+	}
+	return commit, nil
+}
+
+// GitRepoPath returns the path of a local file relative to the root of the git
+// repository that contains it, in the form "<rev>:<path>" revisions expect.
+func GitRepoPath(file string) (string, error) {
+	root, err := gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	// resolve symlinks on both sides, e.g. a temp dir under /var on macOS is
+	// /private/var to git
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is not in the git repository at %s", file, root)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// gitOutput runs git with the given arguments and returns its trimmed stdout,
+// or an error carrying git's stderr.
+func gitOutput(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("is git installed and in PATH?: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
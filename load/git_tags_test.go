@@ -0,0 +1,106 @@
+//go:build unix
+
+package load_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGitTags verifies that tags are filtered by the pattern and sorted by
+// version rather than alphabetically, and that each one loads as a revision.
+func TestGitTags(t *testing.T) {
+	dir := t.TempDir()
+
+	gitRun(t, dir, "git", "init")
+	gitRun(t, dir, "git", "config", "user.email", "test@test.com")
+	gitRun(t, dir, "git", "config", "user.name", "Test")
+
+	specPath := filepath.Join(dir, "openapi.yaml")
+	for _, tag := range []string{"v1.10", "v1.9", "v1.2", "other"} {
+		require.NoError(t, os.WriteFile(specPath, []byte("openapi: 3.0.0\ninfo:\n  title: Test\n  version: "+tag+"\npaths: {}\n"), 0644))
+		gitRun(t, dir, "git", "add", "openapi.yaml")
+		gitRun(t, dir, "git", "commit", "-m", tag)
+		gitRun(t, dir, "git", "tag", tag)
+	}
+
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer os.Chdir(oldDir) //nolint:errcheck
+
+	tags, err := load.GitTags("v*")
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.2", "v1.9", "v1.10"}, tags)
+
+	specInfo, err := load.NewSpecInfo(openapi3.NewLoader(), load.NewSource(tags[2]+":openapi.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, "v1.10", specInfo.GetVersion())
+
+	tags, err = load.GitTags("release-*")
+	require.NoError(t, err)
+	require.Empty(t, tags)
+}
+
+func TestGitTagsRejectsOptionLikePattern(t *testing.T) {
+	_, err := load.GitTags("--output=/tmp/x")
+	require.ErrorIs(t, err, load.ErrRefLooksLikeOption)
+}
+
+// TestGitBase verifies the refs picked for --base-from-latest-tag and
+// --base-merge-base, and the repository path of a file in a subdirectory.
+func TestGitBase(t *testing.T) {
+	dir := t.TempDir()
+
+	gitRun(t, dir, "git", "init", "--initial-branch=main")
+	gitRun(t, dir, "git", "config", "user.email", "test@test.com")
+	gitRun(t, dir, "git", "config", "user.name", "Test")
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "api"), 0755))
+	specPath := filepath.Join(dir, "api", "openapi.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(specV1), 0644))
+	gitRun(t, dir, "git", "add", ".")
+	gitRun(t, dir, "git", "commit", "-m", "v1")
+	gitRun(t, dir, "git", "tag", "v1.0")
+	forkPoint := gitRun(t, dir, "git", "rev-parse", "HEAD")
+
+	gitRun(t, dir, "git", "checkout", "-b", "feature")
+	require.NoError(t, os.WriteFile(specPath, []byte(specV2), 0644))
+	gitRun(t, dir, "git", "commit", "-am", "v2")
+	gitRun(t, dir, "git", "tag", "other")
+
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Join(dir, "api")))
+	defer os.Chdir(oldDir) //nolint:errcheck
+
+	tag, err := load.LatestGitTag("v*", "HEAD", false)
+	require.NoError(t, err)
+	require.Equal(t, "v1.0", tag)
+
+	tag, err = load.LatestGitTag("*", "HEAD", false)
+	require.NoError(t, err)
+	require.Equal(t, "other", tag)
+
+	_, err = load.LatestGitTag("release-*", "HEAD", false)
+	require.Error(t, err)
+
+	commit, err := load.GitMergeBase("main", "HEAD", false)
+	require.NoError(t, err)
+	require.Equal(t, forkPoint, commit)
+
+	_, err = load.GitMergeBase("no-such-branch", "HEAD", false)
+	require.Error(t, err)
+
+	path, err := load.GitRepoPath("openapi.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "api/openapi.yaml", path)
+
+	_, err = load.GitRepoPath(filepath.Join(oldDir, "git.go"))
+	require.Error(t, err)
+}
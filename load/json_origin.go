@@ -0,0 +1,120 @@
+package load
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"go.yaml.in/yaml/v3"
+)
+
+// kin-openapi decodes a document with encoding/json first and falls back to
+// YAML only when that fails, and only the YAML decoder records origins, so a
+// JSON spec loads without source locations. JSON is YAML's flow style though:
+// a JSON document followed by a YAML comment is no longer valid JSON, but is
+// still valid YAML that decodes to the same values, at the same lines and
+// columns. Appending jsonOriginSuffix sends a JSON document down kin's YAML
+// path so that its elements get origins like a YAML spec's do.
+const jsonOriginSuffix = "\n# oasdiff: decoded as YAML to track source locations\n"
+
+// withJSONOrigins returns data with jsonOriginSuffix appended if it is a JSON
+// document that the YAML decoder accepts too, and data unchanged otherwise.
+// Some valid JSON is not valid YAML, e.g. duplicate keys or keys longer than
+// 1024 characters; such a document keeps loading as JSON, without origins.
+func withJSONOrigins(data []byte) []byte {
+	if !isJSONObject(data) {
+		return data
+	}
+
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return data
+	}
+
+	return slices.Concat(data, []byte(jsonOriginSuffix))
+}
+
+func isJSONObject(data []byte) bool {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '{' && json.Valid(trimmed)
+}
+
+// withJSONOriginReader returns a copy of the loader that applies
+// withJSONOrigins to every $ref'd document it reads, or the loader itself when
+// it doesn't include origins or doesn't allow external refs. In the latter
+// case no document is read anyway, and installing a ReadFromURIFunc would turn
+// off kin's own enforcement of IsExternalRefsAllowed.
+func withJSONOriginReader(loader *openapi3.Loader) *openapi3.Loader {
+	if !loader.IncludeOrigin || !loader.IsExternalRefsAllowed {
+		return loader
+	}
+
+	lc := *loader
+	lc.ReadFromURIFunc = jsonOriginReader(lc.ReadFromURIFunc)
+	return &lc
+}
+
+// jsonOriginReader wraps a ReadFromURIFunc (or kin's default when inner is nil)
+// to apply withJSONOrigins to what it reads. It leaves access policy to inner.
+func jsonOriginReader(inner openapi3.ReadFromURIFunc) openapi3.ReadFromURIFunc {
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		data, err := readURI(inner, loader, location)
+		if err != nil {
+			return nil, err
+		}
+		return withJSONOrigins(data), nil
+	}
+}
+
+func readURI(inner openapi3.ReadFromURIFunc, loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	if inner != nil {
+		return inner(loader, location)
+	}
+	return openapi3.DefaultReadFromURI(loader, location)
+}
+
+// loadFromURI is loader.LoadFromURI that also tracks source locations in JSON
+// documents when the loader includes origins, and converts Swagger 2.0
+// documents to OpenAPI 3.
+func loadFromURI(loader *openapi3.Loader, location *url.URL) (*openapi3.T, error) {
+	// the root is read here rather than by kin, so it is read even when
+	// external refs are disallowed, like loader.LoadFromURI reads it
+	data, err := readURI(loader.ReadFromURIFunc, loader, location)
+	if err != nil {
+		return nil, err
+	}
+	return loadFromDataWithPath(loader, data, location)
+}
+
+// loadFromFile is loader.LoadFromFile that also tracks source locations in JSON
+// documents when the loader includes origins, and converts Swagger 2.0
+// documents to OpenAPI 3.
+func loadFromFile(loader *openapi3.Loader, path string) (*openapi3.T, error) {
+	return loadFromURI(loader, &url.URL{Path: filepath.ToSlash(path)})
+}
+
+// loadFromDataWithPath is loader.LoadFromDataWithPath that also tracks source
+// locations in JSON documents when the loader includes origins, and converts
+// Swagger 2.0 documents to OpenAPI 3.
+func loadFromDataWithPath(loader *openapi3.Loader, data []byte, location *url.URL) (*openapi3.T, error) {
+	if !loader.IncludeOrigin {
+		return loadDataWithPath(loader, data, location)
+	}
+	return loadDataWithPath(withJSONOriginReader(loader), withJSONOrigins(data), location)
+}
+
+// loadFromStdin is loader.LoadFromStdin that also tracks source locations in
+// JSON documents when the loader includes origins, and converts Swagger 2.0
+// documents to OpenAPI 3.
+func loadFromStdin(loader *openapi3.Loader) (*openapi3.T, error) {
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	return loadFromDataWithPath(loader, data, nil)
+}
@@ -0,0 +1,85 @@
+package load
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func newOriginLoader() *openapi3.Loader {
+	loader := openapi3.NewLoader()
+	loader.IncludeOrigin = true
+	loader.IsExternalRefsAllowed = true
+	return loader
+}
+
+func TestJSONOrigins_File(t *testing.T) {
+	specInfo, err := NewSpecInfo(newOriginLoader(), NewSource("../data/json-origin/openapi.json"))
+	require.NoError(t, err)
+
+	response := specInfo.Spec.Paths.Find("/users").Get.Responses.Value("200").Value
+	require.NotNil(t, response.Origin)
+	require.Equal(t, 11, response.Origin.Key.Line)
+	require.Equal(t, 11, response.Origin.Key.Column)
+
+	// the $ref'd JSON file is located too, in its own file
+	property := response.Content.Get("application/json").Schema.Value.Properties["name"].Value
+	require.NotNil(t, property.Origin)
+	require.Equal(t, 10, property.Origin.Key.Line)
+	require.Contains(t, property.Origin.Key.File, "schemas.json")
+}
+
+func TestJSONOrigins_Data(t *testing.T) {
+	data, err := os.ReadFile("../data/json-origin/schemas.json")
+	require.NoError(t, err)
+
+	specInfo, err := NewSpecInfoFromData(newOriginLoader(), data, "schemas.json")
+	require.NoError(t, err)
+
+	user := specInfo.Spec.Components.Schemas["User"].Value
+	require.NotNil(t, user.Origin)
+	require.Equal(t, 7, user.Origin.Key.Line)
+}
+
+func TestJSONOrigins_NotIncluded(t *testing.T) {
+	loader := newOriginLoader()
+	loader.IncludeOrigin = false
+
+	specInfo, err := NewSpecInfo(loader, NewSource("../data/json-origin/openapi.json"))
+	require.NoError(t, err)
+	require.Nil(t, specInfo.Spec.Paths.Find("/users").Get.Responses.Value("200").Value.Origin)
+}
+
+func TestJSONOrigins_Capture(t *testing.T) {
+	specInfo, err := NewSpecInfoWithCapture(newOriginLoader(), NewSource("../data/json-origin/openapi.json"))
+	require.NoError(t, err)
+
+	// captured files are kept as read, without the suffix
+	require.Len(t, specInfo.Sources, 2)
+	for _, text := range specInfo.Sources {
+		require.NotContains(t, text, jsonOriginSuffix)
+	}
+}
+
+func TestWithJSONOrigins(t *testing.T) {
+	require.Equal(t, `{"a": 1}`+jsonOriginSuffix, string(withJSONOrigins([]byte(`{"a": 1}`))))
+
+	// not JSON
+	require.Equal(t, "a: 1\n", string(withJSONOrigins([]byte("a: 1\n"))))
+	require.Equal(t, `{"a": 1`, string(withJSONOrigins([]byte(`{"a": 1`))))
+
+	// JSON that isn't YAML keeps loading as JSON
+	require.Equal(t, `{"a": 1, "a": 2}`, string(withJSONOrigins([]byte(`{"a": 1, "a": 2}`))))
+}
+
+func TestJSONOrigins_DuplicateKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"openapi": "3.0.0", "info": {"title": "t", "version": "1", "version": "2"}, "paths": {}}`), 0o600))
+
+	specInfo, err := NewSpecInfo(newOriginLoader(), NewSource(path))
+	require.NoError(t, err)
+	require.Equal(t, "2", specInfo.Version)
+}
@@ -15,13 +15,13 @@ import (
 func from(loader *openapi3.Loader, source *Source, capture *sourceCapture) (*openapi3.T, error) {
 	switch source.Type {
 	case SourceTypeStdin:
-		return loader.LoadFromStdin()
+		return loadFromStdin(loader)
 	case SourceTypeURL:
-		return withCapture(loader, capture).LoadFromURI(source.Uri)
+		return loadFromURI(withCapture(loader, capture), source.Uri)
 	case SourceTypeGitRevision:
 		return loadFromGitRevision(loader, source.Path, source.Fetch, capture)
 	default:
-		return withCapture(loader, capture).LoadFromFile(source.Path)
+		return loadFromFile(withCapture(loader, capture), source.Path)
 	}
 }
 
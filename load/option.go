@@ -5,6 +5,7 @@ import (
 	"github.com/oasdiff/oasdiff/flatten/allof"
 	"github.com/oasdiff/oasdiff/flatten/commonparams"
 	"github.com/oasdiff/oasdiff/flatten/headers"
+	"github.com/oasdiff/oasdiff/flatten/normalize"
 )
 
 // Option functions can be used to preprocess specs after loading them
@@ -53,3 +54,13 @@ func WithLowercaseHeaders() Option {
 		return specInfos, nil
 	}
 }
+
+// WithNormalize returns SpecInfos with schemas rewritten into a canonical form
+func WithNormalize() Option {
+	return func(loader *openapi3.Loader, specInfos []*SpecInfo) ([]*SpecInfo, error) {
+		for _, specInfo := range specInfos {
+			normalize.Spec(specInfo.Spec)
+		}
+		return specInfos, nil
+	}
+}
@@ -0,0 +1,93 @@
+package load
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// SchemaPath and SchemaMethod are the endpoint of the spec that NewSpecInfoFromSchema wraps a schema in
+	SchemaPath   = "/"
+	SchemaMethod = "POST"
+	// SchemaMediaType and SchemaStatus are the media type and response status that carry the schema
+	SchemaMediaType = "application/json"
+	SchemaStatus    = "200"
+)
+
+// NewSpecInfoFromSchema loads a standalone JSON Schema document from a file or a
+// URL and wraps it in an OpenAPI 3.1 spec with a single endpoint, POST /, so that
+// it can be compared like a spec: as the endpoint's request body when asRequest is
+// true, or as the body of its 200 response otherwise.
+//
+// The schema is loaded as an external $ref of the spec, so that its own $refs,
+// e.g. to its $defs, are resolved relative to its location; the loader must allow
+// external refs.
+func NewSpecInfoFromSchema(loader *openapi3.Loader, source *Source, asRequest bool) (*SpecInfo, error) {
+	var location *url.URL
+	var ref string
+
+	switch source.Type {
+	case SourceTypeFile:
+		abs, err := filepath.Abs(source.Path)
+		if err != nil {
+			return nil, err
+		}
+		dir, file := filepath.Split(filepath.ToSlash(abs))
+		location = &url.URL{Path: dir}
+		ref = file
+	case SourceTypeURL:
+		location = source.Uri
+		ref = source.Uri.String()
+	default:
+		return nil, fmt.Errorf("a JSON Schema document can only be loaded from a file or a URL")
+	}
+
+	content := map[string]any{
+		SchemaMediaType: map[string]any{
+			"schema": map[string]any{"$ref": ref},
+		},
+	}
+
+	operation := map[string]any{
+		"responses": map[string]any{
+			"default": map[string]any{"description": ""},
+		},
+	}
+	if asRequest {
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content":  content,
+		}
+	} else {
+		operation["responses"] = map[string]any{
+			SchemaStatus: map[string]any{
+				"description": "",
+				"content":     content,
+			},
+		}
+	}
+
+	data, err := json.Marshal(map[string]any{
+		"openapi": "3.1.0",
+		"info":    map[string]any{"title": source.String(), "version": ""},
+		"paths": map[string]any{
+			SchemaPath: map[string]any{
+				"post": operation,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := loader.LoadFromDataWithPath(data, location)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSpecInfo(spec, source.Path), nil
+}
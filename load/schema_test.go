@@ -0,0 +1,51 @@
+package load_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSpecInfoFromSchema_Request(t *testing.T) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+
+	specInfo, err := load.NewSpecInfoFromSchema(loader, load.NewSource("../data/json-schema/base.json"), true)
+	require.NoError(t, err)
+	require.Equal(t, "../data/json-schema/base.json", specInfo.Url)
+
+	requestBody := specInfo.Spec.Paths.Find(load.SchemaPath).Post.RequestBody.Value
+	require.True(t, requestBody.Required)
+	schema := requestBody.Content.Get(load.SchemaMediaType).Schema.Value
+	require.Equal(t, "Order", schema.Title)
+
+	// $refs to $defs are resolved relative to the schema document
+	require.Contains(t, schema.Properties["address"].Value.Properties, "zip")
+}
+
+func TestNewSpecInfoFromSchema_Response(t *testing.T) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+
+	specInfo, err := load.NewSpecInfoFromSchema(loader, load.NewSource("../data/json-schema/base.json"), false)
+	require.NoError(t, err)
+
+	operation := specInfo.Spec.Paths.Find(load.SchemaPath).Post
+	require.Nil(t, operation.RequestBody)
+	require.Equal(t, "Order", operation.Responses.Status(200).Value.Content.Get(load.SchemaMediaType).Schema.Value.Title)
+}
+
+func TestNewSpecInfoFromSchema_Stdin(t *testing.T) {
+	_, err := load.NewSpecInfoFromSchema(openapi3.NewLoader(), load.NewSource("-"), true)
+	require.EqualError(t, err, "a JSON Schema document can only be loaded from a file or a URL")
+}
+
+func TestNewSpecInfoFromSchema_NotFound(t *testing.T) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+
+	_, err := load.NewSpecInfoFromSchema(loader, load.NewSource("../data/json-schema/missing.json"), true)
+	require.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"net/url"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/asyncapi"
 	"github.com/yargevad/filepathx"
 )
 
@@ -17,6 +18,9 @@ type SpecInfo struct {
 	// Sources holds the raw text of every file that contributed to Spec, keyed
 	// by resolved path, when loaded via NewSpecInfoWithCapture; nil otherwise.
 	Sources map[string]string
+	// AsyncAPI is the document when the spec is an AsyncAPI document, in which
+	// case Spec only holds its info; nil otherwise.
+	AsyncAPI *asyncapi.Document
 }
 
 func (specInfo *SpecInfo) GetVersion() string {
@@ -28,9 +32,10 @@ func (specInfo *SpecInfo) GetVersion() string {
 
 func newSpecInfo(spec *openapi3.T, path string) *SpecInfo {
 	return &SpecInfo{
-		Spec:    spec,
-		Url:     path,
-		Version: getVersion(spec),
+		Spec:     spec,
+		Url:      path,
+		Version:  getVersion(spec),
+		AsyncAPI: takeAsyncAPI(spec),
 	}
 }
 
@@ -114,7 +119,7 @@ func newSpecInfoFromGlob(loader *openapi3.Loader, glob string, capture bool, opt
 // comparing two specs should use a fresh loader per spec so the loader's
 // document cache does not collide when both share a name.
 func NewSpecInfoFromData(loader *openapi3.Loader, data []byte, name string, options ...Option) (*SpecInfo, error) {
-	spec, err := loader.LoadFromDataWithPath(data, &url.URL{Path: name})
+	spec, err := loadFromDataWithPath(loader, data, &url.URL{Path: name})
 	if err != nil {
 		return nil, err
 	}
@@ -155,7 +160,7 @@ func fromGlob(loader *openapi3.Loader, glob string, capture bool) ([]*SpecInfo,
 			cap = newSourceCapture()
 			l = withCapture(loader, cap)
 		}
-		spec, err := l.LoadFromFile(file)
+		spec, err := loadFromFile(l, file)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load %q: %w", file, err)
 		}
@@ -0,0 +1,349 @@
+package load
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/asyncapi"
+	"github.com/oasdiff/yaml"
+	yaml3 "github.com/oasdiff/yaml3"
+)
+
+// isSwagger2 reports whether data is a Swagger 2.0 document, that is, a
+// mapping whose swagger field is "2.0".
+func isSwagger2(data []byte) bool {
+	var probe struct {
+		Swagger any `json:"swagger"`
+	}
+	if _, err := yaml.Unmarshal(data, &probe, yaml.DecodeOpts{DisableTimestamps: true}); err != nil {
+		return false
+	}
+	swagger, ok := probe.Swagger.(string)
+	return ok && swagger == "2.0"
+}
+
+// loadDataWithPath is loader.LoadFromDataWithPath that converts a Swagger 2.0
+// document to OpenAPI 3 first, and parses an AsyncAPI document with
+// loadAsyncAPI. location may be nil for data without a path, such as standard
+// input.
+func loadDataWithPath(loader *openapi3.Loader, data []byte, location *url.URL) (*openapi3.T, error) {
+	if asyncapi.IsAsyncAPI(data) {
+		return loadAsyncAPI(data)
+	}
+	if isSwagger2(data) {
+		return loadSwagger2(loader, data, location)
+	}
+	if location == nil {
+		return loader.LoadFromData(data)
+	}
+	return loader.LoadFromDataWithPath(data, location)
+}
+
+// loadSwagger2 converts a Swagger 2.0 document to OpenAPI 3. kin-openapi
+// doesn't track source locations in Swagger 2.0 documents, so when the loader
+// includes origins, they are set here from the document's YAML nodes: each
+// element of the converted spec gets the location of the element it was
+// converted from.
+func loadSwagger2(loader *openapi3.Loader, data []byte, location *url.URL) (*openapi3.T, error) {
+	var doc2 openapi2.T
+	if _, err := yaml.Unmarshal(data, &doc2, yaml.DecodeOpts{DisableTimestamps: true}); err != nil {
+		return nil, err
+	}
+
+	doc3, err := openapi2conv.ToV3WithLoader(&doc2, loader, location)
+	if err != nil {
+		return nil, err
+	}
+
+	if loader.IncludeOrigin {
+		var root yaml3.Node
+		if err := yaml3.Unmarshal(data, &root); err == nil && len(root.Content) > 0 {
+			file := ""
+			if location != nil {
+				file = location.String()
+			}
+			swagger2Origins{file: file}.setDocument(doc3, root.Content[0])
+		}
+	}
+
+	return doc3, nil
+}
+
+// swagger2Origins sets the origins of a converted spec's elements from the YAML
+// nodes of the Swagger 2.0 document, the way kin-openapi sets them from an
+// OpenAPI 3 document's nodes.
+type swagger2Origins struct {
+	file string
+}
+
+func (o swagger2Origins) setDocument(doc *openapi3.T, root *yaml3.Node) {
+	if doc.Paths != nil {
+		forEachEntry(child(root, "paths"), func(key, value *yaml3.Node) {
+			if pathItem := doc.Paths.Value(key.Value); pathItem != nil {
+				o.setPathItem(pathItem, key, value)
+			}
+		})
+	}
+
+	if doc.Components == nil {
+		return
+	}
+	forEachEntry(child(root, "definitions"), func(key, value *yaml3.Node) {
+		o.setSchema(doc.Components.Schemas[key.Value], key, value)
+	})
+	forEachEntry(child(root, "parameters"), func(key, value *yaml3.Node) {
+		if parameter := doc.Components.Parameters[key.Value]; parameter != nil {
+			o.setParameter(parameter, key, value)
+		} else if requestBody := doc.Components.RequestBodies[key.Value]; requestBody != nil && requestBody.Value != nil {
+			requestBody.Value.Origin = o.origin(key, value)
+		}
+	})
+	forEachEntry(child(root, "responses"), func(key, value *yaml3.Node) {
+		o.setResponse(doc.Components.Responses[key.Value], key, value)
+	})
+	forEachEntry(child(root, "securityDefinitions"), func(key, value *yaml3.Node) {
+		if scheme := doc.Components.SecuritySchemes[key.Value]; scheme != nil && scheme.Value != nil {
+			scheme.Value.Origin = o.origin(key, value)
+		}
+	})
+}
+
+func (o swagger2Origins) setPathItem(pathItem *openapi3.PathItem, key, value *yaml3.Node) {
+	pathItem.Origin = o.origin(key, value)
+	o.setParameters(pathItem.Parameters, child(value, "parameters"))
+
+	forEachEntry(value, func(key, value *yaml3.Node) {
+		operation := pathItem.GetOperation(strings.ToUpper(key.Value))
+		if operation == nil || key.Value == "parameters" {
+			return
+		}
+		operation.Origin = o.origin(key, value)
+		o.setParameters(operation.Parameters, child(value, "parameters"))
+		o.setRequestBody(operation.RequestBody, child(value, "parameters"))
+		if operation.Responses != nil {
+			forEachEntry(child(value, "responses"), func(key, value *yaml3.Node) {
+				o.setResponse(operation.Responses.Value(key.Value), key, value)
+			})
+		}
+	})
+}
+
+// setParameters sets the origins of the parameters converted from the
+// sequence's non-body parameters, matched by name and location.
+func (o swagger2Origins) setParameters(parameters openapi3.Parameters, sequence *yaml3.Node) {
+	for _, item := range items(sequence) {
+		in, name := scalar(item, "in"), scalar(item, "name")
+		if in == "body" || in == "formData" {
+			continue
+		}
+		if parameter := findParameter(parameters, in, name); parameter != nil {
+			o.setParameter(parameter, item.Content[0], item)
+		}
+	}
+}
+
+func findParameter(parameters openapi3.Parameters, in, name string) *openapi3.ParameterRef {
+	for _, parameter := range parameters {
+		if parameter != nil && parameter.Value != nil && parameter.Value.In == in && parameter.Value.Name == name {
+			return parameter
+		}
+	}
+	return nil
+}
+
+// setParameter sets the origin of a parameter and of its schema: in Swagger 2.0
+// a non-body parameter holds its schema's fields, such as type and enum.
+func (o swagger2Origins) setParameter(parameter *openapi3.ParameterRef, key, value *yaml3.Node) {
+	if parameter == nil || parameter.Value == nil || parameter.Ref != "" {
+		return
+	}
+	parameter.Value.Origin = o.origin(key, value)
+	if parameter.Value.Schema != nil && parameter.Value.Schema.Ref == "" && parameter.Value.Schema.Value != nil {
+		schema := parameter.Value.Schema.Value
+		schema.Origin = o.origin(key, value)
+		if items := child(value, "items"); items != nil && schema.Items != nil {
+			o.setSchema(schema.Items, childKey(value, "items"), items)
+		}
+	}
+}
+
+// setRequestBody sets the origin of a request body converted from the
+// sequence's body parameter or from its form parameters.
+func (o swagger2Origins) setRequestBody(requestBody *openapi3.RequestBodyRef, sequence *yaml3.Node) {
+	if requestBody == nil || requestBody.Ref != "" || requestBody.Value == nil {
+		return
+	}
+
+	for _, item := range items(sequence) {
+		switch scalar(item, "in") {
+		case "body":
+			requestBody.Value.Origin = o.origin(item.Content[0], item)
+			for _, mediaType := range requestBody.Value.Content {
+				o.setSchema(mediaType.Schema, childKey(item, "schema"), child(item, "schema"))
+			}
+		case "formData":
+			if requestBody.Value.Origin == nil {
+				requestBody.Value.Origin = o.origin(item.Content[0], item)
+			}
+			name := scalar(item, "name")
+			for _, mediaType := range requestBody.Value.Content {
+				if mediaType.Schema != nil && mediaType.Schema.Value != nil {
+					o.setSchema(mediaType.Schema.Value.Properties[name], item.Content[0], item)
+				}
+			}
+		}
+	}
+}
+
+func (o swagger2Origins) setResponse(response *openapi3.ResponseRef, key, value *yaml3.Node) {
+	if response == nil || response.Ref != "" || response.Value == nil {
+		return
+	}
+	response.Value.Origin = o.origin(key, value)
+	for _, mediaType := range response.Value.Content {
+		o.setSchema(mediaType.Schema, childKey(value, "schema"), child(value, "schema"))
+	}
+	forEachEntry(child(value, "headers"), func(key, value *yaml3.Node) {
+		if header := response.Value.Headers[key.Value]; header != nil && header.Ref == "" && header.Value != nil {
+			header.Value.Origin = o.origin(key, value)
+			if header.Value.Schema != nil && header.Value.Schema.Ref == "" && header.Value.Schema.Value != nil {
+				header.Value.Schema.Value.Origin = o.origin(key, value)
+			}
+		}
+	})
+}
+
+// setSchema sets the origins of a schema and of its subschemas. A $ref'd schema
+// gets its origin where it is defined.
+func (o swagger2Origins) setSchema(schemaRef *openapi3.SchemaRef, key, value *yaml3.Node) {
+	if schemaRef == nil || schemaRef.Ref != "" || schemaRef.Value == nil || value == nil || value.Kind != yaml3.MappingNode {
+		return
+	}
+	schema := schemaRef.Value
+	schema.Origin = o.origin(key, value)
+
+	forEachEntry(child(value, "properties"), func(key, value *yaml3.Node) {
+		o.setSchema(schema.Properties[key.Value], key, value)
+	})
+	if items := child(value, "items"); items != nil {
+		o.setSchema(schema.Items, childKey(value, "items"), items)
+	}
+	for i, item := range items(child(value, "allOf")) {
+		if i < len(schema.AllOf) && len(item.Content) > 0 {
+			o.setSchema(schema.AllOf[i], item.Content[0], item)
+		}
+	}
+	if additionalProperties := child(value, "additionalProperties"); additionalProperties != nil {
+		o.setSchema(schema.AdditionalProperties.Schema, childKey(value, "additionalProperties"), additionalProperties)
+	}
+}
+
+// origin is the origin of the mapping value under key, like the one kin-openapi
+// records: the key's location and the block's end, and the location of each
+// field and of each scalar item of a sequence field.
+func (o swagger2Origins) origin(key, value *yaml3.Node) *openapi3.Origin {
+	if key == nil || value == nil {
+		return nil
+	}
+
+	origin := &openapi3.Origin{
+		Key: &openapi3.Location{
+			File:   o.file,
+			Line:   key.Line,
+			Column: key.Column,
+			Name:   key.Value,
+		},
+	}
+	if value.EndLine > 0 {
+		origin.Key.EndLine = value.EndLine
+		origin.Key.EndColumn = value.EndColumn
+	}
+
+	forEachEntry(value, func(fieldKey, fieldValue *yaml3.Node) {
+		origin.Fields = append(origin.Fields, openapi3.Location{
+			File:   o.file,
+			Line:   fieldKey.Line,
+			Column: fieldKey.Column,
+			Name:   fieldKey.Value,
+		})
+		if fieldValue.Kind != yaml3.SequenceNode {
+			return
+		}
+		var locations []openapi3.Location
+		for _, item := range fieldValue.Content {
+			if item.Kind == yaml3.ScalarNode {
+				locations = append(locations, openapi3.Location{
+					File:   o.file,
+					Line:   item.Line,
+					Column: item.Column,
+					Name:   item.Value,
+				})
+			}
+		}
+		if len(locations) > 0 {
+			if origin.Sequences == nil {
+				origin.Sequences = map[string][]openapi3.Location{}
+			}
+			origin.Sequences[fieldKey.Value] = locations
+		}
+	})
+
+	return origin
+}
+
+// forEachEntry calls f with each key and value of a mapping node.
+func forEachEntry(node *yaml3.Node, f func(key, value *yaml3.Node)) {
+	if node == nil || node.Kind != yaml3.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		f(node.Content[i], node.Content[i+1])
+	}
+}
+
+// childKey returns the key node of a mapping's field, or nil.
+func childKey(node *yaml3.Node, name string) *yaml3.Node {
+	var result *yaml3.Node
+	forEachEntry(node, func(key, value *yaml3.Node) {
+		if result == nil && key.Value == name {
+			result = key
+		}
+	})
+	return result
+}
+
+// child returns the value node of a mapping's field, or nil.
+func child(node *yaml3.Node, name string) *yaml3.Node {
+	var result *yaml3.Node
+	forEachEntry(node, func(key, value *yaml3.Node) {
+		if result == nil && key.Value == name {
+			result = value
+		}
+	})
+	return result
+}
+
+// scalar returns the value of a mapping's scalar field, or "".
+func scalar(node *yaml3.Node, name string) string {
+	if value := child(node, name); value != nil && value.Kind == yaml3.ScalarNode {
+		return value.Value
+	}
+	return ""
+}
+
+// items returns the mapping items of a sequence node.
+func items(node *yaml3.Node) []*yaml3.Node {
+	if node == nil || node.Kind != yaml3.SequenceNode {
+		return nil
+	}
+	result := make([]*yaml3.Node, 0, len(node.Content))
+	for _, item := range node.Content {
+		if item.Kind == yaml3.MappingNode && len(item.Content) > 0 {
+			result = append(result, item)
+		}
+	}
+	return result
+}
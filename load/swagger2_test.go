@@ -0,0 +1,74 @@
+package load
+
+import (
+	"os"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSwagger2(t *testing.T) {
+	require.True(t, isSwagger2([]byte("swagger: \"2.0\"\ninfo: {}\n")))
+	require.True(t, isSwagger2([]byte(`{"swagger": "2.0", "info": {}}`+jsonOriginSuffix)))
+	require.False(t, isSwagger2([]byte("openapi: 3.0.3\n")))
+	require.False(t, isSwagger2([]byte("swagger: 2.0\n")))
+	require.False(t, isSwagger2([]byte("- swagger\n")))
+}
+
+func TestSwagger2_Convert(t *testing.T) {
+	specInfo, err := NewSpecInfo(openapi3.NewLoader(), NewSource("../data/swagger2/base.yaml"))
+	require.NoError(t, err)
+
+	spec := specInfo.Spec
+	require.Equal(t, "3.0.3", spec.OpenAPI)
+	require.Equal(t, "https://api.example.com/v1", spec.Servers[0].URL)
+	require.Contains(t, spec.Components.Schemas, "Pet")
+
+	limit := spec.Paths.Find("/pets").Get.Parameters.GetByInAndName("query", "limit")
+	require.NotNil(t, limit)
+	require.Equal(t, 100.0, *limit.Schema.Value.Max)
+
+	// the body parameter becomes a request body
+	requestBody := spec.Paths.Find("/pets").Post.RequestBody.Value
+	require.Equal(t, "#/components/schemas/Pet", requestBody.Content.Get("application/json").Schema.Ref)
+}
+
+func TestSwagger2_Origins(t *testing.T) {
+	specInfo, err := NewSpecInfo(newOriginLoader(), NewSource("../data/swagger2/base.yaml"))
+	require.NoError(t, err)
+
+	pets := specInfo.Spec.Paths.Find("/pets")
+	require.Equal(t, 15, pets.Get.Origin.Key.Line)
+	require.Equal(t, "../data/swagger2/base.yaml", pets.Get.Origin.Key.File)
+
+	// a parameter and its schema are located at the parameter
+	limit := pets.Get.Parameters.GetByInAndName("query", "limit")
+	require.Equal(t, 18, limit.Origin.Key.Line)
+	maximum, ok := limit.Schema.Value.Origin.Fields.Lookup("maximum")
+	require.True(t, ok)
+	require.Equal(t, 21, maximum.Line)
+
+	status := pets.Get.Parameters.GetByInAndName("query", "status")
+	require.Equal(t, 27, status.Schema.Value.Origin.Sequences["enum"][1].Line)
+
+	require.Equal(t, 29, pets.Get.Responses.Value("200").Value.Origin.Key.Line)
+
+	// definitions are located as components
+	pet := specInfo.Spec.Components.Schemas["Pet"].Value
+	require.Equal(t, 65, pet.Origin.Key.Line)
+	require.Equal(t, 72, pet.Properties["tag"].Value.Origin.Key.Line)
+}
+
+func TestSwagger2_JSON(t *testing.T) {
+	data, err := os.ReadFile("../data/swagger2/base.json")
+	require.NoError(t, err)
+
+	specInfo, err := NewSpecInfoFromData(newOriginLoader(), data, "base.json")
+	require.NoError(t, err)
+
+	get := specInfo.Spec.Paths.Find("/pets").Get
+	require.Equal(t, "listPets", get.OperationID)
+	require.NotNil(t, get.Origin)
+	require.Equal(t, 8, get.Origin.Key.Line)
+}
@@ -0,0 +1,160 @@
+package protobuf
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Label is the cardinality of a field
+type Label int32
+
+// Labels of FieldDescriptorProto
+const (
+	LabelOptional Label = 1
+	LabelRequired Label = 2
+	LabelRepeated Label = 3
+)
+
+// Type is the type of a field
+type Type int32
+
+// Types of FieldDescriptorProto
+const (
+	TypeDouble   Type = 1
+	TypeFloat    Type = 2
+	TypeInt64    Type = 3
+	TypeUint64   Type = 4
+	TypeInt32    Type = 5
+	TypeFixed64  Type = 6
+	TypeFixed32  Type = 7
+	TypeBool     Type = 8
+	TypeString   Type = 9
+	TypeGroup    Type = 10
+	TypeMessage  Type = 11
+	TypeBytes    Type = 12
+	TypeUint32   Type = 13
+	TypeEnum     Type = 14
+	TypeSfixed32 Type = 15
+	TypeSfixed64 Type = 16
+	TypeSint32   Type = 17
+	TypeSint64   Type = 18
+)
+
+var typeNames = map[Type]string{
+	TypeDouble:   "double",
+	TypeFloat:    "float",
+	TypeInt64:    "int64",
+	TypeUint64:   "uint64",
+	TypeInt32:    "int32",
+	TypeFixed64:  "fixed64",
+	TypeFixed32:  "fixed32",
+	TypeBool:     "bool",
+	TypeString:   "string",
+	TypeGroup:    "group",
+	TypeMessage:  "message",
+	TypeBytes:    "bytes",
+	TypeUint32:   "uint32",
+	TypeEnum:     "enum",
+	TypeSfixed32: "sfixed32",
+	TypeSfixed64: "sfixed64",
+	TypeSint32:   "sint32",
+	TypeSint64:   "sint64",
+}
+
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("type(%d)", int32(t))
+}
+
+// DescriptorSet is a FileDescriptorSet reduced to what oasdiff compares: the
+// messages, enums and services of its files, keyed by their fully-qualified
+// names, e.g. acme.users.v1.User
+type DescriptorSet struct {
+	Messages map[string]*Message
+	Enums    map[string]*Enum
+	Methods  map[string]*Method
+}
+
+// Message is a message type
+type Message struct {
+	Name     string
+	File     string
+	Fields   map[int32]*Field
+	Reserved Ranges
+}
+
+// Field is a field of a message
+type Field struct {
+	Name   string
+	Number int32
+	Label  Label
+	Type   Type
+	// TypeName is the fully-qualified name of the message or enum type of
+	// the field, if any
+	TypeName string
+}
+
+// TypeString returns the field's type as written in a .proto file
+func (f *Field) TypeString() string {
+	if f.TypeName != "" {
+		return f.TypeName
+	}
+	return f.Type.String()
+}
+
+// Enum is an enum type
+type Enum struct {
+	Name     string
+	File     string
+	Values   map[int32]string
+	Reserved Ranges
+}
+
+// Method is an RPC method of a service
+type Method struct {
+	// Name is the fully-qualified name of the method, e.g. acme.users.v1.Users.GetUser
+	Name            string
+	File            string
+	InputType       string
+	OutputType      string
+	ClientStreaming bool
+	ServerStreaming bool
+	// HTTP are the REST endpoints the method is transcoded to by its
+	// google.api.http annotation, if any
+	HTTP []HTTPRule
+}
+
+// Path returns the path gRPC calls the method at, e.g. /acme.users.v1.Users/GetUser
+func (m *Method) Path() string {
+	i := strings.LastIndex(m.Name, ".")
+	return "/" + m.Name[:i] + "/" + m.Name[i+1:]
+}
+
+// HTTPRule is a REST endpoint that a method is transcoded to
+type HTTPRule struct {
+	Method string
+	Path   string
+}
+
+func (rule HTTPRule) String() string {
+	return rule.Method + " " + rule.Path
+}
+
+// Range is a range of field or enum value numbers, inclusive
+type Range struct {
+	Start int32
+	End   int32
+}
+
+// Ranges is a list of ranges
+type Ranges []Range
+
+// Contains reports whether a number is in one of the ranges
+func (ranges Ranges) Contains(number int32) bool {
+	return slices.ContainsFunc(ranges, func(r Range) bool {
+		return number >= r.Start && number <= r.End
+	})
+}
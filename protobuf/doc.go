@@ -0,0 +1,22 @@
+/*
+Package protobuf reads protobuf descriptor sets for wire-compatibility checks.
+
+# Overview
+
+A FileDescriptorSet is the binary form of a set of .proto files, as written by
+protoc --descriptor_set_out (with --include_imports) or buf build. Parse
+decodes one into the messages, enums and RPC methods that the checker compares
+for wire compatibility, keyed by their fully-qualified names:
+
+	set, err := protobuf.Load("api.pb")
+
+The descriptor set is decoded directly from the protobuf wire format, reading
+only the fields of descriptor.proto that the checks need.
+
+# Transcoding
+
+A method annotated with google.api.http, as used by grpc-gateway and other
+gRPC-JSON transcoders, carries the REST endpoints it is served at, so changes
+to it can be reported at the endpoint where they meet the API's OpenAPI spec.
+*/
+package protobuf
@@ -0,0 +1,324 @@
+package protobuf
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// httpExtension is the field number of the google.api.http extension of MethodOptions
+const httpExtension = 72295728
+
+// Load reads a FileDescriptorSet from a file, as written by
+// protoc --descriptor_set_out or buf build
+func Load(path string) (*DescriptorSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes a binary FileDescriptorSet
+func Parse(data []byte) (*DescriptorSet, error) {
+	result := &DescriptorSet{
+		Messages: map[string]*Message{},
+		Enums:    map[string]*Enum{},
+		Methods:  map[string]*Method{},
+	}
+
+	err := decodeFields(data, func(f field) error {
+		if f.number == 1 && f.wireType == wireBytes {
+			return result.addFile(f.bytes)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor set: %w", err)
+	}
+	return result, nil
+}
+
+func (set *DescriptorSet) addFile(data []byte) error {
+	var name, pkg string
+	var messages, enums, services [][]byte
+
+	err := decodeFields(data, func(f field) error {
+		if f.wireType != wireBytes {
+			return nil
+		}
+		switch f.number {
+		case 1:
+			name = f.string()
+		case 2:
+			pkg = f.string()
+		case 4:
+			messages = append(messages, f.bytes)
+		case 5:
+			enums = append(enums, f.bytes)
+		case 6:
+			services = append(services, f.bytes)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		if err := set.addMessage(name, pkg, message); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	for _, enum := range enums {
+		if err := set.addEnum(name, pkg, enum); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	for _, service := range services {
+		if err := set.addService(name, pkg, service); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (set *DescriptorSet) addMessage(file, scope string, data []byte) error {
+	message := &Message{File: file, Fields: map[int32]*Field{}}
+	var nested, enums [][]byte
+
+	err := decodeFields(data, func(f field) error {
+		if f.wireType != wireBytes {
+			return nil
+		}
+		switch f.number {
+		case 1:
+			message.Name = qualify(scope, f.string())
+		case 2:
+			field, err := parseField(f.bytes)
+			if err != nil {
+				return err
+			}
+			message.Fields[field.Number] = field
+		case 3:
+			nested = append(nested, f.bytes)
+		case 4:
+			enums = append(enums, f.bytes)
+		case 9:
+			// the end of a message's reserved range is exclusive
+			r, err := parseRange(f.bytes)
+			if err != nil {
+				return err
+			}
+			r.End--
+			message.Reserved = append(message.Reserved, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	set.Messages[message.Name] = message
+
+	for _, data := range nested {
+		if err := set.addMessage(file, message.Name, data); err != nil {
+			return err
+		}
+	}
+	for _, data := range enums {
+		if err := set.addEnum(file, message.Name, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseField(data []byte) (*Field, error) {
+	result := &Field{}
+	err := decodeFields(data, func(f field) error {
+		switch {
+		case f.number == 1 && f.wireType == wireBytes:
+			result.Name = f.string()
+		case f.number == 3 && f.wireType == wireVarint:
+			result.Number = f.int32()
+		case f.number == 4 && f.wireType == wireVarint:
+			result.Label = Label(f.int32())
+		case f.number == 5 && f.wireType == wireVarint:
+			result.Type = Type(f.int32())
+		case f.number == 6 && f.wireType == wireBytes:
+			result.TypeName = strings.TrimPrefix(f.string(), ".")
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (set *DescriptorSet) addEnum(file, scope string, data []byte) error {
+	enum := &Enum{File: file, Values: map[int32]string{}}
+	err := decodeFields(data, func(f field) error {
+		if f.wireType != wireBytes {
+			return nil
+		}
+		switch f.number {
+		case 1:
+			enum.Name = qualify(scope, f.string())
+		case 2:
+			var name string
+			var number int32
+			err := decodeFields(f.bytes, func(f field) error {
+				switch {
+				case f.number == 1 && f.wireType == wireBytes:
+					name = f.string()
+				case f.number == 2 && f.wireType == wireVarint:
+					number = f.int32()
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			// the first of the aliases of a number names it
+			if _, ok := enum.Values[number]; !ok {
+				enum.Values[number] = name
+			}
+		case 4:
+			r, err := parseRange(f.bytes)
+			if err != nil {
+				return err
+			}
+			enum.Reserved = append(enum.Reserved, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	set.Enums[enum.Name] = enum
+	return nil
+}
+
+func parseRange(data []byte) (Range, error) {
+	var result Range
+	err := decodeFields(data, func(f field) error {
+		switch {
+		case f.number == 1 && f.wireType == wireVarint:
+			result.Start = f.int32()
+		case f.number == 2 && f.wireType == wireVarint:
+			result.End = f.int32()
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (set *DescriptorSet) addService(file, pkg string, data []byte) error {
+	var name string
+	var methods [][]byte
+	err := decodeFields(data, func(f field) error {
+		if f.wireType != wireBytes {
+			return nil
+		}
+		switch f.number {
+		case 1:
+			name = qualify(pkg, f.string())
+		case 2:
+			methods = append(methods, f.bytes)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, data := range methods {
+		method := &Method{File: file}
+		err := decodeFields(data, func(f field) error {
+			switch {
+			case f.number == 1 && f.wireType == wireBytes:
+				method.Name = qualify(name, f.string())
+			case f.number == 2 && f.wireType == wireBytes:
+				method.InputType = strings.TrimPrefix(f.string(), ".")
+			case f.number == 3 && f.wireType == wireBytes:
+				method.OutputType = strings.TrimPrefix(f.string(), ".")
+			case f.number == 4 && f.wireType == wireBytes:
+				return decodeFields(f.bytes, func(f field) error {
+					if f.number == httpExtension && f.wireType == wireBytes {
+						return parseHTTPRule(f.bytes, &method.HTTP)
+					}
+					return nil
+				})
+			case f.number == 5 && f.wireType == wireVarint:
+				method.ClientStreaming = f.bool()
+			case f.number == 6 && f.wireType == wireVarint:
+				method.ServerStreaming = f.bool()
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		set.Methods[method.Name] = method
+	}
+	return nil
+}
+
+// httpMethods maps the fields of google.api.HttpRule to the HTTP methods they bind
+var httpMethods = map[int32]string{
+	2: "GET",
+	3: "PUT",
+	4: "POST",
+	5: "DELETE",
+	6: "PATCH",
+}
+
+// parseHTTPRule appends the REST endpoints of a google.api.HttpRule to rules:
+// its own and those of its additional bindings
+func parseHTTPRule(data []byte, rules *[]HTTPRule) error {
+	var additional [][]byte
+	err := decodeFields(data, func(f field) error {
+		if f.wireType != wireBytes {
+			return nil
+		}
+		if method, ok := httpMethods[f.number]; ok {
+			*rules = append(*rules, HTTPRule{Method: method, Path: f.string()})
+			return nil
+		}
+		switch f.number {
+		case 8:
+			var rule HTTPRule
+			err := decodeFields(f.bytes, func(f field) error {
+				switch {
+				case f.number == 1 && f.wireType == wireBytes:
+					rule.Method = strings.ToUpper(f.string())
+				case f.number == 2 && f.wireType == wireBytes:
+					rule.Path = f.string()
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			*rules = append(*rules, rule)
+		case 11:
+			additional = append(additional, f.bytes)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, data := range additional {
+		if err := parseHTTPRule(data, rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func qualify(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
@@ -0,0 +1,55 @@
+package protobuf_test
+
+import (
+	"testing"
+
+	"github.com/oasdiff/oasdiff/protobuf"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	set, err := protobuf.Load("../data/proto/base.pb")
+	require.NoError(t, err)
+
+	require.Len(t, set.Methods, 4)
+	getUser := set.Methods["acme.users.v1.Users.GetUser"]
+	require.Equal(t, "base.proto", getUser.File)
+	require.Equal(t, "/acme.users.v1.Users/GetUser", getUser.Path())
+	require.Equal(t, "acme.users.v1.GetUserRequest", getUser.InputType)
+	require.Equal(t, "acme.users.v1.User", getUser.OutputType)
+	require.Equal(t, []protobuf.HTTPRule{{Method: "GET", Path: "/v1/users/{id}"}}, getUser.HTTP)
+
+	watchUsers := set.Methods["acme.users.v1.Users.WatchUsers"]
+	require.False(t, watchUsers.ClientStreaming)
+	require.True(t, watchUsers.ServerStreaming)
+	require.Empty(t, watchUsers.HTTP)
+
+	user := set.Messages["acme.users.v1.User"]
+	require.Len(t, user.Fields, 8)
+	require.Equal(t, &protobuf.Field{Name: "status", Number: 4, Label: protobuf.LabelOptional, Type: protobuf.TypeEnum, TypeName: "acme.users.v1.Status"}, user.Fields[4])
+	require.Equal(t, protobuf.LabelRepeated, user.Fields[7].Label)
+	require.True(t, user.Reserved.Contains(9))
+	require.False(t, user.Reserved.Contains(10))
+
+	status := set.Enums["acme.users.v1.Status"]
+	require.Equal(t, map[int32]string{0: "STATUS_UNSPECIFIED", 1: "ACTIVE", 2: "SUSPENDED"}, status.Values)
+
+	audit := set.Messages["acme.legacy.v1.Audit"]
+	require.Equal(t, "legacy-base.proto", audit.File)
+	require.Equal(t, protobuf.LabelRequired, audit.Fields[2].Label)
+}
+
+func TestParse_Truncated(t *testing.T) {
+	_, err := protobuf.Parse([]byte{0x0a, 0x05, 0x0a})
+	require.EqualError(t, err, "invalid descriptor set: truncated message")
+}
+
+func TestParse_NotADescriptorSet(t *testing.T) {
+	_, err := protobuf.Parse([]byte("openapi: 3.0.0\n"))
+	require.Error(t, err)
+}
+
+func TestLoad_NoFile(t *testing.T) {
+	_, err := protobuf.Load("../data/proto/missing.pb")
+	require.Error(t, err)
+}
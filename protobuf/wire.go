@@ -0,0 +1,88 @@
+package protobuf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// wire types of the protobuf encoding: https://protobuf.dev/programming-guides/encoding/
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("truncated message")
+
+// field is an encoded field: its number and wire type, and its value, in
+// varint for the varint and fixed wire types and in bytes for the
+// length-delimited one
+type field struct {
+	number   int32
+	wireType int
+	varint   uint64
+	bytes    []byte
+}
+
+// decodeFields calls fn with each field of an encoded message, in order
+func decodeFields(data []byte, fn func(f field) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+
+		f := field{number: int32(key >> 3), wireType: int(key & 7)}
+		switch f.wireType {
+		case wireVarint:
+			if f.varint, n = binary.Uvarint(data); n <= 0 {
+				return errTruncated
+			}
+			data = data[n:]
+		case wireFixed64:
+			if len(data) < 8 {
+				return errTruncated
+			}
+			f.varint = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+		case wireFixed32:
+			if len(data) < 4 {
+				return errTruncated
+			}
+			f.varint = uint64(binary.LittleEndian.Uint32(data))
+			data = data[4:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return errTruncated
+			}
+			f.bytes = data[n : n+int(length)]
+			data = data[n+int(length):]
+		default:
+			return fmt.Errorf("unsupported wire type %d in field %d", f.wireType, f.number)
+		}
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// string returns the value of a length-delimited field as a string
+func (f field) string() string {
+	return string(f.bytes)
+}
+
+// int32 returns the value of a varint field as an int32
+func (f field) int32() int32 {
+	return int32(f.varint)
+}
+
+// bool returns the value of a varint field as a bool
+func (f field) bool() bool {
+	return f.varint != 0
+}
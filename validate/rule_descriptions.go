@@ -3,8 +3,15 @@ package validate
 import (
 	"regexp"
 	"strings"
+
+	"github.com/oasdiff/oasdiff/formatters"
 )
 
+func init() {
+	// the sarif rules table describes the rules of the findings it lists
+	formatters.ValidateRuleDescription = RuleDescription
+}
+
 // Descriptions for the rules `oasdiff validate` can report, used by
 // `oasdiff checks validate` to say what each ID means.
 //
@@ -0,0 +1,27 @@
+/*
+Package verify looks for counterexamples to breaking changes.
+
+# Overview
+
+Some checks are heuristic: a change to a schema may be reported as breaking
+even if no payload is affected by it, for example, when a maxLength is
+decreased below a length that an enum already excludes. Changes looks for
+evidence of each breaking change to a request body, a response body or a
+request parameter:
+
+	changes = verify.Changes(changes, base.Spec, revision.Spec)
+
+A counterexample to a change to a request is a payload that the base accepts
+and the revision rejects; to a change to a response, a payload that the
+revision allows and the base rejects. A breaking change without one is
+possibly a false positive.
+
+# Generation
+
+Candidate payloads are generated from the schemas: the examples and defaults
+of each schema, and values at the boundaries of its constraints. The property
+that a change names is varied exhaustively, and others are kept to a few
+values, so the search is cheap but not complete. The candidates are validated
+with openapi3filter, as a server would validate them.
+*/
+package verify
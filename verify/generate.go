@@ -0,0 +1,502 @@
+package verify
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// maxDepth limits the nesting of the payloads, which recursive schemas would make infinite
+	maxDepth = 10
+	// maxValues limits the values generated for a schema at the focus
+	maxValues = 64
+	// maxVariants limits the values generated for a schema away from the focus
+	maxVariants = 2
+	// maxLength is the length of the long strings generated for schemas without a maxLength
+	maxLength = 1024
+	// maxItems is the length of the long arrays generated for schemas without a maxItems
+	maxItems = 32
+)
+
+// formatValues are values of the common string formats
+var formatValues = map[string]string{
+	"date":      "2024-01-01",
+	"date-time": "2024-01-01T00:00:00Z",
+	"time":      "00:00:00Z",
+	"duration":  "P1D",
+	"email":     "user@example.com",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"uri":       "https://example.com/",
+	"url":       "https://example.com/",
+	"uuid":      "00000000-0000-0000-0000-000000000000",
+	"byte":      "YQ==",
+	"regex":     ".*",
+}
+
+// patternValues are tried for strings with a pattern, since a pattern can't be inverted
+var patternValues = []any{"a", "A", "0", "a0", "abc", "ABC", "123", "a-b", "a_b", "a.b", "A1b2"}
+
+// generator generates candidate payloads for a schema: values that the schema
+// is likely to accept, at the boundaries of its constraints. Values that the
+// schema rejects are dropped when it accepts others, but the candidates still
+// need to be validated as a whole.
+//
+// The focus is the path of the property that a change names, with items for
+// the items of an array. Values at the focus, and right below it, are varied
+// exhaustively; others are kept to a few, to keep the number of candidates low.
+type generator struct {
+	request bool
+	focus   []string
+	options []openapi3.SchemaValidationOption
+}
+
+func newGenerator(request bool, focus []string, openAPI31 bool) generator {
+	options := []openapi3.SchemaValidationOption{openapi3.VisitAsResponse()}
+	if request {
+		options = []openapi3.SchemaValidationOption{openapi3.VisitAsRequest()}
+	}
+	if openAPI31 {
+		options = append(options, openapi3.EnableJSONSchema2020())
+	}
+	return generator{request: request, focus: focus, options: options}
+}
+
+// onFocus reports whether the values at path should be varied exhaustively
+func (g generator) onFocus(path []string) bool {
+	return isPrefix(path, g.focus) ||
+		isPrefix(g.focus, path) && len(path)-len(g.focus) <= 1
+}
+
+func isPrefix(prefix, path []string) bool {
+	return len(prefix) <= len(path) && slices.Equal(prefix, path[:len(prefix)])
+}
+
+// values returns candidate values of the schema, the most likely to be valid first
+func (g generator) values(schemaRef *openapi3.SchemaRef, path []string) []any {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return []any{"a", 1.0, true}
+	}
+	schema := schemaRef.Value
+
+	limit := maxValues
+	if !g.onFocus(path) {
+		limit = maxVariants
+	}
+
+	result := g.generate(schema, path, limit)
+
+	valid := make([]any, 0, len(result))
+	for _, value := range result {
+		if schema.VisitJSON(value, g.options...) == nil {
+			valid = append(valid, value)
+		}
+	}
+	if len(valid) > 0 {
+		return valid
+	}
+	return result
+}
+
+func (g generator) generate(schema *openapi3.Schema, path []string, limit int) []any {
+	result := values{limit: limit}
+
+	if schema.Const != nil {
+		result.add(schema.Const)
+		return result.list
+	}
+
+	if len(schema.Enum) > 0 {
+		result.add(schema.Enum...)
+		if schema.Nullable {
+			result.add(nil)
+		}
+		return result.list
+	}
+
+	// examples are most likely to be valid
+	for _, example := range append([]any{schema.Example, schema.Default}, schema.Examples...) {
+		if example != nil {
+			result.add(example)
+		}
+	}
+
+	if branches := append(slices.Clone(schema.OneOf), schema.AnyOf...); len(branches) > 0 {
+		trunk := *schema
+		trunk.OneOf, trunk.AnyOf = nil, nil
+		for _, branch := range branches {
+			if branch.Value != nil {
+				result.add(g.generate(merge(&trunk, branch.Value), path, limit)...)
+			}
+		}
+		return result.list
+	}
+
+	schema = flatten(schema)
+
+	for _, typ := range getTypes(schema) {
+		switch typ {
+		case openapi3.TypeString:
+			result.add(stringValues(schema)...)
+		case openapi3.TypeInteger:
+			result.add(numberValues(schema, true)...)
+		case openapi3.TypeNumber:
+			result.add(numberValues(schema, false)...)
+		case openapi3.TypeBoolean:
+			result.add(true, false)
+		case openapi3.TypeNull:
+			result.add(nil)
+		case openapi3.TypeArray:
+			result.add(g.arrayValues(schema, path)...)
+		case openapi3.TypeObject:
+			result.add(g.objectValues(schema, path)...)
+		}
+	}
+
+	if schema.Nullable {
+		result.add(nil)
+	}
+
+	return result.list
+}
+
+// values is a list of distinct values, up to a limit
+type values struct {
+	list  []any
+	limit int
+}
+
+func (v *values) add(values ...any) {
+	for _, value := range values {
+		if len(v.list) >= v.limit {
+			return
+		}
+		if !slices.ContainsFunc(v.list, func(existing any) bool { return reflect.DeepEqual(existing, value) }) {
+			v.list = append(v.list, value)
+		}
+	}
+}
+
+// getTypes returns the types of the schema, or those implied by its keywords if it has none
+func getTypes(schema *openapi3.Schema) []string {
+	if types := schema.Type.Slice(); len(types) > 0 {
+		return types
+	}
+	switch {
+	case len(schema.Properties) > 0 || len(schema.Required) > 0:
+		return []string{openapi3.TypeObject}
+	case schema.Items != nil || len(schema.PrefixItems) > 0:
+		return []string{openapi3.TypeArray}
+	case schema.Pattern != "" || schema.Format != "" || schema.MinLength > 0 || schema.MaxLength != nil:
+		return []string{openapi3.TypeString}
+	case schema.Min != nil || schema.Max != nil || schema.MultipleOf != nil:
+		return []string{openapi3.TypeNumber}
+	}
+	return []string{openapi3.TypeString, openapi3.TypeInteger, openapi3.TypeBoolean, openapi3.TypeObject, openapi3.TypeArray}
+}
+
+func stringValues(schema *openapi3.Schema) []any {
+	result := []any{}
+
+	if value, ok := formatValues[schema.Format]; ok {
+		result = append(result, value)
+	}
+	if schema.Pattern != "" {
+		result = append(result, patternValues...)
+	}
+
+	minLength := int(schema.MinLength)
+	result = append(result, strings.Repeat("a", max(minLength, 1)))
+	if minLength == 0 {
+		result = append(result, "")
+	}
+	if schema.MaxLength != nil {
+		if *schema.MaxLength <= maxLength {
+			result = append(result, strings.Repeat("a", int(*schema.MaxLength)))
+		}
+	} else {
+		result = append(result, strings.Repeat("a", max(minLength, 16)), strings.Repeat("a", max(minLength, maxLength)))
+	}
+
+	return result
+}
+
+func numberValues(schema *openapi3.Schema, integer bool) []any {
+	// exclusive bounds are approached by a step, smaller in a narrow range of numbers
+	step := 1.0
+	if lower, upper := getBounds(schema.Min, schema.ExclusiveMin, 0), getBounds(schema.Max, schema.ExclusiveMax, 0); !integer && len(lower) > 0 && len(upper) > 0 {
+		if width := slices.Min(upper) - slices.Max(lower); width > 0 && width <= 2 {
+			step = width / 4
+		}
+	}
+
+	candidates := []float64{}
+	for _, lower := range getBounds(schema.Min, schema.ExclusiveMin, step) {
+		if integer {
+			lower = math.Ceil(lower)
+		}
+		candidates = append(candidates, lower)
+	}
+	for _, upper := range getBounds(schema.Max, schema.ExclusiveMax, -step) {
+		if integer {
+			upper = math.Floor(upper)
+		}
+		candidates = append(candidates, upper)
+	}
+	candidates = append(candidates, 0, 1, -1, 1e6, -1e6)
+
+	result := []any{}
+	for _, value := range candidates {
+		if multipleOf := schema.MultipleOf; multipleOf != nil && *multipleOf > 0 {
+			value = math.Ceil(value / *multipleOf) * *multipleOf
+		}
+		result = append(result, value)
+	}
+	return result
+}
+
+// getBounds returns the values closest to a minimum or a maximum, in the style
+// of OpenAPI 3.0 or 3.1, or both, moved by step from exclusive bounds.
+// Those that the schema rejects, when both are given, are filtered later.
+func getBounds(bound *float64, exclusive openapi3.ExclusiveBound, step float64) []float64 {
+	result := []float64{}
+	if bound != nil {
+		if exclusive.IsTrue() {
+			result = append(result, *bound+step)
+		} else {
+			result = append(result, *bound)
+		}
+	}
+	if exclusive.Value != nil {
+		result = append(result, *exclusive.Value+step)
+	}
+	return result
+}
+
+func (g generator) arrayValues(schema *openapi3.Schema, path []string) []any {
+	if len(path) > maxDepth {
+		return []any{[]any{}}
+	}
+
+	prefix := []any{}
+	for i, prefixItem := range schema.PrefixItems {
+		prefix = append(prefix, first(g.values(prefixItem, append(slices.Clone(path), "prefixItems", strconv.Itoa(i)))))
+	}
+
+	items := []any{"a"}
+	if schema.Items != nil {
+		items = g.values(schema.Items, append(slices.Clone(path), "items"))
+	}
+	if schema.Contains != nil {
+		items = append(g.values(schema.Contains, append(slices.Clone(path), "contains"))[:1], items...)
+	}
+
+	minItems := max(int(schema.MinItems), len(prefix))
+	array := func(length int, first int) []any {
+		result := slices.Clone(prefix)
+		for i := 0; len(result) < length; i++ {
+			result = append(result, items[(first+i)%len(items)])
+		}
+		return result
+	}
+
+	result := []any{array(minItems, 0)}
+	for i := range items {
+		result = append(result, array(max(minItems, len(prefix)+1), i))
+	}
+	if schema.MaxItems != nil {
+		if *schema.MaxItems <= maxItems {
+			result = append(result, array(int(*schema.MaxItems), 0))
+		}
+	} else if g.onFocus(path) {
+		result = append(result, array(max(minItems, maxItems), 0))
+	}
+	return result
+}
+
+func (g generator) objectValues(schema *openapi3.Schema, path []string) []any {
+	if len(path) > maxDepth {
+		return []any{map[string]any{}}
+	}
+
+	propertyValues := map[string][]any{}
+	names := []string{}
+	for name, property := range schema.Properties {
+		if property.Value != nil && (g.request && property.Value.ReadOnly || !g.request && property.Value.WriteOnly) {
+			continue
+		}
+		names = append(names, name)
+		propertyValues[name] = g.values(property, append(slices.Clone(path), name))
+	}
+	for _, name := range schema.Required {
+		if _, ok := propertyValues[name]; !ok {
+			names = append(names, name)
+			propertyValues[name] = []any{"a"}
+		}
+	}
+	slices.Sort(names)
+
+	// with sets the property and those that it requires
+	with := func(object map[string]any, name string, value any) map[string]any {
+		result := make(map[string]any, len(object)+1)
+		for k, v := range object {
+			result[k] = v
+		}
+		result[name] = value
+		for _, dependent := range schema.DependentRequired[name] {
+			if _, ok := result[dependent]; !ok {
+				result[dependent] = first(propertyValues[dependent])
+			}
+		}
+		return result
+	}
+
+	minimal := map[string]any{}
+	for _, name := range schema.Required {
+		minimal = with(minimal, name, first(propertyValues[name]))
+	}
+	full := minimal
+	for _, name := range names {
+		if _, ok := full[name]; !ok {
+			full = with(full, name, first(propertyValues[name]))
+		}
+	}
+
+	// the smallest payloads first, for the smallest counterexamples
+	result := []any{minimal}
+	for _, name := range names {
+		if !g.onFocus(append(slices.Clone(path), name)) {
+			continue
+		}
+		for _, value := range propertyValues[name] {
+			result = append(result, with(minimal, name, value))
+		}
+	}
+	result = append(result, full)
+
+	if additional := schema.AdditionalProperties; additional.Has == nil || *additional.Has || additional.Schema != nil {
+		value := any("a")
+		if additional.Schema != nil {
+			value = first(g.values(additional.Schema, append(slices.Clone(path), "additionalProperties")))
+		}
+		result = append(result, with(full, "additionalProperty", value))
+	}
+
+	return result
+}
+
+func first(values []any) any {
+	if len(values) == 0 {
+		return nil
+	}
+	return values[0]
+}
+
+// flatten returns the schema with its allOf subschemas merged into it
+func flatten(schema *openapi3.Schema) *openapi3.Schema {
+	if len(schema.AllOf) == 0 {
+		return schema
+	}
+	result := *schema
+	result.AllOf = nil
+	for _, part := range schema.AllOf {
+		if part.Value != nil {
+			result = *merge(&result, part.Value)
+		}
+	}
+	return &result
+}
+
+// merge returns a schema with the keywords of both schemas, the stricter of
+// each constraint, that accepts values that both accept, approximately
+func merge(schema, other *openapi3.Schema) *openapi3.Schema {
+	other = flatten(other)
+	result := *schema
+
+	if result.Type.IsEmpty() {
+		result.Type = other.Type
+	}
+	result.Nullable = schema.Nullable && other.Nullable
+	if len(result.Enum) == 0 {
+		result.Enum = other.Enum
+	}
+	if result.Const == nil {
+		result.Const = other.Const
+	}
+	if result.Format == "" {
+		result.Format = other.Format
+	}
+	if result.Pattern == "" {
+		result.Pattern = other.Pattern
+	}
+	if result.Example == nil {
+		result.Example = other.Example
+	}
+
+	result.Min = maxBound(result.Min, other.Min)
+	result.Max = minBound(result.Max, other.Max)
+	if !result.ExclusiveMin.IsSet() {
+		result.ExclusiveMin = other.ExclusiveMin
+	}
+	if !result.ExclusiveMax.IsSet() {
+		result.ExclusiveMax = other.ExclusiveMax
+	}
+	if result.MultipleOf == nil {
+		result.MultipleOf = other.MultipleOf
+	}
+	result.MinLength = max(result.MinLength, other.MinLength)
+	result.MaxLength = minCount(result.MaxLength, other.MaxLength)
+	result.MinItems = max(result.MinItems, other.MinItems)
+	result.MaxItems = minCount(result.MaxItems, other.MaxItems)
+	if result.Items == nil {
+		result.Items = other.Items
+	}
+
+	if len(other.Properties) > 0 {
+		result.Properties = make(openapi3.Schemas, len(schema.Properties)+len(other.Properties))
+		for name, property := range schema.Properties {
+			result.Properties[name] = property
+		}
+		for name, property := range other.Properties {
+			if _, ok := result.Properties[name]; !ok {
+				result.Properties[name] = property
+			}
+		}
+	}
+	result.Required = append(slices.Clone(schema.Required), other.Required...)
+	if other.AdditionalProperties.Has != nil && !*other.AdditionalProperties.Has {
+		result.AdditionalProperties = other.AdditionalProperties
+	}
+
+	result.OneOf = append(slices.Clone(schema.OneOf), other.OneOf...)
+	result.AnyOf = append(slices.Clone(schema.AnyOf), other.AnyOf...)
+
+	return &result
+}
+
+func maxBound(a, b *float64) *float64 {
+	if a == nil || b != nil && *b > *a {
+		return b
+	}
+	return a
+}
+
+func minBound(a, b *float64) *float64 {
+	if a == nil || b != nil && *b < *a {
+		return b
+	}
+	return a
+}
+
+func minCount(a, b *uint64) *uint64 {
+	if a == nil || b != nil && *b < *a {
+		return b
+	}
+	return a
+}
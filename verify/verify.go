@@ -0,0 +1,512 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/oasdiff/oasdiff/checker"
+)
+
+// Changes looks for a counterexample to each breaking change to a request body,
+// a response body or a request parameter, and sets the outcome as the change's
+// verification (see checker.Verification). Other changes are returned as is.
+//
+// A counterexample to a change to a request is a payload that the base accepts
+// and the revision rejects; to a change to a response, a payload that the
+// revision allows and the base rejects. The payloads are generated from the
+// schemas, and validated by openapi3filter, as a server would validate them.
+// The rejection must concern the property that the change names, if any, so
+// that one change doesn't confirm another.
+func Changes(changes checker.Changes, base, revision *openapi3.T) checker.Changes {
+	result := make(checker.Changes, len(changes))
+	for i, change := range changes {
+		result[i] = change
+		apiChange, ok := change.(checker.ApiChange)
+		if !ok || !apiChange.IsBreaking() {
+			continue
+		}
+		if verification := verifyChange(apiChange, base, revision); verification != nil {
+			result[i] = checker.WithVerification(change, verification)
+		}
+	}
+	return result
+}
+
+func verifyChange(change checker.ApiChange, base, revision *openapi3.T) *checker.Verification {
+	targets := getTargets(change, base, revision)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	for _, target := range targets {
+		if verification := target.verify(change); verification != nil {
+			return verification
+		}
+	}
+	return &checker.Verification{In: targets[0].in}
+}
+
+const (
+	baseSide     = 0
+	revisionSide = 1
+)
+
+// target is a payload that a change concerns, in the base and in the revision
+type target struct {
+	// in describes the payload, e.g. request body application/json
+	in       string
+	request  bool
+	specs    [2]*openapi3.T
+	schemas  [2]*openapi3.SchemaRef
+	validate [2]func(value any) error
+}
+
+// verify returns a confirmed verification with a counterexample, or nil if none was found
+func (t target) verify(change checker.ApiChange) *checker.Verification {
+	source, destination := baseSide, revisionSide
+	if !t.request {
+		source, destination = revisionSide, baseSide
+	}
+
+	focus := t.getFocus(change)
+	// a missing property only confirms changes to whether it is required
+	missing := strings.Contains(change.Id, "required") || strings.Contains(change.Id, "optional")
+
+	g := newGenerator(t.request, focus, t.specs[source].IsOpenAPI31OrLater())
+	for _, value := range g.values(t.schemas[source], []string{}) {
+		if t.validate[source](value) != nil {
+			continue
+		}
+		err := t.validate[destination](value)
+		if err == nil {
+			continue
+		}
+		reason, ok := getReason(err, focus, missing)
+		if !ok {
+			continue
+		}
+		counterexample, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		return &checker.Verification{
+			Confirmed:      true,
+			In:             t.in,
+			Counterexample: string(counterexample),
+			Reason:         reason,
+		}
+	}
+	return nil
+}
+
+// getTargets returns the payloads that the change concerns and that exist in
+// both the base and the revision
+func getTargets(change checker.ApiChange, base, revision *openapi3.T) []target {
+	baseOperation, basePathItem := getOperation(base, change.Operation, change.Path)
+	revisionOperation, revisionPathItem := getOperation(revision, change.Operation, change.Path)
+	if baseOperation == nil || revisionOperation == nil {
+		return nil
+	}
+
+	specs := [2]*openapi3.T{base, revision}
+	operations := [2]*openapi3.Operation{baseOperation, revisionOperation}
+
+	switch {
+	case strings.HasPrefix(change.Id, "request-parameter-"):
+		return getParameterTargets(change, specs, operations, [2]*openapi3.PathItem{basePathItem, revisionPathItem})
+	case strings.HasPrefix(change.Id, "request-"):
+		return getRequestBodyTargets(change, specs, operations)
+	case strings.HasPrefix(change.Id, "response-") && !strings.HasPrefix(change.Id, "response-header-"):
+		return getResponseTargets(change, specs, operations)
+	}
+	return nil
+}
+
+func getOperation(spec *openapi3.T, method, path string) (*openapi3.Operation, *openapi3.PathItem) {
+	if spec == nil || spec.Paths == nil {
+		return nil, nil
+	}
+	pathItem := spec.Paths.Find(path)
+	if pathItem == nil {
+		return nil, nil
+	}
+	return pathItem.GetOperation(method), pathItem
+}
+
+func getRequestBodyTargets(change checker.ApiChange, specs [2]*openapi3.T, operations [2]*openapi3.Operation) []target {
+	var requestBodies [2]*openapi3.RequestBody
+	for side, operation := range operations {
+		if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+			return nil
+		}
+		requestBodies[side] = operation.RequestBody.Value
+	}
+
+	result := []target{}
+	for _, mediaType := range getMediaTypes(change, requestBodies[baseSide].Content, requestBodies[revisionSide].Content) {
+		t := target{
+			in:      "request body " + mediaType,
+			request: true,
+			specs:   specs,
+		}
+		for side := range specs {
+			t.schemas[side] = requestBodies[side].Content[mediaType].Schema
+			t.validate[side] = validateRequestBody(specs[side], operations[side], requestBodies[side], mediaType)
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+func getResponseTargets(change checker.ApiChange, specs [2]*openapi3.T, operations [2]*openapi3.Operation) []target {
+	if operations[baseSide].Responses == nil || operations[revisionSide].Responses == nil {
+		return nil
+	}
+
+	statuses := []string{}
+	for status := range operations[baseSide].Responses.Map() {
+		if operations[revisionSide].Responses.Value(status) != nil {
+			statuses = append(statuses, status)
+		}
+	}
+	slices.Sort(statuses)
+	if mentioned := slices.DeleteFunc(slices.Clone(statuses), func(status string) bool { return !mentions(change, status) }); len(mentioned) > 0 {
+		statuses = mentioned
+	}
+
+	result := []target{}
+	for _, status := range statuses {
+		var responses [2]*openapi3.Response
+		for side, operation := range operations {
+			responses[side] = operation.Responses.Value(status).Value
+		}
+		if responses[baseSide] == nil || responses[revisionSide] == nil {
+			continue
+		}
+		for _, mediaType := range getMediaTypes(change, responses[baseSide].Content, responses[revisionSide].Content) {
+			t := target{
+				in:    fmt.Sprintf("response %s %s", status, mediaType),
+				specs: specs,
+			}
+			for side := range specs {
+				t.schemas[side] = responses[side].Content[mediaType].Schema
+				t.validate[side] = validateResponse(specs[side], responses[side], mediaType)
+			}
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// getMediaTypes returns the JSON media types that have a schema in both the
+// base and the revision, or those of them that the change mentions, if any
+func getMediaTypes(change checker.ApiChange, base, revision openapi3.Content) []string {
+	result := []string{}
+	for mediaType, baseMediaType := range base {
+		revisionMediaType := revision[mediaType]
+		if !strings.Contains(mediaType, "json") || revisionMediaType == nil ||
+			baseMediaType.Schema == nil || revisionMediaType.Schema == nil {
+			continue
+		}
+		result = append(result, mediaType)
+	}
+	slices.Sort(result)
+	if mentioned := slices.DeleteFunc(slices.Clone(result), func(mediaType string) bool { return !mentions(change, mediaType) }); len(mentioned) > 0 {
+		return mentioned
+	}
+	return result
+}
+
+func mentions(change checker.ApiChange, value string) bool {
+	return slices.ContainsFunc(change.Args, func(arg any) bool { return fmt.Sprint(arg) == value })
+}
+
+// getParameterTargets returns the parameter that the change names, by its
+// location and name, the first arguments of request parameter changes
+func getParameterTargets(change checker.ApiChange, specs [2]*openapi3.T, operations [2]*openapi3.Operation, pathItems [2]*openapi3.PathItem) []target {
+	if len(change.Args) < 2 {
+		return nil
+	}
+	in, name := fmt.Sprint(change.Args[0]), fmt.Sprint(change.Args[1])
+
+	t := target{
+		in:      in + " parameter " + name,
+		request: true,
+		specs:   specs,
+	}
+	for side := range specs {
+		parameter := operations[side].Parameters.GetByInAndName(in, name)
+		if parameter == nil {
+			parameter = pathItems[side].Parameters.GetByInAndName(in, name)
+		}
+		if parameter == nil || parameter.Schema == nil {
+			return nil
+		}
+		t.schemas[side] = parameter.Schema
+		t.validate[side] = validateParameter(specs[side], operations[side], parameter)
+	}
+	return []target{t}
+}
+
+var validationOptions = &openapi3filter.Options{
+	MultiError:          true,
+	SkipSettingDefaults: true,
+}
+
+func getRoute(spec *openapi3.T, operation *openapi3.Operation) *routers.Route {
+	return &routers.Route{Spec: spec, Operation: operation}
+}
+
+func validateRequestBody(spec *openapi3.T, operation *openapi3.Operation, requestBody *openapi3.RequestBody, mediaType string) func(value any) error {
+	return func(value any) error {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		request, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		request.Header.Set("Content-Type", mediaType)
+		return openapi3filter.ValidateRequestBody(context.Background(), &openapi3filter.RequestValidationInput{
+			Request: request,
+			Route:   getRoute(spec, operation),
+			Options: validationOptions,
+		}, requestBody)
+	}
+}
+
+// validateResponse validates the body of a response; its headers are left out
+func validateResponse(spec *openapi3.T, response *openapi3.Response, mediaType string) func(value any) error {
+	body := *response
+	body.Headers = nil
+	operation := &openapi3.Operation{
+		Responses: openapi3.NewResponses(openapi3.WithStatus(http.StatusOK, &openapi3.ResponseRef{Value: &body})),
+	}
+
+	return func(value any) error {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		request, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			return err
+		}
+		input := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{
+				Request: request,
+				Route:   getRoute(spec, operation),
+			},
+			Status:  http.StatusOK,
+			Header:  http.Header{"Content-Type": []string{mediaType}},
+			Options: validationOptions,
+		}
+		return openapi3filter.ValidateResponse(context.Background(), input.SetBodyBytes(data))
+	}
+}
+
+var errNotSerializable = errors.New("only primitive parameter values are generated")
+
+func validateParameter(spec *openapi3.T, operation *openapi3.Operation, parameter *openapi3.Parameter) func(value any) error {
+	return func(value any) error {
+		serialized, ok := serializeParameter(value)
+		if !ok {
+			return errNotSerializable
+		}
+
+		request, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			return err
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request: request,
+			Route:   getRoute(spec, operation),
+			Options: validationOptions,
+		}
+		switch parameter.In {
+		case openapi3.ParameterInPath:
+			input.PathParams = map[string]string{parameter.Name: serialized}
+		case openapi3.ParameterInQuery:
+			request.URL.RawQuery = url.Values{parameter.Name: []string{serialized}}.Encode()
+		case openapi3.ParameterInHeader:
+			request.Header.Set(parameter.Name, serialized)
+		case openapi3.ParameterInCookie:
+			request.AddCookie(&http.Cookie{Name: parameter.Name, Value: serialized})
+		}
+		return openapi3filter.ValidateParameter(context.Background(), input, parameter)
+	}
+}
+
+func serializeParameter(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// getFocus returns the path of the property that a property change names, by
+// the first of its arguments that is a property path in the base or in the
+// revision, e.g. address/zip, or items/name for the items of an array
+func (t target) getFocus(change checker.ApiChange) []string {
+	if !strings.Contains(change.Id, "-property-") || strings.HasPrefix(change.Id, "request-parameter-") {
+		return []string{}
+	}
+	for _, arg := range change.Args {
+		propertyPath, ok := arg.(string)
+		if !ok {
+			continue
+		}
+		path := strings.Split(strings.Trim(propertyPath, "/"), "/")
+		if resolves(t.schemas[baseSide], path) || resolves(t.schemas[revisionSide], path) {
+			return path
+		}
+	}
+	return []string{}
+}
+
+// resolves reports whether the path names a property of the schema, or of its subschemas
+func resolves(schemaRef *openapi3.SchemaRef, path []string) bool {
+	if len(path) == 0 {
+		return true
+	}
+	if schemaRef == nil || schemaRef.Value == nil {
+		return false
+	}
+	schema := schemaRef.Value
+
+	if property, ok := schema.Properties[path[0]]; ok && resolves(property, path[1:]) {
+		return true
+	}
+	if path[0] == "items" && schema.Items != nil && resolves(schema.Items, path[1:]) {
+		return true
+	}
+	for _, subschema := range slices.Concat(schema.AllOf, schema.AnyOf, schema.OneOf) {
+		if resolves(subschema, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// getReason returns why the payload was rejected at the focus: the reason of a
+// schema error at the focus or below it, or at its parent, naming it.
+// Missing properties are considered only if missing is true.
+func getReason(err error, focus []string, missing bool) (string, bool) {
+	rejections := getRejections(err)
+	if len(rejections) == 0 {
+		// rejected before the schema was applied, e.g. a missing body
+		return err.Error(), len(focus) == 0
+	}
+
+	focus = slices.DeleteFunc(slices.Clone(focus), func(segment string) bool { return segment == "items" })
+	for _, rejection := range rejections {
+		if rejection.missing && !missing {
+			continue
+		}
+		path := slices.DeleteFunc(slices.Clone(rejection.path), isIndex)
+		if isPrefix(focus, path) && (!rejection.missing || len(focus) == 0) ||
+			len(focus) > 0 && slices.Equal(path, focus[:len(focus)-1]) && names(rejection.reason, focus[len(focus)-1]) {
+			return "/" + strings.Join(rejection.path, "/") + ": " + rejection.reason, true
+		}
+	}
+	return "", false
+}
+
+// names reports whether the reason names the property, quoted
+func names(reason, property string) bool {
+	return strings.Contains(reason, strconv.Quote(property)) || strings.Contains(reason, "'"+property+"'")
+}
+
+// rejection is a reason why a payload was rejected, at a path in the payload
+type rejection struct {
+	path    []string
+	reason  string
+	missing bool
+}
+
+// jsonSchemaLocation matches the errors of the JSON Schema 2020-12 validator
+// that kin-openapi uses for OpenAPI 3.1, which are reported as text only
+var jsonSchemaLocation = regexp.MustCompile(`(?m)at '([^']*)': (.*)$`)
+
+func getRejections(err error) []rejection {
+	result := []rejection{}
+	for _, schemaError := range getSchemaErrors(err) {
+		if matches := jsonSchemaLocation.FindAllStringSubmatch(schemaError.Reason, -1); len(matches) > 0 {
+			for _, match := range matches {
+				result = append(result, rejection{
+					path:    splitPointer(match[1]),
+					reason:  match[2],
+					missing: strings.HasPrefix(match[2], "missing propert"),
+				})
+			}
+			continue
+		}
+
+		path := schemaError.JSONPointer()
+		if schemaError.SchemaField == "required" && len(path) > 0 {
+			// a missing property is reported at the object, as in OpenAPI 3.1
+			path = path[:len(path)-1]
+		}
+		result = append(result, rejection{
+			path:    path,
+			reason:  schemaError.Reason,
+			missing: schemaError.SchemaField == "required",
+		})
+	}
+	return result
+}
+
+// splitPointer returns the reference tokens of a JSON pointer
+func splitPointer(pointer string) []string {
+	if pointer == "" {
+		return []string{}
+	}
+	result := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range result {
+		result[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return result
+}
+
+func isIndex(segment string) bool {
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
+
+func getSchemaErrors(err error) []*openapi3.SchemaError {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *openapi3.SchemaError:
+		return []*openapi3.SchemaError{e}
+	case openapi3.MultiError:
+		result := []*openapi3.SchemaError{}
+		for _, err := range e {
+			result = append(result, getSchemaErrors(err)...)
+		}
+		return result
+	case *openapi3filter.RequestError:
+		return getSchemaErrors(e.Err)
+	case *openapi3filter.ResponseError:
+		return getSchemaErrors(e.Err)
+	}
+	return getSchemaErrors(errors.Unwrap(err))
+}
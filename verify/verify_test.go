@@ -0,0 +1,119 @@
+package verify_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/diff"
+	"github.com/oasdiff/oasdiff/load"
+	"github.com/oasdiff/oasdiff/verify"
+	"github.com/stretchr/testify/require"
+)
+
+func getVerifiedChanges(t *testing.T, base, revision string) checker.Changes {
+	t.Helper()
+	loader := openapi3.NewLoader()
+	s1, err := load.NewSpecInfo(loader, load.NewSource(base))
+	require.NoError(t, err)
+	s2, err := load.NewSpecInfo(loader, load.NewSource(revision))
+	require.NoError(t, err)
+	d, osm, err := diff.GetWithOperationsSourcesMap(diff.NewConfig(), s1, s2)
+	require.NoError(t, err)
+	changes := checker.CheckBackwardCompatibility(checker.NewConfig(checker.GetAllChecks()), d, osm)
+	return verify.Changes(changes, s1.Spec, s2.Spec)
+}
+
+func findChange(t *testing.T, changes checker.Changes, id, operation, path string) checker.Change {
+	t.Helper()
+	for _, change := range changes {
+		if change.GetId() == id && change.GetOperation() == operation && change.GetPath() == path {
+			return change
+		}
+	}
+	require.Failf(t, "change not found", "%s %s %s", id, operation, path)
+	return nil
+}
+
+func TestVerify_AllBreakingChangesVerified(t *testing.T) {
+	changes := getVerifiedChanges(t, "../data/verify/base.yaml", "../data/verify/revision.yaml")
+	require.Len(t, changes, 9)
+	for _, change := range changes {
+		require.NotNil(t, checker.GetVerification(change), change.GetId())
+	}
+}
+
+func TestVerify_RequestPropertyBecameRequired(t *testing.T) {
+	changes := getVerifiedChanges(t, "../data/verify/base.yaml", "../data/verify/revision.yaml")
+	require.Equal(t, &checker.Verification{
+		Confirmed:      true,
+		In:             "request body application/json",
+		Counterexample: `{"item":"a"}`,
+		Reason:         `/: property "quantity" is missing`,
+	}, checker.GetVerification(findChange(t, changes, "request-property-became-required", "POST", "/orders")))
+}
+
+func TestVerify_RequestPropertyMaxDecreased(t *testing.T) {
+	changes := getVerifiedChanges(t, "../data/verify/base.yaml", "../data/verify/revision.yaml")
+	verification := checker.GetVerification(findChange(t, changes, "request-property-max-decreased", "POST", "/orders"))
+	require.True(t, verification.Confirmed)
+	require.Equal(t, `{"item":"a","quantity":100}`, verification.Counterexample)
+}
+
+func TestVerify_RequestPropertyEnumValueRemoved(t *testing.T) {
+	changes := getVerifiedChanges(t, "../data/verify/base.yaml", "../data/verify/revision.yaml")
+	verification := checker.GetVerification(findChange(t, changes, "request-property-enum-value-removed", "POST", "/orders"))
+	require.True(t, verification.Confirmed)
+	require.Equal(t, `{"item":"a","priority":"low"}`, verification.Counterexample)
+}
+
+func TestVerify_RequestParameterMaxDecreased(t *testing.T) {
+	changes := getVerifiedChanges(t, "../data/verify/base.yaml", "../data/verify/revision.yaml")
+	verification := checker.GetVerification(findChange(t, changes, "request-parameter-max-decreased", "GET", "/orders"))
+	require.True(t, verification.Confirmed)
+	require.Equal(t, "query parameter limit", verification.In)
+	require.Equal(t, "100", verification.Counterexample)
+}
+
+func TestVerify_ResponsePropertyBecameOptional(t *testing.T) {
+	changes := getVerifiedChanges(t, "../data/verify/base.yaml", "../data/verify/revision.yaml")
+	verification := checker.GetVerification(findChange(t, changes, "response-property-became-optional", "POST", "/orders"))
+	require.True(t, verification.Confirmed)
+	require.Equal(t, "response 201 application/json", verification.In)
+	require.Equal(t, `{"id":"a"}`, verification.Counterexample)
+}
+
+func TestVerify_ResponsePropertyEnumValueAdded(t *testing.T) {
+	changes := getVerifiedChanges(t, "../data/verify/base.yaml", "../data/verify/revision.yaml")
+	verification := checker.GetVerification(findChange(t, changes, "response-property-enum-value-added", "GET", "/orders"))
+	require.True(t, verification.Confirmed)
+	require.Equal(t, `[{"id":"a","status":"cancelled"}]`, verification.Counterexample)
+}
+
+// the enum of the property accepts no values longer than the new max length
+func TestVerify_PossibleFalsePositive(t *testing.T) {
+	changes := getVerifiedChanges(t, "../data/verify/base.yaml", "../data/verify/revision.yaml")
+	require.Equal(t, &checker.Verification{
+		Confirmed: false,
+		In:        "request body application/json",
+	}, checker.GetVerification(findChange(t, changes, "request-property-max-length-decreased", "POST", "/orders")))
+}
+
+func TestVerify_OpenAPI31(t *testing.T) {
+	changes := getVerifiedChanges(t, "../data/checker/nullable_wrap_response_base.yaml", "../data/checker/nullable_wrap_response_revision.yaml")
+	require.NotEmpty(t, changes)
+	for _, change := range changes {
+		if change.GetId() == "response-property-became-nullable" {
+			require.True(t, checker.GetVerification(change).Confirmed)
+			return
+		}
+	}
+	require.Fail(t, "change not found")
+}
+
+func TestVerify_NonBreakingChangesNotVerified(t *testing.T) {
+	changes := verify.Changes(checker.Changes{
+		checker.ApiChange{Id: "response-property-became-required", Level: checker.INFO},
+	}, &openapi3.T{}, &openapi3.T{})
+	require.Nil(t, checker.GetVerification(changes[0]))
+}